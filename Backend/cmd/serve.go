@@ -3,25 +3,33 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"oasis/backend/config"
+	"oasis/backend/group"
 	"oasis/backend/guest"
 	"oasis/backend/housekeeping"
 	"oasis/backend/infra/db"
+	filestore "oasis/backend/infra/storage"
 	"oasis/backend/invoice"
 	"oasis/backend/laundry"
+	"oasis/backend/lostfound"
 	"oasis/backend/rag"
 	"oasis/backend/repository"
 	"oasis/backend/rest"
 	"oasis/backend/restaurant"
 	"oasis/backend/room"
 	"oasis/backend/staff"
+	"oasis/backend/storage"
 	"oasis/backend/ws"
 
+	attachmenthandler "oasis/backend/rest/handlers/attachment"
+	grouphandler "oasis/backend/rest/handlers/group"
 	guesthandler "oasis/backend/rest/handlers/guest"
 	housekeepinghandler "oasis/backend/rest/handlers/housekeeping"
 	invoicehandler "oasis/backend/rest/handlers/invoice"
 	laundryhandler "oasis/backend/rest/handlers/laundry"
+	lostfoundhandler "oasis/backend/rest/handlers/lostfound"
 	raghandler "oasis/backend/rest/handlers/rag"
 	restauranthandler "oasis/backend/rest/handlers/restaurant"
 	roomhandler "oasis/backend/rest/handlers/room"
@@ -58,18 +66,38 @@ func Serve() {
 	laundryRepo := repository.NewLaundryRepo(dbCon)
 	restaurantRepo := repository.NewRestaurantRepo(dbCon)
 	housekeepingRepo := repository.NewHousekeepingRepo(dbCon)
+	groupRepo := repository.NewGroupRepo(dbCon)
+	attachmentRepo := repository.NewAttachmentRepo(dbCon)
+	lostFoundRepo := repository.NewLostFoundRepo(dbCon)
+
+	// File store for photos (local disk; swap for an object store adapter in production)
+	fileStore, err := filestore.NewLocalStore(cnf.UploadDir)
+	if err != nil {
+		fmt.Println("Failed to prepare upload directory:", err)
+		os.Exit(1)
+	}
 
 	// 6. Initialize Services (Domain Logic)
-	guestSvc := guest.NewService(guestRepo)
-	staffSvc := staff.NewService(staffRepo, cnf.JwtSecretKey)
 	roomSvc := room.NewService(roomRepo)
-	laundrySvc := laundry.NewService(laundryRepo)
-	restaurantSvc := restaurant.NewService(restaurantRepo)
-	housekeepingSvc := housekeeping.NewService(housekeepingRepo, hub)
+	guestSvc := guest.NewService(guestRepo, roomSvc)
+	staffSvc := staff.NewService(staffRepo, cnf.JwtSecretKey)
+	laundrySvc := laundry.NewService(laundryRepo, hub)
+	restaurantSvc := restaurant.NewService(restaurantRepo, guestSvc, hub, time.Duration(cnf.KitchenLateMins)*time.Minute)
+	go restaurantSvc.RunTicketTimer(time.Minute) // Flag kitchen tickets past the threshold
+	storageSvc := storage.NewService(attachmentRepo, fileStore, cnf.JwtSecretKey)
+	housekeepingSvc := housekeeping.NewService(housekeepingRepo, hub, roomSvc, storageSvc)
+	go housekeepingSvc.RunTaskScheduler(15 * time.Minute) // Keep the task board in sync with room states
+	go housekeepingSvc.RunSLAChecker(time.Minute)         // Escalate maintenance tickets past their SLA
+	go housekeepingSvc.RunPreventiveScheduler(time.Hour)  // Open preventive maintenance tickets ahead of due dates
+	go housekeepingSvc.RunDNDWelfareCheck(15*time.Minute, time.Duration(cnf.DNDWelfareHours)*time.Hour)
+	groupSvc := group.NewService(groupRepo, roomSvc, guestSvc)
+	go groupSvc.RunCutoffReleaser(time.Hour) // Release unassigned group rooms past cutoff
+	lostFoundSvc := lostfound.NewService(lostFoundRepo, hub, roomSvc, guestSvc, storageSvc)
+	go lostFoundSvc.RunDisposalReminder(6 * time.Hour) // Remind staff of unclaimed items past retention
 
 	// Initialize Invoice Repository and Service
 	invoiceRepo := repository.NewInvoiceRepo(dbCon)
-	invoiceSvc := invoice.NewService(invoiceRepo, guestSvc, roomSvc, laundrySvc, restaurantSvc)
+	invoiceSvc := invoice.NewService(invoiceRepo, guestSvc, roomSvc, laundrySvc, restaurantSvc, groupSvc, housekeepingSvc)
 
 	// 7. Initialize Middlewares
 	middlewares := middleware.NewMiddlewares(cnf)
@@ -78,13 +106,16 @@ func Serve() {
 	ragSvc := rag.NewService(dbCon, cnf.OpenAIKey)
 
 	// 9. Initialize Handlers (Ports)
-	guestHandler := guesthandler.NewHandler(cnf, guestSvc)
+	guestHandler := guesthandler.NewHandler(cnf, middlewares, guestSvc)
 	staffHandler := staffhandler.NewHandler(cnf, staffSvc)
-	roomHandler := roomhandler.NewHandler(cnf, roomSvc)
+	roomHandler := roomhandler.NewHandler(cnf, middlewares, roomSvc)
 	laundryHandler := laundryhandler.NewHandler(middlewares, laundrySvc)
 	restaurantHandler := restauranthandler.NewHandler(middlewares, restaurantSvc)
 	housekeepingHandler := housekeepinghandler.NewHandler(middlewares, housekeepingSvc, hub)
 	invoiceHandler := invoicehandler.NewHandler(middlewares, invoiceSvc)
+	groupHandler := grouphandler.NewHandler(middlewares, groupSvc)
+	attachmentHandler := attachmenthandler.NewHandler(middlewares, storageSvc)
+	lostFoundHandler := lostfoundhandler.NewHandler(middlewares, lostFoundSvc)
 	ragHandler := raghandler.NewHandler(ragSvc)
 
 	// 10. Initialize Server
@@ -97,9 +128,11 @@ func Serve() {
 		restaurantHandler,
 		housekeepingHandler,
 		invoiceHandler,
+		groupHandler,
+		attachmentHandler,
+		lostFoundHandler,
 		ragHandler,
 	)
 
 	server.Start()
 }
-
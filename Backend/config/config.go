@@ -20,12 +20,15 @@ type DBConfig struct {
 }
 
 type Config struct {
-	Version      string
-	ServiceName  string
-	HttpPort     int
-	JwtSecretKey string
-	OpenAIKey    string
-	DB           *DBConfig
+	Version         string
+	ServiceName     string
+	HttpPort        int
+	JwtSecretKey    string
+	OpenAIKey       string
+	UploadDir       string // Local file store for photos
+	DNDWelfareHours int    // Raise a welfare check after a room is on DND this long
+	KitchenLateMins int    // Flag kitchen tickets older than this
+	DB              *DBConfig
 }
 
 func loadConfig() {
@@ -69,6 +72,31 @@ func loadConfig() {
 		os.Exit(1)
 	}
 
+	uploadDir := os.Getenv("UPLOAD_DIR")
+	if uploadDir == "" {
+		uploadDir = "./uploads"
+	}
+
+	dndWelfareHours := 24
+	if hours := os.Getenv("DND_WELFARE_HOURS"); hours != "" {
+		h, err := strconv.Atoi(hours)
+		if err != nil || h < 1 {
+			fmt.Println("DND welfare hours must be a positive number")
+			os.Exit(1)
+		}
+		dndWelfareHours = h
+	}
+
+	kitchenLateMins := 20
+	if mins := os.Getenv("KITCHEN_LATE_MINUTES"); mins != "" {
+		m, err := strconv.Atoi(mins)
+		if err != nil || m < 1 {
+			fmt.Println("Kitchen late minutes must be a positive number")
+			os.Exit(1)
+		}
+		kitchenLateMins = m
+	}
+
 	Host := os.Getenv("DB_HOST")
 	if Host == "" {
 		fmt.Println("Database host is required")
@@ -107,7 +135,7 @@ func loadConfig() {
 
 	enableSSLMode := os.Getenv("DB_ENABLE_SSL_MODE")
 
-	enblSSLMode ,err := strconv.ParseBool(enableSSLMode)
+	enblSSLMode, err := strconv.ParseBool(enableSSLMode)
 	if err != nil {
 		fmt.Println("Enable SSL Mode must be boolean")
 		os.Exit(1)
@@ -122,12 +150,15 @@ func loadConfig() {
 		EnableSSLMODE: enblSSLMode,
 	}
 	configurations = &Config{
-		Version:      version,
-		ServiceName:  ServiceName,
-		HttpPort:     int(port),
-		JwtSecretKey: jwtSecretKey,
-		OpenAIKey:    openAIKey,
-		DB:           dbConfig,
+		Version:         version,
+		ServiceName:     ServiceName,
+		HttpPort:        int(port),
+		JwtSecretKey:    jwtSecretKey,
+		OpenAIKey:       openAIKey,
+		UploadDir:       uploadDir,
+		DNDWelfareHours: dndWelfareHours,
+		KitchenLateMins: kitchenLateMins,
+		DB:              dbConfig,
 	}
 }
 
@@ -140,4 +171,3 @@ func GetConfig() *Config {
 	return configurations
 
 }
-
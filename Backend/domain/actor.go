@@ -0,0 +1,16 @@
+package domain
+
+// Roles used for actors that are not staff members
+const (
+	ActorRoleGuest  = "GUEST"
+	ActorRoleSystem = "SYSTEM"
+)
+
+// Actor identifies who performed an action (for audit trails)
+type Actor struct {
+	ID   int    `json:"id" db:"id"`
+	Role string `json:"role" db:"role"` // Staff role, GUEST or SYSTEM
+}
+
+// SystemActor is used for changes triggered by the backend itself (checkout, schedulers)
+var SystemActor = Actor{ID: 0, Role: ActorRoleSystem}
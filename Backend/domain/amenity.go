@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	AmenityStatusPending   = "PENDING"
+	AmenityStatusDelivered = "DELIVERED"
+	AmenityStatusPaid      = "PAID"
+
+	StockReasonDelivery   = "DELIVERY"
+	StockReasonRestock    = "RESTOCK"
+	StockReasonAdjustment = "ADJUSTMENT"
+)
+
+var (
+	ErrAmenityNotFound        = errors.New("amenity not found in catalog")
+	ErrAmenityLimitExceeded   = errors.New("per-stay limit reached for this amenity")
+	ErrAmenityOutOfStock      = errors.New("amenity is out of stock")
+	ErrAmenityRequestNotFound = errors.New("amenity request not found")
+	ErrAmenityAlreadyHandled  = errors.New("amenity request was already delivered")
+	ErrAmenityExists          = errors.New("amenity code already exists")
+	ErrInvalidStockChange     = errors.New("stock change must be a non-zero RESTOCK (positive) or ADJUSTMENT")
+)
+
+// AmenityItem is one entry of the amenity catalog
+type AmenityItem struct {
+	ID           int       `json:"id" db:"id"`
+	Code         string    `json:"code" db:"code"` // e.g. TOWEL
+	Name         string    `json:"name" db:"name"`
+	Category     string    `json:"category" db:"category"`             // LINEN, CONSUMABLE
+	PerStayLimit *int      `json:"per_stay_limit" db:"per_stay_limit"` // nil = unlimited
+	Price        float64   `json:"price" db:"price"`                   // > 0 is charged to the folio
+	StockOnHand  int       `json:"stock_on_hand" db:"stock_on_hand"`
+	ParLevel     int       `json:"par_level" db:"par_level"`
+	Active       bool      `json:"active" db:"active"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
+
+// IsLowStock reports whether the item has fallen to its par level
+func (a AmenityItem) IsLowStock() bool {
+	return a.StockOnHand <= a.ParLevel
+}
+
+// StockMovement is one row of the linen/consumables ledger
+type StockMovement struct {
+	ID               int       `json:"id" db:"id"`
+	ItemID           int       `json:"item_id" db:"item_id"`
+	Change           int       `json:"change" db:"change"`
+	Reason           string    `json:"reason" db:"reason"` // DELIVERY, RESTOCK, ADJUSTMENT
+	AmenityRequestID *int      `json:"amenity_request_id,omitempty" db:"amenity_request_id"`
+	StaffID          *int      `json:"staff_id,omitempty" db:"staff_id"`
+	Note             string    `json:"note,omitempty" db:"note"`
+	BalanceAfter     int       `json:"balance_after" db:"balance_after"`
+	CreatedAt        time.Time `json:"created_at" db:"created_at"`
+}
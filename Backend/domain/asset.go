@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+type RecurrenceUnit string
+
+const (
+	RecurrenceDay   RecurrenceUnit = "DAY"
+	RecurrenceWeek  RecurrenceUnit = "WEEK"
+	RecurrenceMonth RecurrenceUnit = "MONTH"
+)
+
+var (
+	ErrAssetNotFound    = errors.New("asset not found")
+	ErrScheduleNotFound = errors.New("maintenance schedule not found")
+	ErrInvalidSchedule  = errors.New("invalid maintenance schedule")
+)
+
+// Asset is a piece of equipment in a room or a public area
+type Asset struct {
+	ID           int        `json:"id" db:"id"`
+	Name         string     `json:"name" db:"name"`
+	Category     string     `json:"category" db:"category"` // AC, BOILER, ELEVATOR, ...
+	RoomNumber   *string    `json:"room_number,omitempty" db:"room_number"`
+	Location     string     `json:"location,omitempty" db:"location"` // Public areas
+	SerialNumber string     `json:"serial_number,omitempty" db:"serial_number"`
+	InstalledAt  *time.Time `json:"installed_at,omitempty" db:"installed_at"`
+	RetiredAt    *time.Time `json:"retired_at,omitempty" db:"retired_at"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+}
+
+// AssetDetail is an asset with its schedules and maintenance history
+type AssetDetail struct {
+	Asset
+	Schedules []MaintenanceSchedule `json:"schedules"`
+	Tickets   []MaintenanceTicket   `json:"tickets"`
+}
+
+// MaintenanceSchedule is a recurring preventive job against a room or an asset
+type MaintenanceSchedule struct {
+	ID              int            `json:"id" db:"id"`
+	AssetID         *int           `json:"asset_id,omitempty" db:"asset_id"`
+	RoomNumber      *string        `json:"room_number,omitempty" db:"room_number"`
+	Title           string         `json:"title" db:"title"`
+	IssueType       string         `json:"issue_type" db:"issue_type"`
+	Every           int            `json:"interval_count" db:"interval_count"`
+	Unit            RecurrenceUnit `json:"interval_unit" db:"interval_unit"`
+	LeadDays        int            `json:"lead_days" db:"lead_days"`
+	NextDueDate     time.Time      `json:"next_due_date" db:"next_due_date"`
+	BlocksRoom      bool           `json:"blocks_room" db:"blocks_room"`
+	BlockDays       int            `json:"block_days" db:"block_days"`
+	Active          bool           `json:"active" db:"active"`
+	LastGeneratedAt *time.Time     `json:"last_generated_at,omitempty" db:"last_generated_at"`
+	CreatedAt       time.Time      `json:"created_at" db:"created_at"`
+}
+
+// Validate checks the recurrence rule and the target
+func (s MaintenanceSchedule) Validate() error {
+	if s.AssetID == nil && (s.RoomNumber == nil || *s.RoomNumber == "") {
+		return fmt.Errorf("%w: an asset or a room is required", ErrInvalidSchedule)
+	}
+	if s.Title == "" || s.IssueType == "" {
+		return fmt.Errorf("%w: title and issue type are required", ErrInvalidSchedule)
+	}
+	if s.Every < 1 {
+		return fmt.Errorf("%w: interval must be at least 1", ErrInvalidSchedule)
+	}
+	switch s.Unit {
+	case RecurrenceDay, RecurrenceWeek, RecurrenceMonth:
+	default:
+		return fmt.Errorf("%w: interval unit must be DAY, WEEK or MONTH", ErrInvalidSchedule)
+	}
+	if s.LeadDays < 0 || s.BlockDays < 1 {
+		return fmt.Errorf("%w: lead days cannot be negative and block days must be at least 1", ErrInvalidSchedule)
+	}
+	return nil
+}
+
+// NextAfter returns the due date one interval after due
+func (s MaintenanceSchedule) NextAfter(due time.Time) time.Time {
+	switch s.Unit {
+	case RecurrenceWeek:
+		return due.AddDate(0, 0, 7*s.Every)
+	case RecurrenceMonth:
+		return due.AddDate(0, s.Every, 0)
+	default:
+		return due.AddDate(0, 0, s.Every)
+	}
+}
+
+// DueSchedule is a schedule whose ticket must be generated now, with its resolved target
+type DueSchedule struct {
+	MaintenanceSchedule
+	TargetRoom string `db:"target_room"` // Schedule room or the asset's room, "" for public areas
+	AssetName  string `db:"asset_name"`
+	Location   string `db:"location"`
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"errors"
+	"io"
+	"time"
+)
+
+// Owners an attachment can belong to
+const (
+	AttachmentOwnerTicket   = "MAINTENANCE_TICKET"
+	AttachmentOwnerLostItem = "LOST_ITEM"
+)
+
+// Variants of a stored photo
+const (
+	AttachmentOriginal  = "original"
+	AttachmentThumbnail = "thumb"
+)
+
+var (
+	ErrAttachmentNotFound   = errors.New("attachment not found")
+	ErrAttachmentTooLarge   = errors.New("file is too large")
+	ErrUnsupportedMediaType = errors.New("unsupported file type: only JPEG, PNG and GIF images are accepted")
+	ErrInvalidSignature     = errors.New("download link is invalid or has expired")
+)
+
+// Attachment is a file stored in the file store and linked to a ticket, a lost item...
+type Attachment struct {
+	ID           int       `json:"id" db:"id"`
+	OwnerType    string    `json:"owner_type" db:"owner_type"`
+	OwnerID      int       `json:"owner_id" db:"owner_id"`
+	FileName     string    `json:"file_name" db:"file_name"`
+	ContentType  string    `json:"content_type" db:"content_type"`
+	SizeBytes    int64     `json:"size_bytes" db:"size_bytes"`
+	StorageKey   string    `json:"-" db:"storage_key"`
+	ThumbnailKey *string   `json:"-" db:"thumbnail_key"`
+	UploadedBy   *int      `json:"uploaded_by,omitempty" db:"uploaded_by"`
+	UploaderRole string    `json:"uploader_role" db:"uploader_role"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+
+	// Signed, short-lived download links (filled in on read)
+	URL          string `json:"url" db:"-"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty" db:"-"`
+}
+
+// Upload is a file received from a client, before it is validated and stored
+type Upload struct {
+	FileName string
+	Data     io.Reader
+}
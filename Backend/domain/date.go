@@ -0,0 +1,9 @@
+package domain
+
+import "time"
+
+// Today truncates the current time to a date, like the DATE columns in the database
+func Today() time.Time {
+	y, m, d := time.Now().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	GroupStatusActive = "ACTIVE"
+	GroupStatusClosed = "CLOSED"
+
+	BlockStatusHeld     = "HELD"
+	BlockStatusAssigned = "ASSIGNED"
+	BlockStatusReleased = "RELEASED"
+
+	ChargeTypeRoom       = "ROOM"
+	ChargeTypeRestaurant = "RESTAURANT"
+	ChargeTypeLaundry    = "LAUNDRY"
+)
+
+var (
+	ErrGroupNotFound     = errors.New("group not found")
+	ErrGroupClosed       = errors.New("group is closed")
+	ErrRoomNotInBlock    = errors.New("room is not held in this group's block")
+	ErrGroupCutoffPassed = errors.New("group cutoff date has passed")
+)
+
+// BookingGroup is a wedding/conference booking with a master folio
+type BookingGroup struct {
+	ID                     int       `json:"id" db:"id"`
+	Name                   string    `json:"name" db:"name"`
+	ContactName            string    `json:"contact_name" db:"contact_name"`
+	ContactPhone           string    `json:"contact_phone" db:"contact_phone"`
+	CheckInDate            time.Time `json:"check_in_date" db:"check_in_date"`
+	CheckOutDate           time.Time `json:"check_out_date" db:"check_out_date"`
+	CutoffDate             time.Time `json:"cutoff_date" db:"cutoff_date"`
+	BillRoomToMaster       bool      `json:"bill_room_to_master" db:"bill_room_to_master"`
+	BillRestaurantToMaster bool      `json:"bill_restaurant_to_master" db:"bill_restaurant_to_master"`
+	BillLaundryToMaster    bool      `json:"bill_laundry_to_master" db:"bill_laundry_to_master"`
+	Status                 string    `json:"status" db:"status"`
+	CreatedAt              time.Time `json:"created_at" db:"created_at"`
+}
+
+// GroupRoomBlock is one room held for a group
+type GroupRoomBlock struct {
+	ID         int        `json:"id" db:"id"`
+	GroupID    int        `json:"group_id" db:"group_id"`
+	RoomNumber string     `json:"room_number" db:"room_number"`
+	GuestID    *int       `json:"guest_id,omitempty" db:"guest_id"`
+	Status     string     `json:"status" db:"status"` // HELD, ASSIGNED, RELEASED
+	ReleasedAt *time.Time `json:"released_at,omitempty" db:"released_at"`
+}
+
+type GroupWithBlocks struct {
+	BookingGroup
+	Blocks []GroupRoomBlock `json:"blocks"`
+}
+
+// GroupFolioEntry is a member charge routed to the master folio
+type GroupFolioEntry struct {
+	ID          int       `json:"id" db:"id"`
+	GroupID     int       `json:"group_id" db:"group_id"`
+	GuestID     int       `json:"guest_id" db:"guest_id"`
+	ChargeType  string    `json:"charge_type" db:"charge_type"` // ROOM, RESTAURANT, LAUNDRY
+	Description string    `json:"description" db:"description"`
+	Amount      float64   `json:"amount" db:"amount"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+type GroupFolio struct {
+	Group   BookingGroup      `json:"group"`
+	Entries []GroupFolioEntry `json:"entries"`
+	Total   float64           `json:"total"`
+}
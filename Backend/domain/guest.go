@@ -1,14 +1,68 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	GuestStatusCheckedIn  = "CHECKED_IN"
+	GuestStatusCheckedOut = "CHECKED_OUT"
+)
+
+var (
+	ErrGuestNotFound     = errors.New("guest not found")
+	ErrGuestNotCheckedIn = errors.New("guest is not checked in")
+	ErrRoomUnavailable   = errors.New("room is not available for these dates")
+	ErrInvalidStayDates  = errors.New("invalid stay dates")
+)
 
 type Guest struct {
-	ID           int       `json:"id" db:"id"`
-	Name         string    `json:"name" db:"name"`
-	PhoneNumber  string    `json:"phone_number" db:"phone_number"`
-	RoomNumber   string    `json:"room_number" db:"room_number"`
-	CheckInDate  time.Time `json:"check_in_date" db:"check_in_date"`
-	CheckOutDate time.Time `json:"check_out_date" db:"check_out_date"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	ID           int            `json:"id" db:"id"`
+	Name         string         `json:"name" db:"name"`
+	PhoneNumber  string         `json:"phone_number" db:"phone_number"`
+	RoomNumber   string         `json:"room_number" db:"room_number"`
+	CheckInDate  time.Time      `json:"check_in_date" db:"check_in_date"`
+	CheckOutDate time.Time      `json:"check_out_date" db:"check_out_date"`
+	Status       string         `json:"status" db:"status"` // CHECKED_IN, CHECKED_OUT
+	GroupID      *int           `json:"group_id,omitempty" db:"group_id"`
+	Allergies    pq.StringArray `json:"allergies" db:"allergies"` // Allergens from the menu list, checked when ordering food
+	CreatedAt    time.Time      `json:"created_at" db:"created_at"`
 }
 
+// Reasons a stay segment was opened
+const (
+	StaySegmentCheckIn   = "CHECK_IN"
+	StaySegmentRoomMove  = "ROOM_MOVE"
+	StaySegmentExtension = "EXTENSION"
+)
+
+// StaySegment is a run of consecutive nights in one room at one rate
+type StaySegment struct {
+	ID          int       `json:"id" db:"id"`
+	GuestID     int       `json:"guest_id" db:"guest_id"`
+	RoomNumber  string    `json:"room_number" db:"room_number"`
+	NightlyRate float64   `json:"nightly_rate" db:"nightly_rate"`
+	StartDate   time.Time `json:"start_date" db:"start_date"`
+	EndDate     time.Time `json:"end_date" db:"end_date"`
+	Reason      string    `json:"reason" db:"reason"`
+}
+
+// Nights counts the room-nights covered by the segment
+func (s StaySegment) Nights() int {
+	return int(s.EndDate.Sub(s.StartDate).Hours() / 24)
+}
+
+// RoomMove carries everything the repository needs to transfer a stay atomically
+type RoomMove struct {
+	GuestID      int
+	FromRoom     string
+	ToRoom       string
+	MoveDate     time.Time
+	CheckOutDate time.Time
+	NightlyRate  float64
+	Actor        Actor
+	Reason       string
+}
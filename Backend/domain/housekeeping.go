@@ -4,29 +4,51 @@ import "time"
 
 // AmenityRequest (e.g., "I need 2 Towels")
 type AmenityRequest struct {
-	ID         int       `json:"id" db:"id"`
-	GuestID    int       `json:"guest_id" db:"guest_id"`
-	RoomNumber string    `json:"room_number" db:"room_number"`
-	ItemName   string    `json:"item_name" db:"item_name"`
-	Quantity   int       `json:"quantity" db:"quantity"`
-	Status     string    `json:"status" db:"status"` // PENDING, DELIVERED
-	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	ID          int        `json:"id" db:"id"`
+	GuestID     int        `json:"guest_id" db:"guest_id"`
+	RoomNumber  string     `json:"room_number" db:"room_number"`
+	ItemID      *int       `json:"item_id,omitempty" db:"item_id"` // Catalog item (nil for legacy free-text requests)
+	ItemName    string     `json:"item_name" db:"item_name"`
+	Quantity    int        `json:"quantity" db:"quantity"`
+	UnitPrice   float64    `json:"unit_price" db:"unit_price"`
+	TotalPrice  float64    `json:"total_price" db:"total_price"`
+	Status      string     `json:"status" db:"status"` // PENDING, DELIVERED, PAID
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	DeliveredAt *time.Time `json:"delivered_at,omitempty" db:"delivered_at"`
 }
 
 // MaintenanceTicket (e.g., "Broken AC")
 type MaintenanceTicket struct {
-	ID          int       `json:"id" db:"id"`
-	RoomNumber  string    `json:"room_number" db:"room_number"`
-	IssueType   string    `json:"issue_type" db:"issue_type"`
-	Description string    `json:"description" db:"description"`
-	Priority    string    `json:"priority" db:"priority"`
-	Status      string    `json:"status" db:"status"`
-	ReportedAt  time.Time `json:"created_at" db:"created_at"`
+	ID              int            `json:"id" db:"id"`
+	RoomNumber      string         `json:"room_number" db:"room_number"`
+	IssueType       string         `json:"issue_type" db:"issue_type"`
+	Description     string         `json:"description" db:"description"`
+	Priority        TicketPriority `json:"priority" db:"priority"`
+	Status          TicketStatus   `json:"status" db:"status"`
+	ReportedAt      time.Time      `json:"created_at" db:"created_at"`
+	AssignedTo      *int           `json:"assigned_to,omitempty" db:"assigned_to"` // Engineer
+	AssignedAt      *time.Time     `json:"assigned_at,omitempty" db:"assigned_at"`
+	StartedAt       *time.Time     `json:"started_at,omitempty" db:"started_at"`
+	OnHoldReason    string         `json:"on_hold_reason,omitempty" db:"on_hold_reason"`
+	SLADueAt        time.Time      `json:"sla_due_at" db:"sla_due_at"`
+	EscalatedAt     *time.Time     `json:"escalated_at,omitempty" db:"escalated_at"`
+	ResolutionNotes string         `json:"resolution_notes,omitempty" db:"resolution_notes"`
+	ResolvedBy      *int           `json:"resolved_by,omitempty" db:"resolved_by"`
+	ResolvedAt      *time.Time     `json:"resolved_at,omitempty" db:"resolved_at"`
+	ScheduleID      *int           `json:"schedule_id,omitempty" db:"schedule_id"` // Preventive maintenance
+	AssetID         *int           `json:"asset_id,omitempty" db:"asset_id"`
+	BlocksRoom      bool           `json:"blocks_room" db:"blocks_room"` // Room is out of order until resolved
+	PartsUsed       []TicketPart   `json:"parts_used,omitempty" db:"-"`
+	Photos          []Attachment   `json:"photos,omitempty" db:"-"`
+	PhotoErrors     []string       `json:"photo_errors,omitempty" db:"-"` // Photos rejected when the ticket was reported
 }
 
 // RoomsStatus for the Live Map
 type RoomsStatus struct {
-	RoomNumber string `json:"room_number" db:"room_number"`
-	Status     string `json:"status" db:"housekeeping_status"` // CLEAN, DIRTY, REQUESTED_CLEANING
+	RoomNumber string             `json:"room_number" db:"room_number"`
+	Occupancy  RoomStatus         `json:"occupancy" db:"status"`           // VACANT, OCCUPIED
+	Status     HousekeepingStatus `json:"status" db:"housekeeping_status"` // CLEAN, DIRTY, REQUESTED_CLEANING...
+	DNDSince   *time.Time         `json:"dnd_since,omitempty" db:"dnd_since"`
+	WindowFrom string             `json:"cleaning_window_start,omitempty" db:"window_start"`
+	WindowTo   string             `json:"cleaning_window_end,omitempty" db:"window_end"`
 }
-
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+type TaskType string
+
+const (
+	TaskCheckoutClean TaskType = "CHECKOUT_CLEAN"
+	TaskStayoverClean TaskType = "STAYOVER_CLEAN"
+	TaskTurndown      TaskType = "TURNDOWN"
+	TaskInspection    TaskType = "INSPECTION"
+)
+
+type TaskStatus string
+
+const (
+	TaskStatusPending    TaskStatus = "PENDING"
+	TaskStatusAssigned   TaskStatus = "ASSIGNED"
+	TaskStatusInProgress TaskStatus = "IN_PROGRESS"
+	TaskStatusDone       TaskStatus = "DONE"
+	TaskStatusCancelled  TaskStatus = "CANCELLED"
+)
+
+var (
+	ErrTaskNotFound    = errors.New("housekeeping task not found")
+	ErrTaskNotYours    = errors.New("task is not assigned to you")
+	ErrTaskWrongStatus = errors.New("task cannot be updated in its current status")
+)
+
+// CreditWeight is the share of the room type's cleaning credits a task costs
+func (t TaskType) CreditWeight() float64 {
+	switch t {
+	case TaskCheckoutClean:
+		return 1.0
+	case TaskStayoverClean:
+		return 0.5
+	default: // Turndown, Inspection
+		return 0.25
+	}
+}
+
+// IsCleaning reports whether the task moves the room through CLEANING -> CLEAN
+func (t TaskType) IsCleaning() bool {
+	return t == TaskCheckoutClean || t == TaskStayoverClean
+}
+
+// HousekeepingTask is one job on an attendant's board
+type HousekeepingTask struct {
+	ID         int        `json:"id" db:"id"`
+	RoomNumber string     `json:"room_number" db:"room_number"`
+	Type       TaskType   `json:"task_type" db:"task_type"`
+	TaskDate   time.Time  `json:"task_date" db:"task_date"`
+	Credits    float64    `json:"credits" db:"credits"`
+	Status     TaskStatus `json:"status" db:"status"`
+	AssignedTo *int       `json:"assigned_to,omitempty" db:"assigned_to"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty" db:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty" db:"finished_at"`
+
+	// Guest's preferred cleaning window ("14:00"), empty when any time is fine
+	WindowStart string `json:"window_start,omitempty" db:"window_start"`
+	WindowEnd   string `json:"window_end,omitempty" db:"window_end"`
+}
+
+// TaskCandidate is a room as seen by the task generator
+type TaskCandidate struct {
+	RoomNumber      string  `db:"room_number"`
+	RoomState               // status, housekeeping_status
+	CleaningCredits float64 `db:"cleaning_credits"`
+	Stayover        bool    `db:"stayover"` // Occupied by a guest who arrived before today
+	WindowStart     string  `db:"window_start"`
+	WindowEnd       string  `db:"window_end"`
+}
+
+// AttendantLoad is an on-shift staff member with the credits already assigned to them
+type AttendantLoad struct {
+	StaffID int     `db:"id"`
+	Name    string  `db:"name"`
+	Credits float64 `db:"credits"`
+}
+
+// AttendantWorkload is one row of the workload board
+type AttendantWorkload struct {
+	StaffID         int     `json:"staff_id" db:"staff_id"`
+	Name            string  `json:"name" db:"name"`
+	Role            string  `json:"role" db:"role"`
+	OnShift         bool    `json:"on_shift" db:"on_shift"`
+	AssignedCredits float64 `json:"assigned_credits" db:"assigned_credits"`
+	TotalTasks      int     `json:"total_tasks" db:"total_tasks"`
+	DoneTasks       int     `json:"done_tasks" db:"done_tasks"`
+	AvgCleanMinutes float64 `json:"avg_clean_minutes" db:"avg_clean_minutes"` // Average start -> finish of cleaning tasks
+}
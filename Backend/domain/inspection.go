@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInspectionRequired  = errors.New("INSPECTED can only be set by submitting an inspection checklist")
+	ErrChecklistIncomplete = errors.New("every checklist item must be answered")
+	ErrFailNoteRequired    = errors.New("failed checklist items need a note")
+)
+
+// ChecklistItem is one line of a room type's inspection checklist
+type ChecklistItem struct {
+	ID          int    `json:"id" db:"id"`
+	RoomTypeID  int    `json:"room_type_id" db:"room_type_id"`
+	Area        string `json:"area" db:"area"` // BATHROOM, LINEN, MINIBAR, ...
+	Description string `json:"description" db:"description"`
+	SortOrder   int    `json:"sort_order" db:"sort_order"`
+}
+
+// InspectionResult is the supervisor's answer for one checklist item
+type InspectionResult struct {
+	ChecklistItemID int    `json:"checklist_item_id" db:"checklist_item_id"`
+	Area            string `json:"area,omitempty" db:"area"`
+	Description     string `json:"description,omitempty" db:"description"`
+	Passed          bool   `json:"passed" db:"passed"`
+	Note            string `json:"note,omitempty" db:"note"` // Required when the item failed
+}
+
+// RoomInspection is a completed supervisor check of a cleaned room
+type RoomInspection struct {
+	ID             int                `json:"id" db:"id"`
+	RoomNumber     string             `json:"room_number" db:"room_number"`
+	CleaningTaskID *int               `json:"cleaning_task_id,omitempty" db:"cleaning_task_id"`
+	AttendantID    *int               `json:"attendant_id,omitempty" db:"attendant_id"`
+	InspectorID    int                `json:"inspector_id" db:"inspector_id"`
+	Passed         bool               `json:"passed" db:"passed"`
+	Notes          string             `json:"notes" db:"notes"`
+	InspectedAt    time.Time          `json:"inspected_at" db:"inspected_at"`
+	Results        []InspectionResult `json:"results" db:"-"`
+}
+
+// AttendantPassRate is one row of the inspection pass-rate report
+type AttendantPassRate struct {
+	StaffID     int     `json:"staff_id" db:"staff_id"`
+	Name        string  `json:"name" db:"name"`
+	Inspections int     `json:"inspections" db:"inspections"`
+	Passed      int     `json:"passed" db:"passed"`
+	PassRate    float64 `json:"pass_rate" db:"pass_rate"` // 0-100
+}
@@ -1,25 +1,64 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrLaundryUnconfirmed blocks checkout: those requests cannot be billed, and the guest cannot confirm after leaving
+	ErrLaundryUnconfirmed = errors.New("laundry counts are waiting for the guest's confirmation")
+	// ErrLaundryInProcess blocks checkout: PAID only follows DELIVERED, so bags still out must be delivered or cancelled first
+	ErrLaundryInProcess = errors.New("laundry is still being processed, deliver or cancel it before checkout")
+	// ErrRestaurantInProcess blocks checkout: PAID only follows DELIVERED, so orders still in the kitchen or on the way wait
+	ErrRestaurantInProcess = errors.New("restaurant orders are still open, deliver or cancel them before checkout")
+)
 
 type Invoice struct {
-	ID               int       `json:"id" db:"id"`
-	GuestID          int       `json:"guest_id" db:"guest_id"`
-	RoomNumber       string    `json:"room_number" db:"room_number"`
-	RoomCharge       float64   `json:"room_charge" db:"room_charge"`
-	LaundryCharge    float64   `json:"laundry_charge" db:"laundry_charge"`
-	RestaurantCharge float64   `json:"restaurant_charge" db:"restaurant_charge"`
-	TotalAmount      float64   `json:"total_amount" db:"total_amount"`
-	PaymentMethod    string    `json:"payment_method" db:"payment_method"`
-	CreatedAt        time.Time `json:"created_at" db:"created_at"`
+	ID               int               `json:"id" db:"id"`
+	GuestID          int               `json:"guest_id" db:"guest_id"`
+	RoomNumber       string            `json:"room_number" db:"room_number"`
+	RoomSegments     []RoomNightLine   `json:"room_segments" db:"-"`
+	RoomCharge       float64           `json:"room_charge" db:"room_charge"`
+	LaundryCharge    float64           `json:"laundry_charge" db:"laundry_charge"`
+	RestaurantCharge float64           `json:"restaurant_charge" db:"restaurant_charge"`
+	AmenityCharge    float64           `json:"amenity_charge" db:"amenity_charge"` // Chargeable amenities (water, slippers...)
+	TotalAmount      float64           `json:"total_amount" db:"total_amount"`     // Paid by the guest
+	GroupID          *int              `json:"group_id,omitempty" db:"group_id"`
+	GroupCharge      float64           `json:"group_charge" db:"group_charge"` // Routed to the group master folio
+	GroupEntries     []GroupFolioEntry `json:"group_entries,omitempty" db:"-"`
+	PaymentMethod    string            `json:"payment_method" db:"payment_method"`
+	CreatedAt        time.Time         `json:"created_at" db:"created_at"`
 }
 
 // Helper for the Frontend Preview
 type InvoicePreview struct {
-	GuestName        string  `json:"guest_name"`
-	StayDays         int     `json:"stay_days"`
-	RoomTotal        float64 `json:"room_total"`
-	LaundryTotal     float64 `json:"laundry_total"`
-	RestaurantTotal  float64 `json:"restaurant_total"`
-	GrandTotal       float64 `json:"grand_total"`
+	GuestName          string            `json:"guest_name"`
+	RoomNumber         string            `json:"room_number"`
+	StayDays           int               `json:"stay_days"`
+	RoomSegments       []RoomNightLine   `json:"room_segments"`
+	RoomTotal          float64           `json:"room_total"`
+	LaundryTotal       float64           `json:"laundry_total"`
+	LaundryUnconfirmed int               `json:"laundry_unconfirmed,omitempty"` // Requests left off the bill until the guest confirms the count
+	LaundryInProcess   int               `json:"laundry_in_process,omitempty"`  // Requests not delivered yet; checkout waits for them
+	RestaurantTotal    float64           `json:"restaurant_total"`
+	RestaurantComps    float64           `json:"restaurant_comps,omitempty"` // Comped and voided lines, already left out of RestaurantTotal
+	RestaurantOpen     int               `json:"restaurant_open,omitempty"`  // Orders not delivered yet; checkout waits for them
+	Amenities          []AmenityRequest  `json:"amenities,omitempty"`        // Chargeable amenities delivered during the stay
+	AmenityTotal       float64           `json:"amenity_total"`
+	GroupName          string            `json:"group_name,omitempty"`
+	GroupCharges       []GroupFolioEntry `json:"group_charges,omitempty"` // Charges billed to the master folio
+	GroupBilled        float64           `json:"group_billed"`
+	GrandTotal         float64           `json:"grand_total"` // What the guest pays
+}
+
+// RoomNightLine is one room-night segment as shown on the bill
+type RoomNightLine struct {
+	RoomNumber  string    `json:"room_number"`
+	Reason      string    `json:"reason"` // CHECK_IN, ROOM_MOVE, EXTENSION
+	StartDate   time.Time `json:"start_date"`
+	EndDate     time.Time `json:"end_date"`
+	Nights      int       `json:"nights"`
+	NightlyRate float64   `json:"nightly_rate"`
+	Total       float64   `json:"total"`
 }
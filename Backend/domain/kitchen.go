@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidStation    = errors.New("station must be GRILL, COLD or BAR")
+	ErrOrderNotFound     = errors.New("order not found")
+	ErrOrderItemNotFound = errors.New("order line not found")
+)
+
+// Kitchen stations an order line is routed to
+const (
+	StationGrill = "GRILL" // Hot line
+	StationCold  = "COLD"  // Salads, cold starters, desserts
+	StationBar   = "BAR"   // Drinks
+)
+
+// Order line states on the kitchen display
+const (
+	OrderItemPending = "PENDING"
+	OrderItemReady   = "READY" // Bumped by the station
+)
+
+var stations = []string{StationGrill, StationCold, StationBar}
+
+// KitchenStations lists the stations lines can be routed to
+func KitchenStations() []string {
+	return append([]string(nil), stations...)
+}
+
+// ValidStation tells whether s is a known station
+func ValidStation(s string) bool {
+	return containsTag(stations, s)
+}
+
+// NormalizeStation checks the item's station override; an empty one falls back to the category
+func (m *RestaurantMenuItem) NormalizeStation() error {
+	if m.Station == nil {
+		return nil
+	}
+	if *m.Station == "" {
+		m.Station = nil
+		return nil
+	}
+	if !ValidStation(*m.Station) {
+		return ErrInvalidStation
+	}
+	return nil
+}
+
+// ForStation keeps only the lines routed to the station (false if none are)
+func (o OrderWithItems) ForStation(station string) (OrderWithItems, bool) {
+	lines := []OrderItemDetail{}
+	for _, item := range o.Items {
+		if item.Station == station {
+			lines = append(lines, item)
+		}
+	}
+	o.Items = lines
+	return o, len(lines) > 0
+}
+
+// AllReady tells whether every line of the order has been bumped (voided lines are not made)
+func (o OrderWithItems) AllReady() bool {
+	made := 0
+	for _, item := range o.Items {
+		if item.Voided() {
+			continue
+		}
+		if item.Status != OrderItemReady {
+			return false
+		}
+		made++
+	}
+	return made > 0
+}
+
+// AllVoided tells whether the kitchen has nothing left to make for the order
+func (o OrderWithItems) AllVoided() bool {
+	for _, item := range o.Items {
+		if !item.Voided() {
+			return false
+		}
+	}
+	return len(o.Items) > 0
+}
+
+// StampTimer sets the ticket age, and flags it late once it is older than lateAfter
+func (o *OrderWithItems) StampTimer(now time.Time, lateAfter time.Duration) {
+	age := now.Sub(o.CreatedAt)
+	o.AgeMinutes = int(age.Minutes())
+	o.Late = lateAfter > 0 && age >= lateAfter
+}
@@ -1,29 +1,230 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LaundryStatus is a step in the laundry lifecycle:
+// PENDING -> COLLECTED -> WASHING -> READY -> DELIVERED -> PAID.
+// A request can only be cancelled before the bag is collected.
+type LaundryStatus string
+
+const (
+	LaundryStatusPending   LaundryStatus = "PENDING"   // Guest asked for a pickup
+	LaundryStatusCollected LaundryStatus = "COLLECTED" // Bag picked up from the room
+	LaundryStatusWashing   LaundryStatus = "WASHING"   // Items counted and being cleaned
+	LaundryStatusReady     LaundryStatus = "READY"     // Clean, waiting to go back
+	LaundryStatusDelivered LaundryStatus = "DELIVERED" // Returned to the room
+	LaundryStatusPaid      LaundryStatus = "PAID"      // Settled on the checkout invoice
+	LaundryStatusCancelled LaundryStatus = "CANCELLED"
+)
+
+// laundryTransitions lists the moves staff can make. PAID is only set by the invoice.
+var laundryTransitions = map[LaundryStatus][]LaundryStatus{
+	LaundryStatusPending:   {LaundryStatusCollected, LaundryStatusCancelled},
+	LaundryStatusCollected: {LaundryStatusWashing},
+	LaundryStatusWashing:   {LaundryStatusReady},
+	LaundryStatusReady:     {LaundryStatusDelivered},
+}
+
+var (
+	ErrLaundryRequestNotFound   = errors.New("laundry request not found")
+	ErrInvalidLaundryStatus     = errors.New("invalid laundry status")
+	ErrIllegalLaundryTransition = errors.New("laundry request cannot move to that status")
+	ErrLaundryRequestPaid       = errors.New("laundry request is already paid")
+	ErrLaundryRequestClosed     = errors.New("laundry request can no longer be changed")
+	ErrLaundryStatusChanged     = errors.New("laundry request was updated by someone else, reload and retry")
+
+	ErrLaundryItemNotFound    = errors.New("laundry item not found")
+	ErrLaundryItemUnavailable = errors.New("laundry item is not on the menu")
+	ErrInvalidLaundryItem     = errors.New("laundry item needs a name, and price and turnaround must not be negative")
+	ErrInvalidLaundryCategory = errors.New("category must be WASH_FOLD, DRY_CLEAN or PRESSING")
+	ErrInvalidLaundryQuantity = errors.New("item quantity must be positive")
+	ErrNoCountToConfirm       = errors.New("laundry count is not waiting for confirmation")
+	ErrInvalidLaundryTag      = errors.New("not a laundry bag tag")
+	ErrInvalidTagFormat       = errors.New("tag format must be svg or png")
+)
+
+// Valid reports whether s is one of the known lifecycle states
+func (s LaundryStatus) Valid() bool {
+	switch s {
+	case LaundryStatusPending, LaundryStatusCollected, LaundryStatusWashing, LaundryStatusReady,
+		LaundryStatusDelivered, LaundryStatusPaid, LaundryStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// CanTransitionTo reports whether staff may move a request from s to next
+func (s LaundryStatus) CanTransitionTo(next LaundryStatus) bool {
+	for _, allowed := range laundryTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOpen reports whether the bag has not been returned (or cancelled) yet
+func (s LaundryStatus) IsOpen() bool {
+	return s == LaundryStatusPending || s == LaundryStatusCollected || s == LaundryStatusWashing || s == LaundryStatusReady
+}
+
+// laundryStages is the order a bag moves through the laundry, used by tag scans
+var laundryStages = []LaundryStatus{
+	LaundryStatusPending, LaundryStatusCollected, LaundryStatusWashing, LaundryStatusReady, LaundryStatusDelivered,
+}
+
+// NextStage returns the step after s (false once the bag is delivered, paid or cancelled)
+func (s LaundryStatus) NextStage() (LaundryStatus, bool) {
+	for i := 0; i < len(laundryStages)-1; i++ {
+		if laundryStages[i] == s {
+			return laundryStages[i+1], true
+		}
+	}
+	return "", false
+}
+
+// laundryTagPrefix marks bag tags so a scan of some other barcode is rejected
+const laundryTagPrefix = "LND-"
+
+// LaundryTagCode is the printable tag of a request, e.g. LND-000123
+func LaundryTagCode(reqID int) string {
+	return fmt.Sprintf("%s%06d", laundryTagPrefix, reqID)
+}
+
+// ParseLaundryTag reads the request ID back from a scanned tag
+func ParseLaundryTag(code string) (int, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if !strings.HasPrefix(code, laundryTagPrefix) {
+		return 0, ErrInvalidLaundryTag
+	}
+	id, err := strconv.Atoi(strings.TrimPrefix(code, laundryTagPrefix))
+	if err != nil || id <= 0 {
+		return 0, ErrInvalidLaundryTag
+	}
+	return id, nil
+}
+
+// AcceptsItems reports whether the bag can still be counted and priced
+func (s LaundryStatus) AcceptsItems() bool {
+	return s == LaundryStatusPending || s == LaundryStatusCollected
+}
+
+// Laundry menu categories (how the item is processed)
+const (
+	LaundryCategoryWashFold = "WASH_FOLD"
+	LaundryCategoryDryClean = "DRY_CLEAN"
+	LaundryCategoryPressing = "PRESSING"
+)
+
+// LaundryCategory describes a category and the turnaround new items get by default
+type LaundryCategory struct {
+	Code            string `json:"code"`
+	Label           string `json:"label"`
+	TurnaroundHours int    `json:"turnaround_hours"`
+}
+
+var laundryCategories = []LaundryCategory{
+	{Code: LaundryCategoryWashFold, Label: "Wash & fold", TurnaroundHours: 8},
+	{Code: LaundryCategoryDryClean, Label: "Dry clean", TurnaroundHours: 24},
+	{Code: LaundryCategoryPressing, Label: "Pressing", TurnaroundHours: 4},
+}
+
+// LaundryCategories lists the categories in menu order
+func LaundryCategories() []LaundryCategory {
+	categories := make([]LaundryCategory, len(laundryCategories))
+	copy(categories, laundryCategories)
+	return categories
+}
+
+// FindLaundryCategory returns a category by code (false if the code is unknown)
+func FindLaundryCategory(code string) (LaundryCategory, bool) {
+	for _, c := range laundryCategories {
+		if c.Code == code {
+			return c, true
+		}
+	}
+	return LaundryCategory{}, false
+}
 
 // MenuItem represents a specific clothing item available for cleaning
 type MenuItem struct {
-	ID         int     `json:"id" db:"id"`
-	Name       string  `json:"name" db:"name"`
-	Price      float64 `json:"price" db:"price"`
-	IsDryClean bool    `json:"is_dry_clean" db:"is_dry_clean"`
+	ID              int     `json:"id" db:"id"`
+	Name            string  `json:"name" db:"name"`
+	Price           float64 `json:"price" db:"price"`
+	IsDryClean      bool    `json:"is_dry_clean" db:"is_dry_clean"` // Kept in sync with Category for older clients
+	Category        string  `json:"category" db:"category"`
+	TurnaroundHours int     `json:"turnaround_hours" db:"turnaround_hours"`
+	IsAvailable     bool    `json:"is_available" db:"is_available"`
+	IsDeleted       bool    `json:"-" db:"is_deleted"`
+}
+
+// Validate checks a menu item before it is saved, filling in the category's default turnaround
+func (m *MenuItem) Validate() error {
+	m.Name = strings.TrimSpace(m.Name)
+	if m.Name == "" || m.Price < 0 || m.TurnaroundHours < 0 {
+		return ErrInvalidLaundryItem
+	}
+	category, ok := FindLaundryCategory(m.Category)
+	if !ok {
+		return ErrInvalidLaundryCategory
+	}
+	if m.TurnaroundHours == 0 {
+		m.TurnaroundHours = category.TurnaroundHours
+	}
+	m.IsDryClean = m.Category == LaundryCategoryDryClean
+	return nil
 }
 
 // ServiceRequest represents a Guest's order to pick up laundry
 type ServiceRequest struct {
-	ID         int       `json:"id" db:"id"`
-	GuestID    int       `json:"guest_id" db:"guest_id"`
-	RoomNumber string    `json:"room_number" db:"room_number"`
-	Notes      string    `json:"notes" db:"notes"`
-	Status     string    `json:"status" db:"status"` // PENDING, COLLECTED, WASHING, DELIVERED
-	TotalPrice float64   `json:"total_price" db:"total_price"`
-	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	ID         int           `json:"id" db:"id"`
+	TagCode    string        `json:"tag_code" db:"tag_code"` // Printed on the bag tag
+	GuestID    int           `json:"guest_id" db:"guest_id"`
+	RoomNumber string        `json:"room_number" db:"room_number"`
+	Notes      string        `json:"notes" db:"notes"`
+	Status     LaundryStatus `json:"status" db:"status"`
+	TotalPrice float64       `json:"total_price" db:"total_price"` // Items plus surcharge
+	CreatedAt  time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt  *time.Time    `json:"updated_at,omitempty" db:"updated_at"`
+
+	ServiceLevel    LaundryServiceLevel `json:"service_level" db:"service_level"`
+	SurchargePct    float64             `json:"surcharge_pct" db:"surcharge_pct"`
+	SurchargeAmount float64             `json:"surcharge_amount" db:"surcharge_amount"`
+	PickupFrom      *time.Time          `json:"pickup_from,omitempty" db:"pickup_from"`
+	PickupTo        *time.Time          `json:"pickup_to,omitempty" db:"pickup_to"`
+	PromisedAt      *time.Time          `json:"promised_at,omitempty" db:"promised_at"`
+
+	AwaitingConfirmation bool       `json:"awaiting_confirmation" db:"awaiting_confirmation"` // Count differs from the declaration
+	CountConfirmedAt     *time.Time `json:"count_confirmed_at,omitempty" db:"count_confirmed_at"`
+	CountDispute         string     `json:"count_dispute,omitempty" db:"count_dispute"`
+
+	// Filled when a single request is loaded
+	Events        []LaundryEvent       `json:"events,omitempty" db:"-"`
+	Declared      []DeclaredItem       `json:"declared_items,omitempty" db:"-"`
+	Items         []RequestItem        `json:"items,omitempty" db:"-"`
+	Discrepancies []LaundryDiscrepancy `json:"discrepancies,omitempty" db:"-"`
+}
+
+// LaundryEvent records one status change of a request, with who made it and when
+type LaundryEvent struct {
+	ID         int           `json:"id" db:"id"`
+	RequestID  int           `json:"request_id" db:"request_id"`
+	FromStatus LaundryStatus `json:"from_status" db:"from_status"`
+	ToStatus   LaundryStatus `json:"to_status" db:"to_status"`
+	ActorID    int           `json:"actor_id" db:"actor_id"`
+	ActorRole  string        `json:"actor_role" db:"actor_role"`
+	CreatedAt  time.Time     `json:"created_at" db:"created_at"`
 }
 
 // ... existing structs ...
 
-// RequestItem represents a specific line item (e.g., 3 Shirts)
+// RequestItem represents a specific line item (e.g., 3 Shirts) as counted by staff
 type RequestItem struct {
 	ID        int     `json:"id" db:"id"`
 	RequestID int     `json:"request_id" db:"request_id"`
@@ -31,16 +232,67 @@ type RequestItem struct {
 	ItemName  string  `json:"item_name" db:"name"` // Joined from items table
 	Quantity  int     `json:"quantity" db:"quantity"`
 	SnapPrice float64 `json:"snap_price" db:"snap_price"`
+	Notes     string  `json:"notes,omitempty" db:"notes"` // Damage or stains found when counting
+}
+
+// DeclaredItem is what the guest said was in the bag
+type DeclaredItem struct {
+	RequestID int    `json:"request_id" db:"request_id"`
+	ItemID    int    `json:"item_id" db:"item_id"`
+	ItemName  string `json:"item_name" db:"name"`
+	Quantity  int    `json:"quantity" db:"quantity"`
+}
+
+// LaundryDiscrepancy is an item whose count does not match the declaration
+type LaundryDiscrepancy struct {
+	ItemID   int    `json:"item_id"`
+	ItemName string `json:"item_name"`
+	Declared int    `json:"declared"`
+	Counted  int    `json:"counted"`
+}
+
+// FindDiscrepancies compares the declaration with the count, per menu item.
+// Without a declaration there is nothing to compare against.
+func FindDiscrepancies(declared []DeclaredItem, counted []RequestItem) []LaundryDiscrepancy {
+	if len(declared) == 0 {
+		return nil
+	}
+
+	byItem := make(map[int]*LaundryDiscrepancy)
+	var order []int
+	line := func(itemID int, name string) *LaundryDiscrepancy {
+		d, ok := byItem[itemID]
+		if !ok {
+			d = &LaundryDiscrepancy{ItemID: itemID, ItemName: name}
+			byItem[itemID] = d
+			order = append(order, itemID)
+		}
+		return d
+	}
+	for _, item := range declared {
+		line(item.ItemID, item.ItemName).Declared += item.Quantity
+	}
+	for _, item := range counted {
+		line(item.ItemID, item.ItemName).Counted += item.Quantity
+	}
+
+	var result []LaundryDiscrepancy
+	for _, id := range order {
+		if d := byItem[id]; d.Declared != d.Counted {
+			result = append(result, *d)
+		}
+	}
+	return result
 }
 
 // AddItemInput represents a single item being added to a request
 type AddItemInput struct {
-	ItemID   int `json:"item_id"`
-	Quantity int `json:"quantity"`
+	ItemID   int    `json:"item_id"`
+	Quantity int    `json:"quantity"`
+	Notes    string `json:"notes,omitempty"` // Staff count only: damage or stain notes
 }
 
 // AddItemsPayload is what the Frontend sends to the Backend
 type AddItemsPayload struct {
 	Items []AddItemInput `json:"items"`
 }
-
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+type LaundryServiceLevel string
+
+const (
+	LaundryServiceStandard LaundryServiceLevel = "STANDARD" // Back the next evening
+	LaundryServiceSameDay  LaundryServiceLevel = "SAME_DAY" // Back the same evening if collected before the cut-off
+	LaundryServiceExpress  LaundryServiceLevel = "EXPRESS"  // Back within 4 hours
+)
+
+var (
+	ErrInvalidServiceLevel = errors.New("service level must be STANDARD, SAME_DAY or EXPRESS")
+	ErrInvalidPickupSlot   = errors.New("pickup slot must end after it starts and not be in the past")
+)
+
+// LaundryServiceRule holds the price and cut-off rules of a service level.
+// Timed levels (TurnaroundHours > 0) are promised a fixed time after pickup;
+// the others come back on ReturnHour, ReturnDays after the pickup day.
+type LaundryServiceRule struct {
+	Level           LaundryServiceLevel `json:"level"`
+	Label           string              `json:"label"`
+	SurchargePct    float64             `json:"surcharge_pct"`
+	CutoffHour      int                 `json:"cutoff_hour"` // Pickups from this hour roll over to the next day
+	OpenHour        int                 `json:"open_hour"`   // Timed levels: earliest start of the turnaround
+	TurnaroundHours int                 `json:"turnaround_hours,omitempty"`
+	ReturnDays      int                 `json:"return_days"`
+	ReturnHour      int                 `json:"return_hour,omitempty"`
+}
+
+var laundryServiceRules = []LaundryServiceRule{
+	{Level: LaundryServiceStandard, Label: "Standard", SurchargePct: 0, CutoffHour: 12, ReturnDays: 1, ReturnHour: 18},
+	{Level: LaundryServiceSameDay, Label: "Same day", SurchargePct: 50, CutoffHour: 10, ReturnDays: 0, ReturnHour: 18},
+	{Level: LaundryServiceExpress, Label: "Express (4 hours)", SurchargePct: 100, CutoffHour: 16, OpenHour: 7, TurnaroundHours: 4},
+}
+
+// LaundryServiceRules lists the levels guests can choose from
+func LaundryServiceRules() []LaundryServiceRule {
+	rules := make([]LaundryServiceRule, len(laundryServiceRules))
+	copy(rules, laundryServiceRules)
+	return rules
+}
+
+// FindLaundryServiceRule returns the rule of a level (false if the level is unknown)
+func FindLaundryServiceRule(level LaundryServiceLevel) (LaundryServiceRule, bool) {
+	for _, rule := range laundryServiceRules {
+		if rule.Level == level {
+			return rule, true
+		}
+	}
+	return LaundryServiceRule{}, false
+}
+
+// PromisedBy calculates when a bag picked up at the given time is due back
+func (r LaundryServiceRule) PromisedBy(pickup time.Time) time.Time {
+	day := time.Date(pickup.Year(), pickup.Month(), pickup.Day(), 0, 0, 0, 0, pickup.Location())
+
+	if r.TurnaroundHours > 0 {
+		start := pickup
+		open := day.Add(time.Duration(r.OpenHour) * time.Hour)
+		if start.Before(open) {
+			start = open
+		}
+		if start.Hour() >= r.CutoffHour {
+			start = open.AddDate(0, 0, 1)
+		}
+		return start.Add(time.Duration(r.TurnaroundHours) * time.Hour)
+	}
+
+	if pickup.Hour() >= r.CutoffHour {
+		day = day.AddDate(0, 0, 1)
+	}
+	return day.AddDate(0, 0, r.ReturnDays).Add(time.Duration(r.ReturnHour) * time.Hour)
+}
+
+// LaundrySurcharge is the extra charged on top of the items subtotal, rounded to cents
+func LaundrySurcharge(subtotal, pct float64) float64 {
+	return math.Round(subtotal*pct) / 100
+}
+
+// LaundryRequestInput is what a guest sends when asking for a pickup
+type LaundryRequestInput struct {
+	RoomNumber   string              `json:"room_number"`
+	Notes        string              `json:"notes"`
+	ServiceLevel LaundryServiceLevel `json:"service_level"` // Defaults to STANDARD
+	PickupFrom   *time.Time          `json:"pickup_from,omitempty"`
+	PickupTo     *time.Time          `json:"pickup_to,omitempty"`
+	Items        []AddItemInput      `json:"items,omitempty"` // Optional declaration of what is in the bag
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+type LostItemStatus string
+
+const (
+	LostItemStored   LostItemStatus = "STORED"
+	LostItemReturned LostItemStatus = "RETURNED"
+	LostItemDisposed LostItemStatus = "DISPOSED"
+)
+
+// Item categories, which decide how long an item is kept
+const (
+	LostCategoryValuables   = "VALUABLES"
+	LostCategoryElectronics = "ELECTRONICS"
+	LostCategoryDocuments   = "DOCUMENTS"
+	LostCategoryClothing    = "CLOTHING"
+	LostCategoryPerishable  = "PERISHABLE"
+	LostCategoryOther       = "OTHER"
+)
+
+// How an unclaimed item left the hotel
+const (
+	DisposalDonated   = "DONATED"
+	DisposalDiscarded = "DISCARDED"
+	DisposalPolice    = "POLICE" // Handed to the authorities (documents, valuables)
+)
+
+var (
+	ErrLostItemNotFound     = errors.New("lost item not found")
+	ErrLostItemClosed       = errors.New("item was already returned or disposed of")
+	ErrInvalidLostCategory  = errors.New("invalid lost item category")
+	ErrInvalidDisposal      = errors.New("disposal method must be DONATED, DISCARDED or POLICE")
+	ErrRetentionNotOver     = errors.New("item is still within its retention period")
+	ErrReturnRecipientEmpty = errors.New("returned_to is required when no guest was matched")
+)
+
+// retentionDays is how long each category is kept before it can be disposed of
+var retentionDays = map[string]int{
+	LostCategoryValuables:   180,
+	LostCategoryElectronics: 180,
+	LostCategoryDocuments:   180,
+	LostCategoryClothing:    90,
+	LostCategoryPerishable:  1,
+	LostCategoryOther:       90,
+}
+
+// RetentionDays returns the retention period of a category (false if the category is unknown)
+func RetentionDays(category string) (int, bool) {
+	days, ok := retentionDays[category]
+	return days, ok
+}
+
+// IsValidDisposal checks the disposal method
+func IsValidDisposal(method string) bool {
+	return method == DisposalDonated || method == DisposalDiscarded || method == DisposalPolice
+}
+
+// LostItem is one entry of the lost-and-found log
+type LostItem struct {
+	ID              int            `json:"id" db:"id"`
+	RoomNumber      string         `json:"room_number" db:"room_number"`
+	FoundOn         time.Time      `json:"found_on" db:"found_on"`
+	FoundBy         *int           `json:"found_by,omitempty" db:"found_by"`
+	Description     string         `json:"description" db:"description"`
+	Category        string         `json:"category" db:"category"`
+	StorageLocation string         `json:"storage_location" db:"storage_location"`
+	GuestID         *int           `json:"guest_id,omitempty" db:"guest_id"` // Matched from the room's stay history
+	GuestName       string         `json:"guest_name,omitempty" db:"guest_name"`
+	GuestPhone      string         `json:"guest_phone,omitempty" db:"guest_phone"`
+	Status          LostItemStatus `json:"status" db:"status"`
+	RetainUntil     time.Time      `json:"retain_until" db:"retain_until"`
+	ReturnedTo      string         `json:"returned_to,omitempty" db:"returned_to"`
+	ReturnedAt      *time.Time     `json:"returned_at,omitempty" db:"returned_at"`
+	DisposalMethod  string         `json:"disposal_method,omitempty" db:"disposal_method"`
+	DisposedAt      *time.Time     `json:"disposed_at,omitempty" db:"disposed_at"`
+	HandledBy       *int           `json:"handled_by,omitempty" db:"handled_by"`
+	Notes           string         `json:"notes,omitempty" db:"notes"`
+	ReminderSentAt  *time.Time     `json:"reminder_sent_at,omitempty" db:"reminder_sent_at"`
+	CreatedAt       time.Time      `json:"created_at" db:"created_at"`
+	Photos          []Attachment   `json:"photos,omitempty" db:"-"`
+	PhotoErrors     []string       `json:"photo_errors,omitempty" db:"-"`
+}
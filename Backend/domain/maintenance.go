@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+type TicketStatus string
+
+const (
+	TicketStatusOpen       TicketStatus = "OPEN"
+	TicketStatusInProgress TicketStatus = "IN_PROGRESS"
+	TicketStatusOnHold     TicketStatus = "ON_HOLD" // e.g. waiting for parts
+	TicketStatusResolved   TicketStatus = "RESOLVED"
+)
+
+type TicketPriority string
+
+const (
+	PriorityUrgent TicketPriority = "URGENT"
+	PriorityHigh   TicketPriority = "HIGH"
+	PriorityNormal TicketPriority = "NORMAL"
+	PriorityLow    TicketPriority = "LOW"
+)
+
+var (
+	ErrTicketNotFound          = errors.New("maintenance ticket not found")
+	ErrIllegalTicketTransition = errors.New("illegal ticket status change")
+	ErrNotAnEngineer           = errors.New("tickets can only be assigned to engineers")
+	ErrResolutionRequired      = errors.New("resolution notes are required")
+)
+
+// issuePriorities maps what the guest/staff reported to how fast it must be fixed.
+// Unknown issue types are NORMAL.
+var issuePriorities = map[string]TicketPriority{
+	"NO_WATER":  PriorityUrgent,
+	"NO_POWER":  PriorityUrgent,
+	"GAS_LEAK":  PriorityUrgent,
+	"DOOR_LOCK": PriorityUrgent,
+	"FLOOD":     PriorityUrgent,
+	"LEAK":      PriorityHigh,
+	"PLUMBING":  PriorityHigh,
+	"ELECTRIC":  PriorityHigh,
+	"AC":        PriorityHigh,
+	"HEATING":   PriorityHigh,
+	"HOT_WATER": PriorityHigh,
+	"WIFI":      PriorityNormal,
+	"LIGHT":     PriorityNormal,
+	"TV":        PriorityLow,
+	"FURNITURE": PriorityLow,
+	"COSMETIC":  PriorityLow,
+}
+
+// PriorityForIssue derives the ticket priority from its issue type
+func PriorityForIssue(issueType string) TicketPriority {
+	key := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(issueType), " ", "_"))
+	if p, ok := issuePriorities[key]; ok {
+		return p
+	}
+	return PriorityNormal
+}
+
+// SLA is how long a ticket of this priority may stay unresolved
+func (p TicketPriority) SLA() time.Duration {
+	switch p {
+	case PriorityUrgent:
+		return time.Hour
+	case PriorityHigh:
+		return 4 * time.Hour
+	case PriorityLow:
+		return 72 * time.Hour
+	default:
+		return 24 * time.Hour
+	}
+}
+
+var ticketTransitions = map[TicketStatus][]TicketStatus{
+	TicketStatusOpen:       {TicketStatusInProgress, TicketStatusOnHold, TicketStatusResolved},
+	TicketStatusInProgress: {TicketStatusOnHold, TicketStatusResolved},
+	TicketStatusOnHold:     {TicketStatusInProgress, TicketStatusResolved},
+}
+
+// CanTransitionTo reports whether a ticket may move from s to next
+func (s TicketStatus) CanTransitionTo(next TicketStatus) bool {
+	for _, allowed := range ticketTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// TicketPart is a spare part used to fix a ticket
+type TicketPart struct {
+	ID       int     `json:"id" db:"id"`
+	TicketID int     `json:"ticket_id" db:"ticket_id"`
+	PartName string  `json:"part_name" db:"part_name"`
+	Quantity int     `json:"quantity" db:"quantity"`
+	UnitCost float64 `json:"unit_cost" db:"unit_cost"`
+}
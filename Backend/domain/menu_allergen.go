@@ -0,0 +1,193 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrInvalidAllergen   = errors.New("unknown allergen")
+	ErrInvalidDietaryTag = errors.New("unknown dietary tag")
+	ErrAllergenConflict  = errors.New("order contains allergens listed on the guest profile")
+)
+
+// Allergens a dish can contain (and a guest can be allergic to)
+const (
+	AllergenGluten    = "GLUTEN"
+	AllergenNuts      = "NUTS" // Tree nuts
+	AllergenPeanuts   = "PEANUTS"
+	AllergenDairy     = "DAIRY"
+	AllergenEggs      = "EGGS"
+	AllergenShellfish = "SHELLFISH"
+	AllergenFish      = "FISH"
+	AllergenSoy       = "SOY"
+	AllergenSesame    = "SESAME"
+)
+
+// Dietary tags a dish can be labelled with
+const (
+	DietVegetarian = "VEGETARIAN"
+	DietVegan      = "VEGAN"
+	DietHalal      = "HALAL"
+	DietKosher     = "KOSHER"
+)
+
+var allergens = []string{
+	AllergenGluten, AllergenNuts, AllergenPeanuts, AllergenDairy, AllergenEggs,
+	AllergenShellfish, AllergenFish, AllergenSoy, AllergenSesame,
+}
+
+var dietaryTags = []string{DietVegetarian, DietVegan, DietHalal, DietKosher}
+
+// Allergens lists the allergens the menu knows about
+func Allergens() []string {
+	return append([]string(nil), allergens...)
+}
+
+// DietaryTags lists the dietary tags the menu knows about
+func DietaryTags() []string {
+	return append([]string(nil), dietaryTags...)
+}
+
+// NormalizeAllergens upper-cases, de-duplicates and sorts the list, rejecting unknown allergens
+func NormalizeAllergens(tags []string) ([]string, error) {
+	return normalizeTags(tags, allergens, ErrInvalidAllergen)
+}
+
+// NormalizeDietaryTags does the same for dietary tags
+func NormalizeDietaryTags(tags []string) ([]string, error) {
+	return normalizeTags(tags, dietaryTags, ErrInvalidDietaryTag)
+}
+
+func normalizeTags(tags, known []string, errUnknown error) ([]string, error) {
+	seen := make(map[string]bool, len(tags))
+	out := []string{}
+	for _, t := range tags {
+		t = strings.ToUpper(strings.TrimSpace(t))
+		if t == "" || seen[t] {
+			continue
+		}
+		if !containsTag(known, t) {
+			return nil, fmt.Errorf("%w: %s", errUnknown, t)
+		}
+		seen[t] = true
+		out = append(out, t)
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeTags cleans the item's allergen and dietary tags before it is saved
+func (m *RestaurantMenuItem) NormalizeTags() error {
+	a, err := NormalizeAllergens(m.Allergens)
+	if err != nil {
+		return err
+	}
+	d, err := NormalizeDietaryTags(m.Dietary)
+	if err != nil {
+		return err
+	}
+	m.Allergens, m.Dietary = a, d
+	return nil
+}
+
+// AllergensIn returns the allergies (from a guest profile) that the item contains
+func (m RestaurantMenuItem) AllergensIn(allergies []string) []string {
+	found := []string{}
+	for _, a := range allergies {
+		if containsTag(m.Allergens, a) {
+			found = append(found, a)
+		}
+	}
+	return found
+}
+
+// MenuFilter narrows GET /restaurant/menu down.
+// An item passes when it contains none of ExcludeAllergens and carries every tag in Diets.
+type MenuFilter struct {
+	ExcludeAllergens []string
+	Diets            []string
+	GuestID          int  // Set for a logged-in guest: items are annotated with the guest's allergies
+	SafeForGuest     bool // Also exclude the guest's own allergies
+}
+
+// Allows tells whether the item passes the filter
+func (f MenuFilter) Allows(m RestaurantMenuItem) bool {
+	if len(m.AllergensIn(f.ExcludeAllergens)) > 0 {
+		return false
+	}
+	for _, d := range f.Diets {
+		if !containsTag(m.Dietary, d) {
+			return false
+		}
+	}
+	return true
+}
+
+// AllergenConflict is one order line that contains something the guest is allergic to
+type AllergenConflict struct {
+	Line      int      `json:"line"`
+	ItemID    int      `json:"item_id"`
+	Name      string   `json:"name"`
+	Allergens []string `json:"allergens"`
+}
+
+// AllergenWarning asks the guest to confirm an order that conflicts with their allergies.
+// Re-sending the order with the acknowledgement places it, flagged for the kitchen.
+type AllergenWarning struct {
+	Conflicts []AllergenConflict `json:"conflicts"`
+}
+
+func (e *AllergenWarning) Error() string {
+	return fmt.Sprintf("%s: %d line(s)", ErrAllergenConflict, len(e.Conflicts))
+}
+
+func (e *AllergenWarning) Unwrap() error {
+	return ErrAllergenConflict
+}
+
+// FindAllergenConflicts checks each order line against the guest's allergies.
+// Items missing from menu are skipped; the order validation reports those.
+func FindAllergenConflicts(items []RestaurantOrderItemInput, menu map[int]RestaurantMenuItem, allergies []string) []AllergenConflict {
+	var conflicts []AllergenConflict
+	if len(allergies) == 0 {
+		return conflicts
+	}
+	for i, item := range items {
+		m, ok := menu[item.ItemID]
+		if !ok {
+			continue
+		}
+		if found := m.AllergensIn(allergies); len(found) > 0 {
+			conflicts = append(conflicts, AllergenConflict{Line: i, ItemID: m.ID, Name: m.Name, Allergens: found})
+		}
+	}
+	return conflicts
+}
+
+// ConflictingAllergens merges the allergens of all conflicts, sorted
+func ConflictingAllergens(conflicts []AllergenConflict) []string {
+	seen := map[string]bool{}
+	out := []string{}
+	for _, c := range conflicts {
+		for _, a := range c.Allergens {
+			if !seen[a] {
+				seen[a] = true
+				out = append(out, a)
+			}
+		}
+	}
+	sort.Strings(out)
+	return out
+}
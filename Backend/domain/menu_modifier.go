@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrMenuItemNotFound     = errors.New("menu item not found")
+	ErrInvalidModifierGroup = errors.New("modifier group needs a name, options and 0 <= min_select <= max_select (max at least 1)")
+)
+
+// ModifierGroup is a choice attached to a menu item, e.g. "Doneness" (pick exactly one) or "Extras" (up to three)
+type ModifierGroup struct {
+	ID         int              `json:"id" db:"id"`
+	MenuItemID int              `json:"menu_item_id" db:"menu_item_id"`
+	Name       string           `json:"name" db:"name"`
+	Required   bool             `json:"required" db:"-"` // Same as min_select > 0
+	MinSelect  int              `json:"min_select" db:"min_select"`
+	MaxSelect  int              `json:"max_select" db:"max_select"`
+	Position   int              `json:"position" db:"position"`
+	Options    []ModifierOption `json:"options" db:"-"`
+}
+
+// ModifierOption is one pick inside a group; PriceDelta is added to the item price per unit
+type ModifierOption struct {
+	ID          int     `json:"id" db:"id"`
+	GroupID     int     `json:"group_id" db:"group_id"`
+	Name        string  `json:"name" db:"name"`
+	PriceDelta  float64 `json:"price_delta" db:"price_delta"`
+	IsAvailable bool    `json:"is_available" db:"is_available"`
+	Position    int     `json:"position" db:"position"`
+}
+
+// Normalize fills Required/MinSelect from each other and checks the limits
+func (g *ModifierGroup) Normalize() error {
+	g.Name = strings.TrimSpace(g.Name)
+	if g.Required && g.MinSelect == 0 {
+		g.MinSelect = 1
+	}
+	if g.MaxSelect == 0 {
+		g.MaxSelect = 1
+	}
+	g.Required = g.MinSelect > 0
+	if g.Name == "" || len(g.Options) == 0 || g.MinSelect < 0 || g.MinSelect > g.MaxSelect || g.MinSelect > len(g.Options) {
+		return ErrInvalidModifierGroup
+	}
+	for i := range g.Options {
+		g.Options[i].Name = strings.TrimSpace(g.Options[i].Name)
+		if g.Options[i].Name == "" {
+			return ErrInvalidModifierGroup
+		}
+	}
+	return nil
+}
+
+// OrderItemModifier is a modifier as it was chosen on an order line (names and price copied)
+type OrderItemModifier struct {
+	OrderItemID int     `json:"-" db:"order_item_id"`
+	OptionID    *int    `json:"option_id,omitempty" db:"option_id"`
+	GroupName   string  `json:"group_name" db:"group_name"`
+	OptionName  string  `json:"option_name" db:"option_name"`
+	PriceDelta  float64 `json:"price_delta" db:"price_delta"`
+}
+
+// chooseModifiers checks the picked options against the item's groups.
+// It returns the chosen modifiers, or the line error reason and message.
+func chooseModifiers(item RestaurantMenuItem, optionIDs []int) ([]OrderItemModifier, string, string) {
+	type owner struct {
+		group  *ModifierGroup
+		option ModifierOption
+	}
+	options := make(map[int]owner)
+	for gi := range item.ModifierGroups {
+		g := &item.ModifierGroups[gi]
+		for _, o := range g.Options {
+			options[o.ID] = owner{group: g, option: o}
+		}
+	}
+
+	picked := make(map[int]int) // group ID -> number of picks
+	seen := make(map[int]bool)
+	var chosen []OrderItemModifier
+	for _, id := range optionIDs {
+		o, ok := options[id]
+		if !ok || seen[id] {
+			return nil, OrderLineInvalidModifier, "modifier does not belong to " + item.Name
+		}
+		if !o.option.IsAvailable {
+			return nil, OrderLineInvalidModifier, o.option.Name + " is not available right now"
+		}
+		seen[id] = true
+		picked[o.group.ID]++
+		optionID := o.option.ID
+		chosen = append(chosen, OrderItemModifier{
+			OptionID:   &optionID,
+			GroupName:  o.group.Name,
+			OptionName: o.option.Name,
+			PriceDelta: o.option.PriceDelta,
+		})
+	}
+
+	for _, g := range item.ModifierGroups {
+		n := picked[g.ID]
+		if n < g.MinSelect {
+			return nil, OrderLineModifierCount, "choose " + g.Name + " for " + item.Name
+		}
+		if n > g.MaxSelect {
+			return nil, OrderLineModifierCount, "too many choices for " + g.Name
+		}
+	}
+	return chosen, "", ""
+}
@@ -0,0 +1,171 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrInvalidServicePeriod = errors.New("service period needs a name, HH:MM start and end times that differ, and days from 0 (Sunday) to 6 (Saturday)")
+	ErrCategoryNotFound     = errors.New("menu category not found")
+)
+
+// RestockHour is when items served all day come back after being 86'd
+const RestockHour = 6
+
+// ServicePeriod is a window in which a category (or a single item) can be ordered, e.g. breakfast 06:00-11:00.
+// An End before Start runs past midnight (late night 22:00-02:00); Days are the days the period starts on.
+type ServicePeriod struct {
+	ID         int           `json:"id" db:"id"`
+	CategoryID *int          `json:"category_id,omitempty" db:"category_id"`
+	MenuItemID *int          `json:"menu_item_id,omitempty" db:"menu_item_id"`
+	Name       string        `json:"name" db:"name"`
+	Start      string        `json:"start" db:"starts_at"` // "06:00"
+	End        string        `json:"end" db:"ends_at"`     // "11:00"
+	Days       pq.Int64Array `json:"days" db:"days"`       // 0 = Sunday ... 6 = Saturday; empty = every day
+}
+
+// Validate checks the times and days and tidies the name
+func (p *ServicePeriod) Validate() error {
+	p.Name = strings.TrimSpace(p.Name)
+	start, err1 := time.Parse("15:04", p.Start)
+	end, err2 := time.Parse("15:04", p.End)
+	if p.Name == "" || err1 != nil || err2 != nil || start.Equal(end) {
+		return ErrInvalidServicePeriod
+	}
+	for _, d := range p.Days {
+		if d < 0 || d > 6 {
+			return ErrInvalidServicePeriod
+		}
+	}
+	if p.Days == nil {
+		p.Days = pq.Int64Array{}
+	}
+	return nil
+}
+
+// minutes turns "HH:MM" into minutes after midnight
+func minutes(hhmm string) int {
+	t, err := time.Parse("15:04", hhmm)
+	if err != nil {
+		return 0
+	}
+	return t.Hour()*60 + t.Minute()
+}
+
+func (p ServicePeriod) runsOn(day time.Weekday) bool {
+	if len(p.Days) == 0 {
+		return true
+	}
+	for _, d := range p.Days {
+		if time.Weekday(d) == day {
+			return true
+		}
+	}
+	return false
+}
+
+// OpenAt tells whether the period is running at t
+func (p ServicePeriod) OpenAt(t time.Time) bool {
+	now, start, end := t.Hour()*60+t.Minute(), minutes(p.Start), minutes(p.End)
+	if start < end {
+		return p.runsOn(t.Weekday()) && now >= start && now < end
+	}
+	// Past midnight: the early hours belong to the previous day's period
+	return (now >= start && p.runsOn(t.Weekday())) || (now < end && p.runsOn(t.AddDate(0, 0, -1).Weekday()))
+}
+
+// NextOpening returns the first start of the period after t
+func (p ServicePeriod) NextOpening(t time.Time) time.Time {
+	start := minutes(p.Start)
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	for i := 0; i <= 7; i++ {
+		d := day.AddDate(0, 0, i)
+		opening := d.Add(time.Duration(start) * time.Minute)
+		if opening.After(t) && p.runsOn(d.Weekday()) {
+			return opening
+		}
+	}
+	return day.AddDate(0, 0, 8).Add(time.Duration(start) * time.Minute)
+}
+
+// MenuSchedule holds the service periods of the whole menu.
+// Periods set on an item replace those of its category; with neither, the item is served all day.
+type MenuSchedule struct {
+	byCategory map[int][]ServicePeriod
+	byItem     map[int][]ServicePeriod
+}
+
+func NewMenuSchedule(periods []ServicePeriod) MenuSchedule {
+	s := MenuSchedule{byCategory: map[int][]ServicePeriod{}, byItem: map[int][]ServicePeriod{}}
+	for _, p := range periods {
+		switch {
+		case p.MenuItemID != nil:
+			s.byItem[*p.MenuItemID] = append(s.byItem[*p.MenuItemID], p)
+		case p.CategoryID != nil:
+			s.byCategory[*p.CategoryID] = append(s.byCategory[*p.CategoryID], p)
+		}
+	}
+	return s
+}
+
+// CategoryPeriods returns the periods set on a category
+func (s MenuSchedule) CategoryPeriods(categoryID int) []ServicePeriod {
+	return s.byCategory[categoryID]
+}
+
+// PeriodsFor returns the periods that apply to the item
+func (s MenuSchedule) PeriodsFor(item RestaurantMenuItem) []ServicePeriod {
+	if periods, ok := s.byItem[item.ID]; ok {
+		return periods
+	}
+	return s.byCategory[item.CategoryID]
+}
+
+// ServedAt tells whether any of the periods is running at t (no periods: always)
+func ServedAt(periods []ServicePeriod, t time.Time) bool {
+	if len(periods) == 0 {
+		return true
+	}
+	for _, p := range periods {
+		if p.OpenAt(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextService returns when the next service of the periods starts after t.
+// Items served all day restock at RestockHour.
+func NextService(periods []ServicePeriod, t time.Time) time.Time {
+	if len(periods) == 0 {
+		return ServicePeriod{Start: fmt.Sprintf("%02d:00", RestockHour)}.NextOpening(t)
+	}
+	next := periods[0].NextOpening(t)
+	for _, p := range periods[1:] {
+		if o := p.NextOpening(t); o.Before(next) {
+			next = o
+		}
+	}
+	return next
+}
+
+// Apply fills in the item's periods and whether it can be ordered at t
+func (s MenuSchedule) Apply(item *RestaurantMenuItem, t time.Time) {
+	item.ServicePeriods = s.PeriodsFor(*item)
+	item.ServedNow = ServedAt(item.ServicePeriods, t)
+	item.ServedFrom = nil
+	if !item.ServedNow {
+		from := NextService(item.ServicePeriods, t)
+		item.ServedFrom = &from
+	}
+}
+
+// SoldOutAt tells whether the kitchen has 86'd the item at t
+func (m RestaurantMenuItem) SoldOutAt(t time.Time) bool {
+	return m.SoldOutUntil != nil && t.Before(*m.SoldOutUntil)
+}
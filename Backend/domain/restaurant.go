@@ -1,12 +1,17 @@
 package domain
 
-import "time"
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
 
 // Database Entities
 type MenuCategory struct {
 	ID       int    `json:"id" db:"id"`
 	Name     string `json:"name" db:"name"`
 	Priority int    `json:"priority" db:"priority"`
+	Station  string `json:"station" db:"station"` // Kitchen station its items go to
 }
 
 type RestaurantMenuItem struct {
@@ -18,38 +23,78 @@ type RestaurantMenuItem struct {
 	IsAvailable bool    `json:"is_available" db:"is_available"`
 	ImageURL    string  `json:"image_url" db:"image_url"`
 	IsDeleted   bool    `json:"-" db:"is_deleted"`
+
+	Allergens pq.StringArray `json:"allergens" db:"allergens"` // GLUTEN, NUTS, DAIRY, ...
+	Dietary   pq.StringArray `json:"dietary" db:"dietary"`     // VEGAN, HALAL, KOSHER, ...
+
+	SoldOutUntil *time.Time `json:"sold_out_until,omitempty" db:"sold_out_until"` // 86'd by the kitchen until the next service
+	Station      *string    `json:"station,omitempty" db:"station"`               // Overrides the category's station
+
+	ServicePeriods []ServicePeriod `json:"service_periods,omitempty" db:"-"` // When it can be ordered (item or category periods)
+	ServedNow      bool            `json:"served_now" db:"-"`
+	ServedFrom     *time.Time      `json:"served_from,omitempty" db:"-"` // Next service, when not served now
+
+	ModifierGroups    []ModifierGroup `json:"modifier_groups,omitempty" db:"-"`
+	AllergenConflicts []string        `json:"allergen_conflicts,omitempty" db:"-"` // The calling guest's allergies found in the item
 }
 
 type Order struct {
-	ID         int       `json:"id" db:"id"`
-	GuestID    int       `json:"guest_id" db:"guest_id"`
-	RoomNumber string    `json:"room_number" db:"room_number"`
-	Notes      string    `json:"notes" db:"notes"`
-	Status     string    `json:"status" db:"status"`
-	TotalPrice float64   `json:"total_price" db:"total_price"`
-	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	ID         int         `json:"id" db:"id"`
+	GuestID    int         `json:"guest_id" db:"guest_id"`
+	RoomNumber string      `json:"room_number" db:"room_number"`
+	Notes      string      `json:"notes" db:"notes"`
+	Status     OrderStatus `json:"status" db:"status"`
+	TotalPrice float64     `json:"total_price" db:"total_price"`
+	CreatedAt  time.Time   `json:"created_at" db:"created_at"`
+
+	UpdatedAt    *time.Time `json:"updated_at,omitempty" db:"updated_at"`       // Last status change
+	CancelReason *string    `json:"cancel_reason,omitempty" db:"cancel_reason"` // Set when the kitchen cancels
+
+	CancelRequestedAt   *time.Time `json:"cancel_requested_at,omitempty" db:"cancel_requested_at"`     // Guest asked to cancel once cooking had started
+	CancelRequestReason *string    `json:"cancel_request_reason,omitempty" db:"cancel_request_reason"` // Waiting for the kitchen to approve
+	AdjustedTotal       float64    `json:"adjusted_total" db:"adjusted_total"`                         // Comped and voided lines, left out of TotalPrice
+
+	AllergyAlert bool           `json:"allergy_alert" db:"allergy_alert"` // Guest confirmed a dish with one of their allergens
+	Allergens    pq.StringArray `json:"allergens" db:"allergens"`         // Which of the guest's allergens are in the order
+
+	LateAlertedAt *time.Time `json:"late_alerted_at,omitempty" db:"late_alerted_at"` // Ticket timer went off
 }
 
 // Complex Structs for API Responses
 type CategoryWithItems struct {
-	Category MenuCategory         `json:"category"`
-	Items    []RestaurantMenuItem `json:"items"`
+	Category       MenuCategory         `json:"category"`
+	ServicePeriods []ServicePeriod      `json:"service_periods,omitempty"`
+	OpenNow        bool                 `json:"open_now"`
+	Items          []RestaurantMenuItem `json:"items"`
 }
 
 type RestaurantOrderItemInput struct {
-	ItemID   int `json:"item_id"`
-	Quantity int `json:"quantity"`
+	ItemID    int   `json:"item_id"`
+	Quantity  int   `json:"quantity"`
+	Modifiers []int `json:"modifiers,omitempty"` // Chosen modifier option IDs
 }
 
 // Response for Kitchen Display
 type OrderItemDetail struct {
-	Name     string  `json:"name" db:"name"`
-	Quantity int     `json:"quantity" db:"quantity"`
-	Price    float64 `json:"price" db:"snap_price"`
+	ID             int                 `json:"id" db:"id"`
+	OrderID        int                 `json:"order_id" db:"order_id"`
+	Name           string              `json:"name" db:"name"`
+	Quantity       int                 `json:"quantity" db:"quantity"`
+	Price          float64             `json:"price" db:"snap_price"`                // Base price per unit
+	ModifiersPrice float64             `json:"modifiers_price" db:"modifiers_price"` // Added per unit by the modifiers
+	Allergens      pq.StringArray      `json:"allergens" db:"allergens"`             // Allergens of the dish, for the kitchen
+	Station        string              `json:"station" db:"station"`
+	Status         string              `json:"status" db:"status"` // PENDING, READY
+	ReadyAt        *time.Time          `json:"ready_at,omitempty" db:"ready_at"`
+	Adjustment     *string             `json:"adjustment,omitempty" db:"adjustment"`       // COMP or VOID: not billed
+	AdjustReason   *string             `json:"adjust_reason,omitempty" db:"adjust_reason"` // Reason code of the comp or void
+	AdjustNote     *string             `json:"adjust_note,omitempty" db:"adjust_note"`
+	Modifiers      []OrderItemModifier `json:"modifiers,omitempty" db:"-"`
 }
 
 type OrderWithItems struct {
 	Order
-	Items []OrderItemDetail `json:"items"`
+	Items      []OrderItemDetail `json:"items"`
+	AgeMinutes int               `json:"age_minutes"` // Ticket timer
+	Late       bool              `json:"late"`        // Older than the kitchen threshold
 }
-
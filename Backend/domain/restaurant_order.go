@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrEmptyOrder   = errors.New("order has no items")
+	ErrInvalidOrder = errors.New("order has invalid lines")
+)
+
+// Reasons a single order line is rejected
+const (
+	OrderLineUnknownItem     = "UNKNOWN_ITEM"
+	OrderLineItemRemoved     = "ITEM_REMOVED"     // Soft-deleted from the menu
+	OrderLineItemUnavailable = "ITEM_UNAVAILABLE" // Switched off (e.g. sold out)
+	OrderLineSoldOut         = "SOLD_OUT"         // 86'd by the kitchen until the next service
+	OrderLineNotServed       = "NOT_SERVED_NOW"   // Outside the item's service periods
+	OrderLineBadQuantity     = "INVALID_QUANTITY"
+	OrderLineInvalidModifier = "INVALID_MODIFIER"   // Option not offered for this item, or switched off
+	OrderLineModifierCount   = "MODIFIER_SELECTION" // Too few or too many picks in a group
+)
+
+// OrderLineError points at one bad line of an order (Line is the 0-based index in the request)
+type OrderLineError struct {
+	Line    int    `json:"line"`
+	ItemID  int    `json:"item_id"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+// OrderValidationError lists every bad line, so the guest can fix the cart in one go
+type OrderValidationError struct {
+	Lines []OrderLineError `json:"lines"`
+}
+
+func (e *OrderValidationError) Error() string {
+	return fmt.Sprintf("%s: %d line(s)", ErrInvalidOrder, len(e.Lines))
+}
+
+func (e *OrderValidationError) Unwrap() error {
+	return ErrInvalidOrder
+}
+
+// PricedOrderLine is an order line with its prices taken from the menu
+type PricedOrderLine struct {
+	UnitPrice      float64 // Item price
+	ModifiersPrice float64 // Sum of the chosen price deltas, per unit
+	Modifiers      []OrderItemModifier
+}
+
+// PriceOrderLines checks the cart against the menu and prices each line, modifiers included.
+// menu must contain every item the cart refers to, including removed ones, so the reason can be precise,
+// and each item's modifier groups, with the schedule applied for the order time at.
+func PriceOrderLines(items []RestaurantOrderItemInput, menu map[int]RestaurantMenuItem, at time.Time) ([]PricedOrderLine, float64, error) {
+	if len(items) == 0 {
+		return nil, 0, ErrEmptyOrder
+	}
+
+	var bad []OrderLineError
+	reject := func(line int, item RestaurantOrderItemInput, reason, message string) {
+		bad = append(bad, OrderLineError{Line: line, ItemID: item.ItemID, Reason: reason, Message: message})
+	}
+
+	lines := make([]PricedOrderLine, len(items))
+	var total float64
+	for i, item := range items {
+		if item.Quantity <= 0 {
+			reject(i, item, OrderLineBadQuantity, "quantity must be positive")
+			continue
+		}
+		m, ok := menu[item.ItemID]
+		switch {
+		case !ok:
+			reject(i, item, OrderLineUnknownItem, "item does not exist")
+			continue
+		case m.IsDeleted:
+			reject(i, item, OrderLineItemRemoved, m.Name+" is no longer on the menu")
+			continue
+		case !m.IsAvailable:
+			reject(i, item, OrderLineItemUnavailable, m.Name+" is not available right now")
+			continue
+		case m.SoldOutAt(at):
+			reject(i, item, OrderLineSoldOut, m.Name+" is sold out until "+m.SoldOutUntil.Format("15:04"))
+			continue
+		case !m.ServedNow:
+			message := m.Name + " is not served right now"
+			if m.ServedFrom != nil {
+				message = m.Name + " is served from " + m.ServedFrom.Format("Mon 15:04")
+			}
+			reject(i, item, OrderLineNotServed, message)
+			continue
+		}
+
+		modifiers, reason, message := chooseModifiers(m, item.Modifiers)
+		if reason != "" {
+			reject(i, item, reason, message)
+			continue
+		}
+
+		line := PricedOrderLine{UnitPrice: m.Price, Modifiers: modifiers}
+		for _, mod := range modifiers {
+			line.ModifiersPrice += mod.PriceDelta
+		}
+		lines[i] = line
+		total += (line.UnitPrice + line.ModifiersPrice) * float64(item.Quantity)
+	}
+
+	if len(bad) > 0 {
+		return nil, 0, &OrderValidationError{Lines: bad}
+	}
+	return lines, total, nil
+}
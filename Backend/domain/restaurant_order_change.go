@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrOrderNotEditable      = errors.New("order can only be changed before the kitchen starts on it")
+	ErrCancelTooLate         = errors.New("order has left the kitchen and can no longer be cancelled")
+	ErrNoCancelRequest       = errors.New("order has no pending cancellation request")
+	ErrOrderClosed           = errors.New("order is cancelled or already paid")
+	ErrInvalidLineAdjustment = errors.New("adjustment must be COMP or VOID with a known reason code")
+	ErrLineAlreadyAdjusted   = errors.New("line is already comped or voided")
+	ErrLineNotAdjusted       = errors.New("line is not comped or voided")
+)
+
+// Manager adjustments of a single order line. Neither is billed:
+// a comp was made and served on the house, a void should never have been made.
+const (
+	LineAdjustmentComp = "COMP"
+	LineAdjustmentVoid = "VOID"
+)
+
+// Reason codes a manager picks when comping or voiding a line
+const (
+	AdjustReasonQuality     = "QUALITY"          // Food not up to standard
+	AdjustReasonWrongItem   = "WRONG_ITEM"       // Kitchen sent the wrong dish
+	AdjustReasonLate        = "LATE_DELIVERY"    // Arrived well past the ETA
+	AdjustReasonAllergy     = "ALLERGY"          // Dish conflicted with the guest's allergies
+	AdjustReasonEntryError  = "ENTRY_ERROR"      // Ordered by mistake
+	AdjustReasonHospitality = "HOSPITALITY"      // Goodwill gesture (VIP, complaint recovery)
+	AdjustReasonManager     = "MANAGER_DECISION" // Anything else, explained in the note
+)
+
+var adjustReasons = []string{
+	AdjustReasonQuality, AdjustReasonWrongItem, AdjustReasonLate, AdjustReasonAllergy,
+	AdjustReasonEntryError, AdjustReasonHospitality, AdjustReasonManager,
+}
+
+// AdjustReasons lists the reason codes for comps and voids
+func AdjustReasons() []string {
+	return append([]string(nil), adjustReasons...)
+}
+
+// LineAdjustment is a manager's comp or void of one order line
+type LineAdjustment struct {
+	Type       string `json:"type"`        // COMP, VOID
+	ReasonCode string `json:"reason_code"` // One of AdjustReasons
+	Note       string `json:"note"`
+}
+
+// Normalize upper-cases the codes and checks them
+func (a *LineAdjustment) Normalize() error {
+	a.Type = strings.ToUpper(strings.TrimSpace(a.Type))
+	a.ReasonCode = strings.ToUpper(strings.TrimSpace(a.ReasonCode))
+	a.Note = strings.TrimSpace(a.Note)
+	if a.Type != LineAdjustmentComp && a.Type != LineAdjustmentVoid {
+		return ErrInvalidLineAdjustment
+	}
+	if !containsTag(adjustReasons, a.ReasonCode) {
+		return ErrInvalidLineAdjustment
+	}
+	return nil
+}
+
+// Billed tells whether the line is still charged to the guest
+func (i OrderItemDetail) Billed() bool {
+	return i.Adjustment == nil
+}
+
+// Voided tells whether a manager voided the line (the kitchen no longer makes it)
+func (i OrderItemDetail) Voided() bool {
+	return i.Adjustment != nil && *i.Adjustment == LineAdjustmentVoid
+}
+
+// Closed tells whether the order can no longer change (cancelled, or settled on the invoice)
+func (s OrderStatus) Closed() bool {
+	return s == OrderStatusCancelled || s == OrderStatusPaid
+}
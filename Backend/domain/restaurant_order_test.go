@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// testMenu is a small menu with one item in each state PriceOrderLines tells apart
+func testMenu(at time.Time) map[int]RestaurantMenuItem {
+	soldOutUntil := at.Add(2 * time.Hour)
+	servedFrom := at.Add(3 * time.Hour)
+	return map[int]RestaurantMenuItem{
+		1: {ID: 1, Name: "Club Sandwich", Price: 12, IsAvailable: true, ServedNow: true},
+		2: {ID: 2, Name: "Old Special", Price: 9, IsAvailable: true, ServedNow: true, IsDeleted: true},
+		3: {ID: 3, Name: "Lobster", Price: 40, IsAvailable: false, ServedNow: true},
+		4: {ID: 4, Name: "Soup of the Day", Price: 7, IsAvailable: true, ServedNow: true, SoldOutUntil: &soldOutUntil},
+		5: {ID: 5, Name: "Pancakes", Price: 8, IsAvailable: true, ServedNow: false, ServedFrom: &servedFrom},
+		6: {
+			ID: 6, Name: "Steak", Price: 30, IsAvailable: true, ServedNow: true,
+			ModifierGroups: []ModifierGroup{
+				{ID: 10, Name: "Doneness", MinSelect: 1, MaxSelect: 1, Options: []ModifierOption{
+					{ID: 100, Name: "Rare", IsAvailable: true},
+					{ID: 101, Name: "Well Done", IsAvailable: true},
+				}},
+				{ID: 11, Name: "Extras", MinSelect: 0, MaxSelect: 2, Options: []ModifierOption{
+					{ID: 110, Name: "Pepper Sauce", PriceDelta: 2.5, IsAvailable: true},
+					{ID: 111, Name: "Truffle Butter", PriceDelta: 6, IsAvailable: false},
+				}},
+			},
+		},
+	}
+}
+
+func TestPriceOrderLinesRejects(t *testing.T) {
+	at := time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC)
+	menu := testMenu(at)
+
+	tests := []struct {
+		name   string
+		item   RestaurantOrderItemInput
+		reason string
+	}{
+		{"zero quantity", RestaurantOrderItemInput{ItemID: 1, Quantity: 0}, OrderLineBadQuantity},
+		{"negative quantity", RestaurantOrderItemInput{ItemID: 1, Quantity: -2}, OrderLineBadQuantity},
+		{"unknown item", RestaurantOrderItemInput{ItemID: 99, Quantity: 1}, OrderLineUnknownItem},
+		{"removed item", RestaurantOrderItemInput{ItemID: 2, Quantity: 1}, OrderLineItemRemoved},
+		{"unavailable item", RestaurantOrderItemInput{ItemID: 3, Quantity: 1}, OrderLineItemUnavailable},
+		{"sold out item", RestaurantOrderItemInput{ItemID: 4, Quantity: 1}, OrderLineSoldOut},
+		{"outside service period", RestaurantOrderItemInput{ItemID: 5, Quantity: 1}, OrderLineNotServed},
+		{"option of another item", RestaurantOrderItemInput{ItemID: 1, Quantity: 1, Modifiers: []int{100}}, OrderLineInvalidModifier},
+		{"option switched off", RestaurantOrderItemInput{ItemID: 6, Quantity: 1, Modifiers: []int{100, 111}}, OrderLineInvalidModifier},
+		{"option picked twice", RestaurantOrderItemInput{ItemID: 6, Quantity: 1, Modifiers: []int{100, 110, 110}}, OrderLineInvalidModifier},
+		{"required group skipped", RestaurantOrderItemInput{ItemID: 6, Quantity: 1}, OrderLineModifierCount},
+		{"too many picks in a group", RestaurantOrderItemInput{ItemID: 6, Quantity: 1, Modifiers: []int{100, 101}}, OrderLineModifierCount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, total, err := PriceOrderLines([]RestaurantOrderItemInput{tt.item}, menu, at)
+			if lines != nil || total != 0 {
+				t.Errorf("got lines %v and total %v, want none", lines, total)
+			}
+
+			var invalid *OrderValidationError
+			if !errors.As(err, &invalid) {
+				t.Fatalf("got error %v, want *OrderValidationError", err)
+			}
+			if !errors.Is(err, ErrInvalidOrder) {
+				t.Errorf("error does not unwrap to ErrInvalidOrder")
+			}
+			if len(invalid.Lines) != 1 {
+				t.Fatalf("got %d bad lines, want 1", len(invalid.Lines))
+			}
+			got := invalid.Lines[0]
+			if got.Reason != tt.reason {
+				t.Errorf("got reason %s, want %s", got.Reason, tt.reason)
+			}
+			if got.Line != 0 || got.ItemID != tt.item.ItemID || got.Message == "" {
+				t.Errorf("got line %+v, want line 0 of item %d with a message", got, tt.item.ItemID)
+			}
+		})
+	}
+}
+
+func TestPriceOrderLinesListsEveryBadLine(t *testing.T) {
+	at := time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC)
+	items := []RestaurantOrderItemInput{
+		{ItemID: 1, Quantity: 1},
+		{ItemID: 2, Quantity: 1},
+		{ItemID: 1, Quantity: 1},
+		{ItemID: 4, Quantity: 1},
+	}
+
+	_, _, err := PriceOrderLines(items, testMenu(at), at)
+	var invalid *OrderValidationError
+	if !errors.As(err, &invalid) {
+		t.Fatalf("got error %v, want *OrderValidationError", err)
+	}
+	want := []OrderLineError{
+		{Line: 1, ItemID: 2, Reason: OrderLineItemRemoved},
+		{Line: 3, ItemID: 4, Reason: OrderLineSoldOut},
+	}
+	if len(invalid.Lines) != len(want) {
+		t.Fatalf("got %d bad lines, want %d", len(invalid.Lines), len(want))
+	}
+	for i, w := range want {
+		got := invalid.Lines[i]
+		if got.Line != w.Line || got.ItemID != w.ItemID || got.Reason != w.Reason {
+			t.Errorf("bad line %d: got %+v, want %+v", i, got, w)
+		}
+	}
+}
+
+func TestPriceOrderLinesPricesModifiers(t *testing.T) {
+	at := time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC)
+	items := []RestaurantOrderItemInput{
+		{ItemID: 1, Quantity: 2},
+		{ItemID: 6, Quantity: 3, Modifiers: []int{101, 110}},
+	}
+
+	lines, total, err := PriceOrderLines(items, testMenu(at), at)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := 2*12.0 + 3*(30+2.5); total != want {
+		t.Errorf("got total %v, want %v", total, want)
+	}
+	if lines[1].UnitPrice != 30 || lines[1].ModifiersPrice != 2.5 || len(lines[1].Modifiers) != 2 {
+		t.Errorf("got steak line %+v, want 30 + 2.5 with two modifiers", lines[1])
+	}
+	if m := lines[1].Modifiers[0]; m.GroupName != "Doneness" || m.OptionName != "Well Done" {
+		t.Errorf("got first modifier %+v, want Doneness: Well Done", m)
+	}
+}
+
+func TestPriceOrderLinesEmptyCart(t *testing.T) {
+	if _, _, err := PriceOrderLines(nil, nil, time.Now()); !errors.Is(err, ErrEmptyOrder) {
+		t.Errorf("got error %v, want ErrEmptyOrder", err)
+	}
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// OrderStatus is a step in the room service lifecycle:
+// RECEIVED -> PREPARING -> READY -> OUT_FOR_DELIVERY -> DELIVERED -> PAID.
+// The kitchen can cancel (with a reason) until the food is ready.
+type OrderStatus string
+
+const (
+	OrderStatusReceived       OrderStatus = "RECEIVED"         // Placed by the guest
+	OrderStatusPreparing      OrderStatus = "PREPARING"        // Kitchen started on it
+	OrderStatusReady          OrderStatus = "READY"            // Every line bumped, waiting for a runner
+	OrderStatusOutForDelivery OrderStatus = "OUT_FOR_DELIVERY" // On its way to the room
+	OrderStatusDelivered      OrderStatus = "DELIVERED"
+	OrderStatusPaid           OrderStatus = "PAID" // Settled on the checkout invoice
+	OrderStatusCancelled      OrderStatus = "CANCELLED"
+)
+
+// orderTransitions lists the moves staff can make. PAID is only set by the invoice.
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusReceived:       {OrderStatusPreparing, OrderStatusCancelled},
+	OrderStatusPreparing:      {OrderStatusReady, OrderStatusCancelled},
+	OrderStatusReady:          {OrderStatusOutForDelivery},
+	OrderStatusOutForDelivery: {OrderStatusDelivered},
+}
+
+var (
+	ErrInvalidOrderStatus     = errors.New("invalid order status")
+	ErrIllegalOrderTransition = errors.New("order cannot move to that status")
+	ErrOrderStatusChanged     = errors.New("order was updated by someone else, reload and retry")
+	ErrCancelReasonRequired   = errors.New("a reason is required to cancel an order")
+	ErrOrderLeftKitchen       = errors.New("order has left the kitchen")
+)
+
+// Valid reports whether s is one of the known lifecycle states
+func (s OrderStatus) Valid() bool {
+	switch s {
+	case OrderStatusReceived, OrderStatusPreparing, OrderStatusReady, OrderStatusOutForDelivery,
+		OrderStatusDelivered, OrderStatusPaid, OrderStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// CanTransitionTo reports whether staff may move an order from s to next
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, allowed := range orderTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// InKitchen reports whether the kitchen display still shows the order
+func (s OrderStatus) InKitchen() bool {
+	return s == OrderStatusReceived || s == OrderStatusPreparing || s == OrderStatusReady || s == OrderStatusOutForDelivery
+}
+
+// OrderEvent records one status change of an order
+type OrderEvent struct {
+	ID         int         `json:"id" db:"id"`
+	OrderID    int         `json:"order_id" db:"order_id"`
+	FromStatus OrderStatus `json:"from_status" db:"from_status"`
+	ToStatus   OrderStatus `json:"to_status" db:"to_status"`
+	Reason     string      `json:"reason,omitempty" db:"reason"`
+	ActorID    int         `json:"actor_id" db:"actor_id"`
+	ActorRole  string      `json:"actor_role" db:"actor_role"`
+	CreatedAt  time.Time   `json:"created_at" db:"created_at"`
+}
+
+// Rough timings of room service, used for the guest's ETA
+const (
+	etaQueuePerOrder = 3 * time.Minute  // Each order ahead in the kitchen
+	etaPrepTime      = 15 * time.Minute // Cooking an order
+	etaDeliveryTime  = 10 * time.Minute // Runner to the room
+	etaMinimum       = 2 * time.Minute  // Running late still means "any minute now"
+)
+
+// EstimateDelivery guesses when the order reaches the room, from the time it entered its
+// current status and the number of orders ahead of it in the kitchen. Closed orders have no ETA.
+func EstimateDelivery(status OrderStatus, since time.Time, ordersAhead int, now time.Time) *time.Time {
+	var eta time.Time
+	switch status {
+	case OrderStatusReceived:
+		eta = since.Add(time.Duration(ordersAhead)*etaQueuePerOrder + etaPrepTime + etaDeliveryTime)
+	case OrderStatusPreparing:
+		eta = since.Add(etaPrepTime + etaDeliveryTime)
+	case OrderStatusReady, OrderStatusOutForDelivery:
+		eta = since.Add(etaDeliveryTime)
+	default:
+		return nil
+	}
+	if earliest := now.Add(etaMinimum); eta.Before(earliest) {
+		eta = earliest
+	}
+	return &eta
+}
+
+// OrderTracking is what the guest sees while following an order
+type OrderTracking struct {
+	OrderWithItems
+	ETA    *time.Time   `json:"eta,omitempty"`
+	Events []OrderEvent `json:"events"`
+}
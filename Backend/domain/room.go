@@ -1,18 +1,71 @@
 package domain
 
+import (
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
 type RoomStatus string
 
 const (
 	RoomStatusVacant   RoomStatus = "VACANT"
 	RoomStatusOccupied RoomStatus = "OCCUPIED"
-	RoomStatusCleaning RoomStatus = "CLEANING"
+)
+
+var (
+	ErrRoomNotFound  = errors.New("room not found")
+	ErrRoomExists    = errors.New("room number already exists")
+	ErrRoomOccupied  = errors.New("room is occupied")
+	ErrRoomRetired   = errors.New("room is retired")
+	ErrInvalidWindow = errors.New("out-of-order end date must not be before start date")
+
+	ErrRoomTypeNotFound = errors.New("room type not found")
+	ErrRoomTypeExists   = errors.New("room type already exists")
 )
 
 type Room struct {
-	ID         int        `json:"id" db:"id"`
-	RoomNumber string     `json:"room_number" db:"room_number"`
-	Type       string     `json:"type" db:"type"`
-	Status     RoomStatus `json:"status" db:"status"`
-	Price      float64    `json:"price" db:"price"`
+	ID               int            `json:"id" db:"id"`
+	RoomNumber       string         `json:"room_number" db:"room_number"`
+	RoomTypeID       int            `json:"room_type_id" db:"room_type_id"`
+	Type             string         `json:"type" db:"type"` // Room type name
+	Status           RoomStatus     `json:"status" db:"status"`
+	Price            float64        `json:"price" db:"price"` // Nightly rate: per-room override or type base rate
+	Floor            int            `json:"floor" db:"floor"`
+	Features         pq.StringArray `json:"features" db:"features"`
+	MaxOccupancy     int            `json:"max_occupancy" db:"max_occupancy"`
+	OutOfOrderFrom   *time.Time     `json:"out_of_order_from,omitempty" db:"out_of_order_from"`
+	OutOfOrderTo     *time.Time     `json:"out_of_order_to,omitempty" db:"out_of_order_to"`
+	OutOfOrderReason string         `json:"out_of_order_reason,omitempty" db:"out_of_order_reason"`
+	RetiredAt        *time.Time     `json:"retired_at,omitempty" db:"retired_at"`
 }
 
+// IsOutOfOrder reports whether the room is blocked on the given day.
+func (r Room) IsOutOfOrder(day time.Time) bool {
+	if r.OutOfOrderFrom == nil || r.OutOfOrderTo == nil {
+		return false
+	}
+	return !day.Before(*r.OutOfOrderFrom) && !day.After(*r.OutOfOrderTo)
+}
+
+// RoomType is the bookable product (e.g. "Deluxe Suite") shared by many rooms
+type RoomType struct {
+	ID               int            `json:"id" db:"id"`
+	Name             string         `json:"name" db:"name"`
+	Description      string         `json:"description" db:"description"`
+	BaseOccupancy    int            `json:"base_occupancy" db:"base_occupancy"`
+	MaxOccupancy     int            `json:"max_occupancy" db:"max_occupancy"`
+	BedConfiguration string         `json:"bed_configuration" db:"bed_configuration"`
+	Amenities        pq.StringArray `json:"amenities" db:"amenities"`
+	Photos           pq.StringArray `json:"photos" db:"photos"`
+	BaseRate         float64        `json:"base_rate" db:"base_rate"`
+	CleaningCredits  float64        `json:"cleaning_credits" db:"cleaning_credits"` // Housekeeping workload for a full clean
+}
+
+// RoomTypeAvailability is one row of the availability search
+type RoomTypeAvailability struct {
+	RoomType
+	AvailableRooms int     `json:"available_rooms" db:"available_rooms"`
+	FromRate       float64 `json:"from_rate" db:"from_rate"` // Cheapest nightly rate among the free rooms
+}
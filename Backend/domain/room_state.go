@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+type HousekeepingStatus string
+
+const (
+	HousekeepingClean             HousekeepingStatus = "CLEAN"
+	HousekeepingDirty             HousekeepingStatus = "DIRTY"
+	HousekeepingRequestedCleaning HousekeepingStatus = "REQUESTED_CLEANING"
+	HousekeepingCleaning          HousekeepingStatus = "CLEANING"
+	HousekeepingInspected         HousekeepingStatus = "INSPECTED"
+	HousekeepingDND               HousekeepingStatus = "DND" // Do Not Disturb
+)
+
+var (
+	ErrIllegalTransition = errors.New("illegal room state transition")
+	ErrGuestRoomStatus   = errors.New("guests can only request cleaning or Do Not Disturb")
+)
+
+// RoomState combines occupancy and physical cleanliness.
+// Both columns always change together through the state machine below.
+type RoomState struct {
+	Status       RoomStatus         `json:"status" db:"status"`
+	Housekeeping HousekeepingStatus `json:"housekeeping_status" db:"housekeeping_status"`
+}
+
+func (s RoomState) String() string {
+	return string(s.Status) + "+" + string(s.Housekeeping)
+}
+
+// roomTransitions lists every allowed move. Anything not listed is rejected.
+var roomTransitions = map[RoomState][]RoomState{
+	// Stayover: the guest is in the room
+	{RoomStatusOccupied, HousekeepingClean}: {
+		{RoomStatusOccupied, HousekeepingDirty},
+		{RoomStatusOccupied, HousekeepingRequestedCleaning},
+		{RoomStatusOccupied, HousekeepingDND},
+		{RoomStatusVacant, HousekeepingDirty},
+	},
+	{RoomStatusOccupied, HousekeepingDirty}: {
+		{RoomStatusOccupied, HousekeepingCleaning},
+		{RoomStatusOccupied, HousekeepingRequestedCleaning},
+		{RoomStatusOccupied, HousekeepingDND},
+		{RoomStatusVacant, HousekeepingDirty},
+	},
+	{RoomStatusOccupied, HousekeepingRequestedCleaning}: {
+		{RoomStatusOccupied, HousekeepingCleaning},
+		{RoomStatusOccupied, HousekeepingDND},
+		{RoomStatusVacant, HousekeepingDirty},
+	},
+	{RoomStatusOccupied, HousekeepingCleaning}: {
+		{RoomStatusOccupied, HousekeepingClean},
+		{RoomStatusVacant, HousekeepingDirty},
+	},
+	{RoomStatusOccupied, HousekeepingDND}: {
+		{RoomStatusOccupied, HousekeepingClean},
+		{RoomStatusOccupied, HousekeepingDirty},
+		{RoomStatusOccupied, HousekeepingRequestedCleaning},
+		{RoomStatusVacant, HousekeepingDirty},
+	},
+
+	// Turnover: checkout leaves the room dirty, it must be cleaned before the next arrival
+	{RoomStatusVacant, HousekeepingDirty}: {
+		{RoomStatusVacant, HousekeepingCleaning},
+	},
+	{RoomStatusVacant, HousekeepingCleaning}: {
+		{RoomStatusVacant, HousekeepingClean},
+	},
+	{RoomStatusVacant, HousekeepingClean}: {
+		{RoomStatusVacant, HousekeepingInspected},
+		{RoomStatusVacant, HousekeepingDirty},
+		{RoomStatusOccupied, HousekeepingClean},
+	},
+	{RoomStatusVacant, HousekeepingInspected}: {
+		{RoomStatusVacant, HousekeepingDirty},
+		{RoomStatusOccupied, HousekeepingClean},
+	},
+}
+
+// CanTransitionTo reports whether the state machine allows moving from s to next
+func (s RoomState) CanTransitionTo(next RoomState) bool {
+	for _, allowed := range roomTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// RoomStatusChange is one row of room_status_history
+type RoomStatusChange struct {
+	ID         int       `json:"id" db:"id"`
+	RoomNumber string    `json:"room_number" db:"room_number"`
+	From       RoomState `json:"from" db:"from"`
+	To         RoomState `json:"to" db:"to"`
+	ChangedBy  Actor     `json:"changed_by" db:"changed_by"`
+	Reason     string    `json:"reason" db:"reason"`
+	ChangedAt  time.Time `json:"changed_at" db:"changed_at"`
+}
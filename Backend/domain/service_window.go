@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidCleaningWindow = errors.New("cleaning window must be HH:MM times with start before end, at least 30 minutes apart")
+	ErrRoomDND               = errors.New("room is on Do Not Disturb")
+	ErrBeforeCleaningWindow  = errors.New("guest asked for the room to be cleaned later")
+)
+
+// minWindow is the shortest cleaning window a guest can choose
+const minWindow = 30 * time.Minute
+
+// CleaningWindow is the time of day the in-house guest wants the room serviced
+type CleaningWindow struct {
+	RoomNumber string    `json:"room_number" db:"room_number"`
+	GuestID    int       `json:"guest_id" db:"guest_id"`
+	Start      string    `json:"start" db:"window_start"` // "14:00"
+	End        string    `json:"end" db:"window_end"`     // "17:00"
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// Validate checks the times are HH:MM and leave enough room for a clean
+func (w CleaningWindow) Validate() error {
+	start, err1 := time.Parse("15:04", w.Start)
+	end, err2 := time.Parse("15:04", w.End)
+	if err1 != nil || err2 != nil || end.Sub(start) < minWindow {
+		return ErrInvalidCleaningWindow
+	}
+	return nil
+}
+
+// HasStarted reports whether a window starting at start ("HH:MM") has opened at t.
+// An empty start means the room can be serviced any time.
+func HasStarted(start string, t time.Time) bool {
+	if start == "" {
+		return true
+	}
+	s, err := time.Parse("15:04", start)
+	if err != nil {
+		return true
+	}
+	return t.Hour()*60+t.Minute() >= s.Hour()*60+s.Minute()
+}
+
+// DNDRoom is a room on Do Not Disturb, for welfare checks
+type DNDRoom struct {
+	RoomNumber     string     `json:"room_number" db:"room_number"`
+	GuestName      string     `json:"guest_name,omitempty" db:"guest_name"`
+	DNDSince       time.Time  `json:"dnd_since" db:"dnd_since"`
+	DNDHours       float64    `json:"dnd_hours" db:"-"`
+	WelfareAlerted *time.Time `json:"welfare_alerted_at,omitempty" db:"dnd_alerted_at"`
+}
@@ -1,5 +1,20 @@
 package domain
 
+import "errors"
+
+var ErrStaffNotFound = errors.New("staff not found")
+
+// Staff roles stored in staff.role
+const (
+	StaffRoleAdmin        = "ADMIN"
+	StaffRoleManager      = "MANAGER"
+	StaffRoleReceptionist = "RECEPTIONIST"
+	StaffRoleHousekeeping = "HOUSEKEEPING"
+	StaffRoleSupervisor   = "SUPERVISOR" // Housekeeping supervisor (inspections)
+	StaffRoleEngineer     = "ENGINEER"   // Maintenance
+	StaffRoleKitchen      = "KITCHEN"    // Kitchen and room service
+)
+
 // Staff entity
 type Staff struct {
 	ID       int    `json:"id" db:"id"`
@@ -0,0 +1,26 @@
+package group
+
+import (
+	"time"
+
+	"oasis/backend/domain"
+	groupHandler "oasis/backend/rest/handlers/group"
+)
+
+// Service defines what the "Group Module" is capable of doing.
+type Service interface {
+	groupHandler.Service
+	ReleaseExpiredBlocks() (int, error)
+	RunCutoffReleaser(interval time.Duration)
+}
+
+// Repository defines how the "Group Module" talks to the database.
+type Repository interface {
+	CreateGroup(group *domain.BookingGroup, rooms []string) error
+	FetchGroups() ([]domain.BookingGroup, error)
+	FindGroup(id int) (*domain.BookingGroup, error)
+	FetchBlocks(groupID int) ([]domain.GroupRoomBlock, error)
+	ReleaseHeldBlocks(groupID int) (int, error)
+	ReleaseBlocksPastCutoff(today time.Time) (int, error)
+	FetchFolioEntries(groupID int) ([]domain.GroupFolioEntry, error)
+}
@@ -0,0 +1,166 @@
+package group
+
+import (
+	"fmt"
+	"time"
+
+	"oasis/backend/domain"
+	"oasis/backend/guest"
+	"oasis/backend/room"
+)
+
+type service struct {
+	repo     Repository
+	roomSvc  room.Service
+	guestSvc guest.Service
+}
+
+func NewService(repo Repository, roomSvc room.Service, guestSvc guest.Service) Service {
+	return &service{
+		repo:     repo,
+		roomSvc:  roomSvc,
+		guestSvc: guestSvc,
+	}
+}
+
+// CreateGroup holds a block of rooms for the group dates
+func (s *service) CreateGroup(group domain.BookingGroup, rooms []string) (*domain.GroupWithBlocks, error) {
+	if !group.CheckOutDate.After(group.CheckInDate) || group.CutoffDate.After(group.CheckInDate) {
+		return nil, domain.ErrInvalidStayDates
+	}
+
+	// Every room must be free for the whole group stay
+	for _, roomNumber := range rooms {
+		_, err := s.roomSvc.CheckAvailability(roomNumber, group.CheckInDate, group.CheckOutDate, 0, 0)
+		if err != nil {
+			return nil, fmt.Errorf("room %s: %w", roomNumber, err)
+		}
+	}
+
+	group.Status = domain.GroupStatusActive
+	group.CreatedAt = time.Now()
+	if err := s.repo.CreateGroup(&group, rooms); err != nil {
+		return nil, err
+	}
+
+	return s.GetGroup(group.ID)
+}
+
+func (s *service) GetGroups() ([]domain.BookingGroup, error) {
+	return s.repo.FetchGroups()
+}
+
+// GetGroup returns the group with its room block
+func (s *service) GetGroup(id int) (*domain.GroupWithBlocks, error) {
+	group, err := s.repo.FindGroup(id)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, domain.ErrGroupNotFound
+	}
+
+	blocks, err := s.repo.FetchBlocks(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.GroupWithBlocks{
+		BookingGroup: *group,
+		Blocks:       blocks,
+	}, nil
+}
+
+func (s *service) AssignGuest(groupID int, roomNumber string, gst domain.Guest, actor domain.Actor) (*domain.Guest, error) {
+	group, err := s.GetGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group.Status != domain.GroupStatusActive {
+		return nil, domain.ErrGroupClosed
+	}
+	// Blocks still held after the cutoff are about to be released, so they take no more members
+	if domain.Today().After(group.CutoffDate) {
+		return nil, domain.ErrGroupCutoffPassed
+	}
+
+	var block *domain.GroupRoomBlock
+	for i := range group.Blocks {
+		if group.Blocks[i].RoomNumber == roomNumber && group.Blocks[i].Status == domain.BlockStatusHeld {
+			block = &group.Blocks[i]
+			break
+		}
+	}
+	if block == nil {
+		return nil, domain.ErrRoomNotInBlock
+	}
+
+	// Members stay on the group dates
+	gst.RoomNumber = roomNumber
+	gst.CheckInDate = group.CheckInDate
+	gst.CheckOutDate = group.CheckOutDate
+	gst.GroupID = &group.ID
+	gst.CreatedAt = time.Now()
+
+	// The guest repository claims the block in the check-in transaction
+	return s.guestSvc.Create(gst, actor)
+}
+
+func (s *service) ReleaseUnassigned(groupID int) (int, error) {
+	group, err := s.repo.FindGroup(groupID)
+	if err != nil {
+		return 0, err
+	}
+	if group == nil {
+		return 0, domain.ErrGroupNotFound
+	}
+	return s.repo.ReleaseHeldBlocks(groupID)
+}
+
+// ReleaseExpiredBlocks frees every held room whose group passed its cutoff date
+func (s *service) ReleaseExpiredBlocks() (int, error) {
+	return s.repo.ReleaseBlocksPastCutoff(domain.Today())
+}
+
+// RunCutoffReleaser runs ReleaseExpiredBlocks periodically (start it in a goroutine)
+func (s *service) RunCutoffReleaser(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for ; ; <-ticker.C {
+		released, err := s.ReleaseExpiredBlocks()
+		if err != nil {
+			fmt.Println("Failed to release group blocks:", err)
+			continue
+		}
+		if released > 0 {
+			fmt.Println("Released", released, "unassigned group rooms past cutoff")
+		}
+	}
+}
+
+func (s *service) GetFolio(groupID int) (*domain.GroupFolio, error) {
+	group, err := s.repo.FindGroup(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, domain.ErrGroupNotFound
+	}
+
+	entries, err := s.repo.FetchFolioEntries(groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	var total float64
+	for _, e := range entries {
+		total += e.Amount
+	}
+
+	return &domain.GroupFolio{
+		Group:   *group,
+		Entries: entries,
+		Total:   total,
+	}, nil
+}
@@ -1,6 +1,8 @@
 package guest
 
 import (
+	"time"
+
 	"oasis/backend/domain"
 	guestHandler "oasis/backend/rest/handlers/guest"
 )
@@ -9,14 +11,21 @@ import (
 // This is the interface your API Handlers will use.
 type Service interface {
 	guestHandler.Service
+
+	// FindLastGuestInRoom returns the guest who most recently stayed in the room on or before day
+	FindLastGuestInRoom(roomNumber string, day time.Time) (*domain.Guest, error)
 }
 
 // GuestRepo defines how the "Guest Module" talks to the database.
 // This is the interface your PostgreSQL adapter will implement.
 type GuestRepo interface {
-	Create(guest domain.Guest) (*domain.Guest, error)
+	Create(guest domain.Guest, nightlyRate float64, actor domain.Actor) (*domain.Guest, error)
 	Find(roomNumber, phoneNumber string) (*domain.Guest, error)
 	FindByID(id int) (*domain.Guest, error)
 	FindByRoomNumber(roomNumber string) (*domain.Guest, error)
+	FetchStaySegments(guestID int) ([]domain.StaySegment, error)
+	FindLastInRoom(roomNumber string, day time.Time) (*domain.Guest, error)
+	SetAllergies(guestID int, allergies []string) error
+	MoveRoom(move domain.RoomMove) error
+	ExtendStay(guestID int, roomNumber string, from, to time.Time, nightlyRate float64) error
 }
-
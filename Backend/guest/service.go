@@ -1,16 +1,23 @@
 package guest
 
-import "oasis/backend/domain"
+import (
+	"time"
+
+	"oasis/backend/domain"
+	"oasis/backend/room"
+)
 
 // service implements the Service interface defined in port.go
 type service struct {
 	gstRepo GuestRepo
+	roomSvc room.Service
 }
 
 // NewService creates a new instance of the guest service
-func NewService(gstRepo GuestRepo) *service {
+func NewService(gstRepo GuestRepo, roomSvc room.Service) *service {
 	return &service{
 		gstRepo: gstRepo,
+		roomSvc: roomSvc,
 	}
 }
 
@@ -25,8 +32,24 @@ func (svc *service) Find(roomNumber, phoneNumber string) (*domain.Guest, error)
 	return gst, nil
 }
 
-func (svc *service) Create(guest domain.Guest) (*domain.Guest, error) {
-	gst, err := svc.gstRepo.Create(guest)
+// Create checks the guest in: the room must be free for the whole stay
+func (svc *service) Create(guest domain.Guest, actor domain.Actor) (*domain.Guest, error) {
+	groupID := 0
+	if guest.GroupID != nil {
+		groupID = *guest.GroupID
+	}
+	rm, err := svc.roomSvc.CheckAvailability(guest.RoomNumber, guest.CheckInDate, guest.CheckOutDate, 0, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	allergies, err := domain.NormalizeAllergens(guest.Allergies)
+	if err != nil {
+		return nil, err
+	}
+	guest.Allergies = allergies
+	guest.Status = domain.GuestStatusCheckedIn
+	gst, err := svc.gstRepo.Create(guest, rm.Price, actor)
 	if err != nil {
 		return nil, err
 	}
@@ -58,3 +81,114 @@ func (svc *service) GetByRoomNumber(roomNumber string) (*domain.Guest, error) {
 	return gst, nil
 }
 
+// activeGuest loads a guest that is still in-house
+func (svc *service) activeGuest(guestID int) (*domain.Guest, error) {
+	gst, err := svc.gstRepo.FindByID(guestID)
+	if err != nil {
+		return nil, err
+	}
+	if gst == nil {
+		return nil, domain.ErrGuestNotFound
+	}
+	if gst.Status != domain.GuestStatusCheckedIn {
+		return nil, domain.ErrGuestNotCheckedIn
+	}
+	return gst, nil
+}
+
+// MoveRoom transfers the rest of the stay (from today) to another room.
+// Open orders and the folio follow the guest; the old room is left dirty.
+func (svc *service) MoveRoom(guestID int, newRoom, reason string, actor domain.Actor) (*domain.Guest, error) {
+	gst, err := svc.activeGuest(guestID)
+	if err != nil {
+		return nil, err
+	}
+	if newRoom == gst.RoomNumber {
+		return nil, domain.ErrRoomUnavailable
+	}
+
+	moveDate := domain.Today()
+	if moveDate.Before(gst.CheckInDate) {
+		moveDate = gst.CheckInDate
+	}
+	if !gst.CheckOutDate.After(moveDate) {
+		return nil, domain.ErrInvalidStayDates
+	}
+
+	rm, err := svc.roomSvc.CheckAvailability(newRoom, moveDate, gst.CheckOutDate, guestID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	err = svc.gstRepo.MoveRoom(domain.RoomMove{
+		GuestID:      guestID,
+		FromRoom:     gst.RoomNumber,
+		ToRoom:       newRoom,
+		MoveDate:     moveDate,
+		CheckOutDate: gst.CheckOutDate,
+		NightlyRate:  rm.Price,
+		Actor:        actor,
+		Reason:       reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	gst.RoomNumber = newRoom
+	return gst, nil
+}
+
+// ExtendStay pushes the check-out date, if the current room is still free
+func (svc *service) ExtendStay(guestID int, newCheckOut time.Time) (*domain.Guest, error) {
+	gst, err := svc.activeGuest(guestID)
+	if err != nil {
+		return nil, err
+	}
+	if !newCheckOut.After(gst.CheckOutDate) {
+		return nil, domain.ErrInvalidStayDates
+	}
+
+	rm, err := svc.roomSvc.CheckAvailability(gst.RoomNumber, gst.CheckOutDate, newCheckOut, guestID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	err = svc.gstRepo.ExtendStay(guestID, gst.RoomNumber, gst.CheckOutDate, newCheckOut, rm.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	gst.CheckOutDate = newCheckOut
+	return gst, nil
+}
+
+func (svc *service) GetStaySegments(guestID int) ([]domain.StaySegment, error) {
+	return svc.gstRepo.FetchStaySegments(guestID)
+}
+
+// SetAllergies records the guest's allergies; the restaurant warns before serving a dish that contains one
+func (svc *service) SetAllergies(guestID int, allergies []string) (*domain.Guest, error) {
+	gst, err := svc.gstRepo.FindByID(guestID)
+	if err != nil {
+		return nil, err
+	}
+	if gst == nil {
+		return nil, domain.ErrGuestNotFound
+	}
+
+	normalized, err := domain.NormalizeAllergens(allergies)
+	if err != nil {
+		return nil, err
+	}
+	if err := svc.gstRepo.SetAllergies(guestID, normalized); err != nil {
+		return nil, err
+	}
+
+	gst.Allergies = normalized
+	return gst, nil
+}
+
+// FindLastGuestInRoom uses the stay segments, so guests who moved out of the room are found too
+func (svc *service) FindLastGuestInRoom(roomNumber string, day time.Time) (*domain.Guest, error) {
+	return svc.gstRepo.FindLastInRoom(roomNumber, day)
+}
@@ -0,0 +1,192 @@
+package housekeeping
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"oasis/backend/domain"
+)
+
+// RequestAmenity books a catalog item for the guest, enforcing the per-stay limit.
+// The room must be the one the guest is staying in.
+func (s *service) RequestAmenity(guestID int, roomNumber, code string, qty int) (*domain.AmenityRequest, error) {
+	if qty < 1 {
+		return nil, fmt.Errorf("%w: quantity must be at least 1", domain.ErrAmenityLimitExceeded)
+	}
+	if err := s.checkGuestRoom(roomNumber, domain.Actor{ID: guestID, Role: domain.ActorRoleGuest}); err != nil {
+		return nil, err
+	}
+
+	item, err := s.repo.FindAmenityItemByCode(code)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil || !item.Active {
+		return nil, domain.ErrAmenityNotFound
+	}
+
+	if item.PerStayLimit != nil {
+		already, err := s.repo.CountGuestAmenity(guestID, item.ID)
+		if err != nil {
+			return nil, err
+		}
+		if already+qty > *item.PerStayLimit {
+			return nil, fmt.Errorf("%w: %s is limited to %d per stay (%d already requested)",
+				domain.ErrAmenityLimitExceeded, item.Name, *item.PerStayLimit, already)
+		}
+	}
+	if item.StockOnHand < qty {
+		return nil, domain.ErrAmenityOutOfStock
+	}
+
+	req := &domain.AmenityRequest{
+		GuestID:    guestID,
+		RoomNumber: roomNumber,
+		ItemID:     &item.ID,
+		ItemName:   item.Name,
+		Quantity:   qty,
+		UnitPrice:  item.Price,
+		TotalPrice: item.Price * float64(qty),
+		Status:     domain.AmenityStatusPending,
+		CreatedAt:  time.Now(),
+	}
+	// Save to DB
+	if err := s.repo.SaveAmenityRequest(req); err != nil {
+		return nil, err
+	}
+
+	// Broadcast "New Task" event
+	s.hub.BroadcastToStaff("NEW_TASK", req)
+	return req, nil
+}
+
+func (s *service) GetAmenityRequests() ([]domain.AmenityRequest, error) {
+	return s.repo.FetchAmenityRequests()
+}
+
+// MarkAmenityDelivered closes the request and takes the items out of stock
+func (s *service) MarkAmenityDelivered(id int, actor domain.Actor) (*domain.AmenityRequest, error) {
+	req, err := s.repo.FindAmenityRequest(id)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil {
+		return nil, domain.ErrAmenityRequestNotFound
+	}
+	if req.Status != domain.AmenityStatusPending {
+		return nil, domain.ErrAmenityAlreadyHandled
+	}
+
+	now := time.Now()
+	req.Status = domain.AmenityStatusDelivered
+	req.DeliveredAt = &now
+
+	item, err := s.repo.DeliverAmenityTx(req, ledgerStaffID(actor))
+	if err != nil {
+		return nil, err
+	}
+	s.alertIfLowStock(item)
+
+	return req, nil
+}
+
+// alertIfLowStock warns the staff when an item reaches its par level
+func (s *service) alertIfLowStock(item *domain.AmenityItem) {
+	if item == nil || !item.IsLowStock() {
+		return
+	}
+	s.hub.BroadcastToStaff("LOW_STOCK", item)
+}
+
+// ledgerStaffID is the ledger's staff reference; guests and the system record none
+func ledgerStaffID(actor domain.Actor) int {
+	if actor.Role == domain.ActorRoleGuest || actor.Role == domain.ActorRoleSystem {
+		return 0
+	}
+	return actor.ID
+}
+
+func (s *service) GetAmenityCatalog(includeInactive bool) ([]domain.AmenityItem, error) {
+	return s.repo.FetchAmenityItems(includeInactive)
+}
+
+func (s *service) CreateAmenityItem(item domain.AmenityItem) (*domain.AmenityItem, error) {
+	item.Code = strings.ToUpper(strings.TrimSpace(item.Code))
+	existing, err := s.repo.FindAmenityItemByCode(item.Code)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && existing.Code == item.Code {
+		return nil, domain.ErrAmenityExists
+	}
+
+	// Opening stock goes through the ledger like any other restock
+	opening := item.StockOnHand
+	item.StockOnHand = 0
+	item.CreatedAt = time.Now()
+	if err := s.repo.CreateAmenityItem(&item); err != nil {
+		return nil, err
+	}
+	if opening > 0 {
+		return s.repo.AdjustStockTx(item.ID, opening, domain.StockReasonRestock, "Opening stock", 0)
+	}
+	return &item, nil
+}
+
+// UpdateAmenityItem edits the catalog entry; use AdjustStock to change stock levels
+func (s *service) UpdateAmenityItem(item domain.AmenityItem) (*domain.AmenityItem, error) {
+	existing, err := s.repo.FindAmenityItem(item.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, domain.ErrAmenityNotFound
+	}
+
+	item.Code = strings.ToUpper(strings.TrimSpace(item.Code))
+	item.StockOnHand = existing.StockOnHand
+	item.CreatedAt = existing.CreatedAt
+	if err := s.repo.UpdateAmenityItem(&item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// AdjustStock records a restock (positive) or a correction such as damaged linen (negative)
+func (s *service) AdjustStock(itemID, change int, reason, note string, actor domain.Actor) (*domain.AmenityItem, error) {
+	if change == 0 || (reason == domain.StockReasonRestock && change < 0) {
+		return nil, domain.ErrInvalidStockChange
+	}
+	if reason != domain.StockReasonRestock && reason != domain.StockReasonAdjustment {
+		return nil, domain.ErrInvalidStockChange
+	}
+
+	existing, err := s.repo.FindAmenityItem(itemID)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, domain.ErrAmenityNotFound
+	}
+
+	item, err := s.repo.AdjustStockTx(itemID, change, reason, note, ledgerStaffID(actor))
+	if err != nil {
+		return nil, err
+	}
+	s.alertIfLowStock(item)
+	return item, nil
+}
+
+func (s *service) GetStockLedger(itemID int) ([]domain.StockMovement, error) {
+	return s.repo.FetchStockMovements(itemID)
+}
+
+func (s *service) GetLowStockItems() ([]domain.AmenityItem, error) {
+	return s.repo.FetchLowStockItems()
+}
+
+// GetGuestAmenityCharges lists the chargeable amenities to post on the guest's folio
+func (s *service) GetGuestAmenityCharges(guestID int) ([]domain.AmenityRequest, error) {
+	return s.repo.FetchGuestAmenityCharges(guestID)
+}
@@ -0,0 +1,129 @@
+package housekeeping
+
+import (
+	"fmt"
+	"time"
+
+	"oasis/backend/domain"
+)
+
+// GetCleaningWindow returns the current guest's window, nil when none was chosen
+func (s *service) GetCleaningWindow(roomNumber string, actor domain.Actor) (*domain.CleaningWindow, error) {
+	if err := s.checkGuestRoom(roomNumber, actor); err != nil {
+		return nil, err
+	}
+	return s.repo.FindCleaningWindow(roomNumber)
+}
+
+// SetCleaningWindow stores the in-house guest's preferred cleaning time and
+// moves today's open stayover clean into it. Guests can only set the window of their own room.
+func (s *service) SetCleaningWindow(roomNumber, start, end string, actor domain.Actor) (*domain.CleaningWindow, error) {
+	window := &domain.CleaningWindow{RoomNumber: roomNumber, Start: start, End: end, CreatedAt: time.Now()}
+	if err := window.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.checkGuestRoom(roomNumber, actor); err != nil {
+		return nil, err
+	}
+
+	guestID, err := s.repo.FindCheckedInGuestID(roomNumber)
+	if err != nil {
+		return nil, err
+	}
+	if guestID == 0 {
+		return nil, domain.ErrGuestNotCheckedIn
+	}
+	window.GuestID = guestID
+
+	if err := s.repo.SaveCleaningWindow(window); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateOpenTaskWindows(roomNumber, domain.Today(), start, end); err != nil {
+		return nil, err
+	}
+
+	s.hub.BroadcastToStaff("CLEANING_WINDOW", window)
+	return window, nil
+}
+
+// ClearCleaningWindow lets housekeeping service the room at any time again
+func (s *service) ClearCleaningWindow(roomNumber string, actor domain.Actor) error {
+	if err := s.checkGuestRoom(roomNumber, actor); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteCleaningWindow(roomNumber); err != nil {
+		return err
+	}
+	return s.repo.UpdateOpenTaskWindows(roomNumber, domain.Today(), "", "")
+}
+
+// GetDNDRooms lists the rooms on Do Not Disturb with how long they have been closed
+func (s *service) GetDNDRooms() ([]domain.DNDRoom, error) {
+	rooms, err := s.repo.FetchDNDRooms()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for i := range rooms {
+		rooms[i].DNDHours = now.Sub(rooms[i].DNDSince).Hours()
+	}
+	return rooms, nil
+}
+
+// CheckDNDWelfare raises one welfare-check alert per DND period longer than threshold
+func (s *service) CheckDNDWelfare(threshold time.Duration) (int, error) {
+	rooms, err := s.GetDNDRooms()
+	if err != nil {
+		return 0, err
+	}
+
+	var due []domain.DNDRoom
+	var roomNumbers []string
+	for _, room := range rooms {
+		if room.WelfareAlerted == nil && time.Since(room.DNDSince) >= threshold {
+			due = append(due, room)
+			roomNumbers = append(roomNumbers, room.RoomNumber)
+		}
+	}
+	if len(due) == 0 {
+		return 0, nil
+	}
+
+	if err := s.repo.MarkDNDAlerted(roomNumbers, time.Now()); err != nil {
+		return 0, err
+	}
+	for _, room := range due {
+		s.hub.BroadcastToStaff("DND_WELFARE_CHECK", room)
+	}
+	return len(due), nil
+}
+
+// RunDNDWelfareCheck runs CheckDNDWelfare periodically (start it in a goroutine)
+func (s *service) RunDNDWelfareCheck(interval, threshold time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for ; ; <-ticker.C {
+		alerted, err := s.CheckDNDWelfare(threshold)
+		if err != nil {
+			fmt.Println("Failed to check DND rooms:", err)
+			continue
+		}
+		if alerted > 0 {
+			fmt.Println("Welfare check needed for", alerted, "DND rooms")
+		}
+	}
+}
+
+// refreshTasksOnRequest re-plans the day right away when a guest closes the room (DND)
+// or opens it again / asks for a make-up, instead of waiting for the task scheduler
+func (s *service) refreshTasksOnRequest(from, to domain.RoomState) {
+	if from.Housekeeping != domain.HousekeepingDND &&
+		to.Housekeeping != domain.HousekeepingDND &&
+		to.Housekeeping != domain.HousekeepingRequestedCleaning {
+		return
+	}
+	if _, err := s.GenerateTasks(domain.Today()); err != nil {
+		fmt.Println("Failed to refresh housekeeping tasks:", err)
+	}
+}
@@ -0,0 +1,177 @@
+package housekeeping
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"oasis/backend/domain"
+)
+
+func (s *service) GetChecklist(roomTypeID int) ([]domain.ChecklistItem, error) {
+	return s.repo.FetchChecklist(roomTypeID)
+}
+
+func (s *service) GetRoomChecklist(roomNumber string) ([]domain.ChecklistItem, error) {
+	return s.repo.FetchRoomChecklist(roomNumber)
+}
+
+func (s *service) SetChecklist(roomTypeID int, items []domain.ChecklistItem) ([]domain.ChecklistItem, error) {
+	for i := range items {
+		if items[i].SortOrder == 0 {
+			items[i].SortOrder = i + 1
+		}
+	}
+	if err := s.repo.ReplaceChecklist(roomTypeID, items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// SubmitInspection records a supervisor's checklist for a CLEAN vacant room.
+// A full pass marks the room INSPECTED; any failed item sends it back to DIRTY
+// and reopens the attendant's cleaning task with the failure notes.
+func (s *service) SubmitInspection(roomNumber string, results []domain.InspectionResult, notes string, actor domain.Actor) (*domain.RoomInspection, error) {
+	current, err := s.repo.FetchRoomState(roomNumber)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, domain.ErrRoomNotFound
+	}
+	clean := domain.RoomState{Status: domain.RoomStatusVacant, Housekeeping: domain.HousekeepingClean}
+	if *current != clean {
+		return nil, fmt.Errorf("%w: only %s rooms can be inspected, room is %s", domain.ErrIllegalTransition, clean, current)
+	}
+
+	// Every active checklist item must be answered, and failures explained
+	items, err := s.repo.FetchRoomChecklist(roomNumber)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("%w: no checklist configured for this room type", domain.ErrChecklistIncomplete)
+	}
+
+	answers := map[int]domain.InspectionResult{}
+	for _, res := range results {
+		answers[res.ChecklistItemID] = res
+	}
+
+	passed := true
+	var failures []string
+	checked := make([]domain.InspectionResult, 0, len(items))
+	for _, item := range items {
+		res, ok := answers[item.ID]
+		if !ok {
+			return nil, fmt.Errorf("%w: missing %s - %s", domain.ErrChecklistIncomplete, item.Area, item.Description)
+		}
+		if !res.Passed {
+			if strings.TrimSpace(res.Note) == "" {
+				return nil, fmt.Errorf("%w: %s - %s", domain.ErrFailNoteRequired, item.Area, item.Description)
+			}
+			passed = false
+			failures = append(failures, item.Area+": "+res.Note)
+		}
+		res.Area = item.Area
+		res.Description = item.Description
+		checked = append(checked, res)
+	}
+
+	inspection := &domain.RoomInspection{
+		RoomNumber:  roomNumber,
+		InspectorID: actor.ID,
+		Passed:      passed,
+		Notes:       notes,
+		InspectedAt: time.Now(),
+		Results:     checked,
+	}
+
+	cleaning, err := s.repo.FindLastCleaningTask(roomNumber)
+	if err != nil {
+		return nil, err
+	}
+	if cleaning != nil {
+		inspection.CleaningTaskID = &cleaning.ID
+		inspection.AttendantID = cleaning.AssignedTo
+	}
+
+	next := domain.RoomState{Status: domain.RoomStatusVacant, Housekeeping: domain.HousekeepingInspected}
+	reason := "Inspection passed"
+	if !passed {
+		next.Housekeeping = domain.HousekeepingDirty
+		reason = "Inspection failed: " + strings.Join(failures, "; ")
+	}
+	if !current.CanTransitionTo(next) {
+		return nil, fmt.Errorf("%w: %s -> %s", domain.ErrIllegalTransition, current, next)
+	}
+	change := newRoomChange(roomNumber, *current, next, actor, reason)
+
+	// The tasks are settled in memory and stored with the inspection and the room move
+	var tasks []*domain.HousekeepingTask
+	inspectionTask, err := s.closeInspectionTask(roomNumber, passed)
+	if err != nil {
+		return nil, err
+	}
+	if inspectionTask != nil {
+		tasks = append(tasks, inspectionTask)
+	}
+	reopened := !passed && cleaning != nil && cleaning.TaskDate.Equal(domain.Today())
+	if reopened {
+		reopenCleaningTask(cleaning)
+		tasks = append(tasks, cleaning)
+	}
+
+	// One transaction: the optimistic room update protects against a concurrent change
+	if err := s.repo.SaveInspection(inspection, change, tasks); err != nil {
+		return nil, err
+	}
+
+	s.roomChanged(change)
+	if reopened {
+		s.hub.BroadcastToStaff("TASK_REOPENED", map[string]interface{}{
+			"task":   cleaning,
+			"reason": reason,
+		})
+	}
+	return inspection, nil
+}
+
+// closeInspectionTask settles today's inspection task: done on a pass. A failed one is
+// cancelled instead so a fresh inspection is generated after the re-clean.
+func (s *service) closeInspectionTask(roomNumber string, passed bool) (*domain.HousekeepingTask, error) {
+	task, err := s.repo.FindOpenTask(roomNumber, domain.TaskInspection, domain.Today())
+	if err != nil || task == nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if passed {
+		task.Status = domain.TaskStatusDone
+		if task.StartedAt == nil {
+			task.StartedAt = &now
+		}
+		task.FinishedAt = &now
+	} else {
+		task.Status = domain.TaskStatusCancelled
+	}
+	return task, nil
+}
+
+// reopenCleaningTask puts the failed clean back on the attendant's board
+func reopenCleaningTask(task *domain.HousekeepingTask) {
+	task.Status = domain.TaskStatusAssigned
+	if task.AssignedTo == nil {
+		task.Status = domain.TaskStatusPending
+	}
+	task.StartedAt = nil
+	task.FinishedAt = nil
+}
+
+func (s *service) GetInspections(roomNumber string) ([]domain.RoomInspection, error) {
+	return s.repo.FetchInspections(roomNumber)
+}
+
+func (s *service) GetPassRateReport(from, to time.Time) ([]domain.AttendantPassRate, error) {
+	return s.repo.FetchPassRates(from, to)
+}
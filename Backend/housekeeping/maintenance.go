@@ -0,0 +1,231 @@
+package housekeeping
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"oasis/backend/domain"
+)
+
+// ReportIssue opens a ticket with a priority and SLA deadline derived from the issue type.
+// A rejected photo does not lose the report: it is listed in PhotoErrors. Guests report for their own room.
+func (s *service) ReportIssue(roomNumber, issueType, desc string, photos []domain.Upload, actor domain.Actor) (*domain.MaintenanceTicket, error) {
+	if err := s.checkGuestRoom(roomNumber, actor); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	priority := domain.PriorityForIssue(issueType)
+	ticket := &domain.MaintenanceTicket{
+		RoomNumber:  roomNumber,
+		IssueType:   issueType,
+		Description: desc,
+		Priority:    priority,
+		Status:      domain.TicketStatusOpen,
+		ReportedAt:  now,
+		SLADueAt:    now.Add(priority.SLA()),
+	}
+	if err := s.repo.SaveTicket(ticket); err != nil {
+		return nil, err
+	}
+
+	for _, photo := range photos {
+		att, err := s.storageSvc.Upload(domain.AttachmentOwnerTicket, ticket.ID, photo.FileName, photo.Data, actor)
+		if err != nil {
+			ticket.PhotoErrors = append(ticket.PhotoErrors, fmt.Sprintf("%s: %v", photo.FileName, err))
+			continue
+		}
+		ticket.Photos = append(ticket.Photos, *att)
+	}
+
+	s.hub.BroadcastToStaff("NEW_TICKET", ticket)
+	return ticket, nil
+}
+
+func (s *service) GetMaintenanceTickets() ([]domain.MaintenanceTicket, error) {
+	return s.repo.FetchMaintenanceTickets(0)
+}
+
+// GetMyTickets lists the open tickets assigned to an engineer
+func (s *service) GetMyTickets(engineerID int) ([]domain.MaintenanceTicket, error) {
+	return s.repo.FetchMaintenanceTickets(engineerID)
+}
+
+func (s *service) GetTicket(id int) (*domain.MaintenanceTicket, error) {
+	ticket, err := s.repo.FindTicket(id)
+	if err != nil {
+		return nil, err
+	}
+	if ticket == nil {
+		return nil, domain.ErrTicketNotFound
+	}
+
+	parts, err := s.repo.FetchTicketParts(id)
+	if err != nil {
+		return nil, err
+	}
+	ticket.PartsUsed = parts
+
+	photos, err := s.storageSvc.List(domain.AttachmentOwnerTicket, id)
+	if err != nil {
+		return nil, err
+	}
+	ticket.Photos = photos
+	return ticket, nil
+}
+
+// AttachTicketPhoto adds a photo to an existing ticket (e.g. the engineer's "after" shot)
+func (s *service) AttachTicketPhoto(ticketID int, photo domain.Upload, actor domain.Actor) (*domain.Attachment, error) {
+	ticket, err := s.repo.FindTicket(ticketID)
+	if err != nil {
+		return nil, err
+	}
+	if ticket == nil {
+		return nil, domain.ErrTicketNotFound
+	}
+	// Guests only add photos to tickets of the room they are staying in
+	if err := s.checkGuestRoom(ticket.RoomNumber, actor); errors.Is(err, domain.ErrRoomNotFound) {
+		return nil, domain.ErrTicketNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return s.storageSvc.Upload(domain.AttachmentOwnerTicket, ticketID, photo.FileName, photo.Data, actor)
+}
+
+// AssignTicket hands the ticket to an engineer (re-assigning is allowed until it is resolved)
+func (s *service) AssignTicket(id, engineerID int) (*domain.MaintenanceTicket, error) {
+	ticket, err := s.GetTicket(id)
+	if err != nil {
+		return nil, err
+	}
+	if ticket.Status == domain.TicketStatusResolved {
+		return nil, fmt.Errorf("%w: ticket is already resolved", domain.ErrIllegalTicketTransition)
+	}
+
+	role, err := s.repo.FetchStaffRole(engineerID)
+	if err != nil {
+		return nil, err
+	}
+	if role == "" {
+		return nil, domain.ErrStaffNotFound
+	}
+	if role != domain.StaffRoleEngineer {
+		return nil, domain.ErrNotAnEngineer
+	}
+
+	now := time.Now()
+	ticket.AssignedTo = &engineerID
+	ticket.AssignedAt = &now
+	if err := s.repo.UpdateTicket(ticket); err != nil {
+		return nil, err
+	}
+
+	s.hub.BroadcastToStaff("TICKET_ASSIGNED", ticket)
+	return ticket, nil
+}
+
+// UpdateTicketStatus moves a ticket between OPEN, IN_PROGRESS and ON_HOLD.
+// Use ResolveTicket to close it.
+func (s *service) UpdateTicketStatus(id int, status domain.TicketStatus, reason string) (*domain.MaintenanceTicket, error) {
+	if status == domain.TicketStatusResolved {
+		return nil, domain.ErrResolutionRequired
+	}
+
+	ticket, err := s.GetTicket(id)
+	if err != nil {
+		return nil, err
+	}
+	if !ticket.Status.CanTransitionTo(status) {
+		return nil, fmt.Errorf("%w: %s -> %s", domain.ErrIllegalTicketTransition, ticket.Status, status)
+	}
+
+	switch status {
+	case domain.TicketStatusInProgress:
+		if ticket.StartedAt == nil {
+			now := time.Now()
+			ticket.StartedAt = &now
+		}
+		ticket.OnHoldReason = ""
+	case domain.TicketStatusOnHold:
+		if strings.TrimSpace(reason) == "" {
+			return nil, fmt.Errorf("%w: a reason is required to put a ticket on hold", domain.ErrIllegalTicketTransition)
+		}
+		ticket.OnHoldReason = reason
+	}
+	ticket.Status = status
+
+	if err := s.repo.UpdateTicket(ticket); err != nil {
+		return nil, err
+	}
+
+	s.hub.BroadcastToStaff("TICKET_UPDATE", ticket)
+	return ticket, nil
+}
+
+// ResolveTicket closes the ticket with the engineer's notes and the parts they used
+func (s *service) ResolveTicket(id int, notes string, parts []domain.TicketPart, actor domain.Actor) (*domain.MaintenanceTicket, error) {
+	if strings.TrimSpace(notes) == "" {
+		return nil, domain.ErrResolutionRequired
+	}
+
+	ticket, err := s.GetTicket(id)
+	if err != nil {
+		return nil, err
+	}
+	if !ticket.Status.CanTransitionTo(domain.TicketStatusResolved) {
+		return nil, fmt.Errorf("%w: %s -> %s", domain.ErrIllegalTicketTransition, ticket.Status, domain.TicketStatusResolved)
+	}
+
+	now := time.Now()
+	ticket.Status = domain.TicketStatusResolved
+	ticket.ResolutionNotes = notes
+	ticket.ResolvedBy = &actor.ID
+	ticket.ResolvedAt = &now
+	ticket.OnHoldReason = ""
+	ticket.PartsUsed = parts
+
+	if err := s.repo.ResolveTicketTx(ticket); err != nil {
+		return nil, err
+	}
+	if err := s.releaseRoomBlock(ticket); err != nil {
+		return nil, err
+	}
+
+	s.hub.BroadcastToStaff("TICKET_RESOLVED", ticket)
+	return ticket, nil
+}
+
+// EscalateOverdueTickets flags every unresolved ticket past its SLA and alerts the staff
+func (s *service) EscalateOverdueTickets() (int, error) {
+	now := time.Now()
+	overdue, err := s.repo.FetchOverdueTickets(now)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, ticket := range overdue {
+		if err := s.repo.MarkTicketEscalated(ticket.ID, now); err != nil {
+			return 0, err
+		}
+		ticket.EscalatedAt = &now
+
+		s.hub.BroadcastToStaff("TICKET_ESCALATED", map[string]interface{}{
+			"ticket":         ticket,
+			"overdue_by_min": int(now.Sub(ticket.SLADueAt).Minutes()),
+		})
+	}
+	return len(overdue), nil
+}
+
+// RunSLAChecker runs EscalateOverdueTickets periodically (start it in a goroutine)
+func (s *service) RunSLAChecker(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for ; ; <-ticker.C {
+		if _, err := s.EscalateOverdueTickets(); err != nil {
+			fmt.Println("Failed to escalate overdue tickets:", err)
+		}
+	}
+}
@@ -1,32 +1,162 @@
 package housekeeping
 
-import "oasis/backend/domain"
+import (
+	"time"
+
+	"oasis/backend/domain"
+)
 
 // Service Interface
 type Service interface {
 	// Guest Actions
-	RequestAmenity(guestID int, roomNumber, item string, qty int) error
-	ReportIssue(roomNumber, issueType, desc string) error
-	RequestCleaning(roomNumber string) error
+	RequestAmenity(guestID int, roomNumber, code string, qty int) (*domain.AmenityRequest, error)
+	ReportIssue(roomNumber, issueType, desc string, photos []domain.Upload, actor domain.Actor) (*domain.MaintenanceTicket, error)
+	AttachTicketPhoto(ticketID int, photo domain.Upload, actor domain.Actor) (*domain.Attachment, error)
+	RequestCleaning(roomNumber string, actor domain.Actor) error
 
 	// Staff Actions
 	GetLiveStatus() ([]domain.RoomsStatus, error)
-	UpdateRoomStatus(roomNumber, status string) error
+	UpdateHousekeepingStatus(roomNumber string, status domain.HousekeepingStatus, actor domain.Actor, reason string) error
+	TransitionRoom(roomNumber string, to domain.RoomState, actor domain.Actor, reason string) error
+	GetRoomHistory(roomNumber string) ([]domain.RoomStatusChange, error)
 	GetAmenityRequests() ([]domain.AmenityRequest, error)
 	GetMaintenanceTickets() ([]domain.MaintenanceTicket, error)
-	MarkAmenityDelivered(id int) error
-	ResolveTicket(id int) error
+	MarkAmenityDelivered(id int, actor domain.Actor) (*domain.AmenityRequest, error)
+
+	// Maintenance
+	GetMyTickets(engineerID int) ([]domain.MaintenanceTicket, error)
+	GetTicket(id int) (*domain.MaintenanceTicket, error)
+	AssignTicket(id, engineerID int) (*domain.MaintenanceTicket, error)
+	UpdateTicketStatus(id int, status domain.TicketStatus, reason string) (*domain.MaintenanceTicket, error)
+	ResolveTicket(id int, notes string, parts []domain.TicketPart, actor domain.Actor) (*domain.MaintenanceTicket, error)
+	EscalateOverdueTickets() (int, error)
+	RunSLAChecker(interval time.Duration)
+	RunPreventiveScheduler(interval time.Duration)
+	GetGuestAmenityCharges(guestID int) ([]domain.AmenityRequest, error)
+	CheckDNDWelfare(threshold time.Duration) (int, error)
+	RunDNDWelfareCheck(interval, threshold time.Duration)
+
+	// Task Board
+	GenerateTasks(date time.Time) (int, error)
+	AssignPendingTasks(date time.Time) (int, error)
+	RunTaskScheduler(interval time.Duration)
+	GetTasks(date time.Time) ([]domain.HousekeepingTask, error)
+	GetMyTasks(staffID int, date time.Time) ([]domain.HousekeepingTask, error)
+	StartTask(taskID int, actor domain.Actor) (*domain.HousekeepingTask, error)
+	FinishTask(taskID int, actor domain.Actor) (*domain.HousekeepingTask, error)
+	StartShift(staffID int) error
+	EndShift(staffID int) error
+	GetWorkload(date time.Time) ([]domain.AttendantWorkload, error)
+
+	// Inspections
+	GetChecklist(roomTypeID int) ([]domain.ChecklistItem, error)
+	GetRoomChecklist(roomNumber string) ([]domain.ChecklistItem, error)
+	SetChecklist(roomTypeID int, items []domain.ChecklistItem) ([]domain.ChecklistItem, error)
+	SubmitInspection(roomNumber string, results []domain.InspectionResult, notes string, actor domain.Actor) (*domain.RoomInspection, error)
+	GetInspections(roomNumber string) ([]domain.RoomInspection, error)
+	GetPassRateReport(from, to time.Time) ([]domain.AttendantPassRate, error)
+
+	// Preventive Maintenance
+	CreateAsset(asset domain.Asset) (*domain.Asset, error)
+	UpdateAsset(asset domain.Asset) (*domain.Asset, error)
+	GetAssets() ([]domain.Asset, error)
+	GetAsset(id int) (*domain.AssetDetail, error)
+	CreateSchedule(schedule domain.MaintenanceSchedule) (*domain.MaintenanceSchedule, error)
+	UpdateSchedule(schedule domain.MaintenanceSchedule) (*domain.MaintenanceSchedule, error)
+	DeactivateSchedule(id int) error
+	GetSchedules() ([]domain.MaintenanceSchedule, error)
+	GeneratePreventiveTickets(today time.Time) (int, error)
+
+	// Do Not Disturb & Cleaning Windows
+	GetCleaningWindow(roomNumber string, actor domain.Actor) (*domain.CleaningWindow, error)
+	SetCleaningWindow(roomNumber, start, end string, actor domain.Actor) (*domain.CleaningWindow, error)
+	ClearCleaningWindow(roomNumber string, actor domain.Actor) error
+	GetDNDRooms() ([]domain.DNDRoom, error)
+
+	// Amenity Catalog & Stock
+	GetAmenityCatalog(includeInactive bool) ([]domain.AmenityItem, error)
+	CreateAmenityItem(item domain.AmenityItem) (*domain.AmenityItem, error)
+	UpdateAmenityItem(item domain.AmenityItem) (*domain.AmenityItem, error)
+	AdjustStock(itemID, change int, reason, note string, actor domain.Actor) (*domain.AmenityItem, error)
+	GetStockLedger(itemID int) ([]domain.StockMovement, error)
+	GetLowStockItems() ([]domain.AmenityItem, error)
 }
 
 // Repository Interface
 type Repository interface {
 	SaveAmenityRequest(req *domain.AmenityRequest) error
 	SaveTicket(ticket *domain.MaintenanceTicket) error
-	UpdateRoomStatus(roomNumber, status string) error
+	FetchRoomState(roomNumber string) (*domain.RoomState, error)
+	SaveRoomTransition(change *domain.RoomStatusChange) error
+	FetchRoomHistory(roomNumber string) ([]domain.RoomStatusChange, error)
 	FetchAllRoomStatuses() ([]domain.RoomsStatus, error)
 	FetchAmenityRequests() ([]domain.AmenityRequest, error)
-	FetchMaintenanceTickets() ([]domain.MaintenanceTicket, error)
-	UpdateAmenityStatus(id int, status string) error
-	UpdateTicketStatus(id int, status string) error
-}
+	FetchMaintenanceTickets(engineerID int) ([]domain.MaintenanceTicket, error)
+
+	// Maintenance
+	FindTicket(id int) (*domain.MaintenanceTicket, error)
+	FetchTicketParts(ticketID int) ([]domain.TicketPart, error)
+	UpdateTicket(ticket *domain.MaintenanceTicket) error
+	ResolveTicketTx(ticket *domain.MaintenanceTicket) error
+	FetchOverdueTickets(now time.Time) ([]domain.MaintenanceTicket, error)
+	MarkTicketEscalated(id int, at time.Time) error
+	FetchStaffRole(staffID int) (string, error)
+
+	// Tasks
+	FetchTaskCandidates() ([]domain.TaskCandidate, error)
+	CreateTask(task *domain.HousekeepingTask) (bool, error)
+	UpdateTask(task *domain.HousekeepingTask) error
+	FindTask(id int) (*domain.HousekeepingTask, error)
+	FetchOpenTasks(date time.Time) ([]domain.HousekeepingTask, error)
+	FetchTasks(date time.Time, staffID int) ([]domain.HousekeepingTask, error)
+	FetchOnShiftStaff(date time.Time, role string) ([]domain.AttendantLoad, error)
+	SetOnShift(staffID int, onShift bool) error
+	UnassignOpenTasks(staffID int) error
+	FetchWorkload(date time.Time) ([]domain.AttendantWorkload, error)
+
+	// Inspections
+	FetchChecklist(roomTypeID int) ([]domain.ChecklistItem, error)
+	FetchRoomChecklist(roomNumber string) ([]domain.ChecklistItem, error)
+	ReplaceChecklist(roomTypeID int, items []domain.ChecklistItem) error
+	FindLastCleaningTask(roomNumber string) (*domain.HousekeepingTask, error)
+	FindOpenTask(roomNumber string, taskType domain.TaskType, date time.Time) (*domain.HousekeepingTask, error)
+	SaveInspection(inspection *domain.RoomInspection, change *domain.RoomStatusChange, tasks []*domain.HousekeepingTask) error
+	FetchInspections(roomNumber string) ([]domain.RoomInspection, error)
+	FetchPassRates(from, to time.Time) ([]domain.AttendantPassRate, error)
 
+	// Preventive Maintenance
+	CreateAsset(asset *domain.Asset) error
+	UpdateAsset(asset *domain.Asset) error
+	FindAsset(id int) (*domain.Asset, error)
+	FetchAssets() ([]domain.Asset, error)
+	FetchAssetTickets(assetID int) ([]domain.MaintenanceTicket, error)
+	CreateSchedule(schedule *domain.MaintenanceSchedule) error
+	UpdateSchedule(schedule *domain.MaintenanceSchedule) error
+	FindSchedule(id int) (*domain.MaintenanceSchedule, error)
+	FetchSchedules(assetID int) ([]domain.MaintenanceSchedule, error)
+	FetchDueSchedules(today time.Time) ([]domain.DueSchedule, error)
+	SaveScheduledTicket(ticket *domain.MaintenanceTicket, nextDue, generatedAt time.Time) error
+
+	// Do Not Disturb & Cleaning Windows
+	FindCheckedInGuestID(roomNumber string) (int, error)
+	FindCleaningWindow(roomNumber string) (*domain.CleaningWindow, error)
+	SaveCleaningWindow(window *domain.CleaningWindow) error
+	DeleteCleaningWindow(roomNumber string) error
+	UpdateOpenTaskWindows(roomNumber string, date time.Time, start, end string) error
+	FetchDNDRooms() ([]domain.DNDRoom, error)
+	MarkDNDAlerted(roomNumbers []string, at time.Time) error
+
+	// Amenity Catalog & Stock
+	FetchAmenityItems(includeInactive bool) ([]domain.AmenityItem, error)
+	FindAmenityItem(id int) (*domain.AmenityItem, error)
+	FindAmenityItemByCode(code string) (*domain.AmenityItem, error)
+	CreateAmenityItem(item *domain.AmenityItem) error
+	UpdateAmenityItem(item *domain.AmenityItem) error
+	CountGuestAmenity(guestID, itemID int) (int, error)
+	FindAmenityRequest(id int) (*domain.AmenityRequest, error)
+	FetchGuestAmenityCharges(guestID int) ([]domain.AmenityRequest, error)
+	DeliverAmenityTx(req *domain.AmenityRequest, staffID int) (*domain.AmenityItem, error)
+	AdjustStockTx(itemID, change int, reason, note string, staffID int) (*domain.AmenityItem, error)
+	FetchStockMovements(itemID int) ([]domain.StockMovement, error)
+	FetchLowStockItems() ([]domain.AmenityItem, error)
+}
@@ -0,0 +1,270 @@
+package housekeeping
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"oasis/backend/domain"
+)
+
+func (s *service) CreateAsset(asset domain.Asset) (*domain.Asset, error) {
+	if err := s.validateAssetRoom(asset.RoomNumber); err != nil {
+		return nil, err
+	}
+	asset.CreatedAt = time.Now()
+	if err := s.repo.CreateAsset(&asset); err != nil {
+		return nil, err
+	}
+	return &asset, nil
+}
+
+func (s *service) UpdateAsset(asset domain.Asset) (*domain.Asset, error) {
+	existing, err := s.repo.FindAsset(asset.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, domain.ErrAssetNotFound
+	}
+	if err := s.validateAssetRoom(asset.RoomNumber); err != nil {
+		return nil, err
+	}
+
+	asset.CreatedAt = existing.CreatedAt
+	if err := s.repo.UpdateAsset(&asset); err != nil {
+		return nil, err
+	}
+	return &asset, nil
+}
+
+func (s *service) validateAssetRoom(roomNumber *string) error {
+	if roomNumber == nil {
+		return nil
+	}
+	rm, err := s.roomSvc.Find(*roomNumber)
+	if err != nil {
+		return err
+	}
+	if rm == nil {
+		return domain.ErrRoomNotFound
+	}
+	return nil
+}
+
+func (s *service) GetAssets() ([]domain.Asset, error) {
+	return s.repo.FetchAssets()
+}
+
+// GetAsset returns the asset with its schedules and ticket history
+func (s *service) GetAsset(id int) (*domain.AssetDetail, error) {
+	asset, err := s.repo.FindAsset(id)
+	if err != nil {
+		return nil, err
+	}
+	if asset == nil {
+		return nil, domain.ErrAssetNotFound
+	}
+
+	schedules, err := s.repo.FetchSchedules(id)
+	if err != nil {
+		return nil, err
+	}
+	tickets, err := s.repo.FetchAssetTickets(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.AssetDetail{
+		Asset:     *asset,
+		Schedules: schedules,
+		Tickets:   tickets,
+	}, nil
+}
+
+func (s *service) CreateSchedule(schedule domain.MaintenanceSchedule) (*domain.MaintenanceSchedule, error) {
+	if err := s.validateSchedule(schedule); err != nil {
+		return nil, err
+	}
+	schedule.Active = true
+	schedule.CreatedAt = time.Now()
+	if err := s.repo.CreateSchedule(&schedule); err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+func (s *service) UpdateSchedule(schedule domain.MaintenanceSchedule) (*domain.MaintenanceSchedule, error) {
+	existing, err := s.repo.FindSchedule(schedule.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, domain.ErrScheduleNotFound
+	}
+	if err := s.validateSchedule(schedule); err != nil {
+		return nil, err
+	}
+
+	schedule.LastGeneratedAt = existing.LastGeneratedAt
+	schedule.CreatedAt = existing.CreatedAt
+	if err := s.repo.UpdateSchedule(&schedule); err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// DeactivateSchedule stops a schedule from generating more tickets
+func (s *service) DeactivateSchedule(id int) error {
+	schedule, err := s.repo.FindSchedule(id)
+	if err != nil {
+		return err
+	}
+	if schedule == nil {
+		return domain.ErrScheduleNotFound
+	}
+	schedule.Active = false
+	return s.repo.UpdateSchedule(schedule)
+}
+
+func (s *service) validateSchedule(schedule domain.MaintenanceSchedule) error {
+	if err := schedule.Validate(); err != nil {
+		return err
+	}
+	if schedule.AssetID != nil {
+		asset, err := s.repo.FindAsset(*schedule.AssetID)
+		if err != nil {
+			return err
+		}
+		if asset == nil {
+			return domain.ErrAssetNotFound
+		}
+	}
+	return s.validateAssetRoom(schedule.RoomNumber)
+}
+
+func (s *service) GetSchedules() ([]domain.MaintenanceSchedule, error) {
+	return s.repo.FetchSchedules(0)
+}
+
+// GeneratePreventiveTickets opens a ticket for every schedule whose lead window
+// has started, moving the schedule on to its next due date in the same transaction,
+// then blocks the room for the work if the schedule asks for it. A room that is already out of order
+// keeps its block; rooms that could not be blocked are reported in the error once
+// every schedule has been handled.
+func (s *service) GeneratePreventiveTickets(today time.Time) (int, error) {
+	due, err := s.repo.FetchDueSchedules(today)
+	if err != nil {
+		return 0, err
+	}
+
+	generated := 0
+	var blockErrs []error
+	for _, sch := range due {
+		ticket := &domain.MaintenanceTicket{
+			RoomNumber:  sch.TargetRoom,
+			IssueType:   sch.IssueType,
+			Description: preventiveDescription(sch),
+			Priority:    domain.PriorityNormal,
+			Status:      domain.TicketStatusOpen,
+			ReportedAt:  time.Now(),
+			SLADueAt:    sch.NextDueDate.AddDate(0, 0, 1), // Done by the end of the due date
+			ScheduleID:  &sch.ID,
+			AssetID:     sch.AssetID,
+			BlocksRoom:  sch.BlocksRoom && sch.TargetRoom != "",
+		}
+		if ticket.BlocksRoom {
+			// The room has a single out-of-order window: never replace someone else's block
+			until, err := s.blockedUntil(ticket.RoomNumber, today)
+			if err != nil {
+				return generated, err
+			}
+			if until != nil {
+				ticket.BlocksRoom = false
+				ticket.Description += ", room already out of order until " + until.Format("2006-01-02")
+			}
+		}
+
+		// Skip any cycles missed while the scheduler was down
+		next := sch.NextAfter(sch.NextDueDate)
+		for !next.After(today) {
+			next = sch.NextAfter(next)
+		}
+		if err := s.repo.SaveScheduledTicket(ticket, next, time.Now()); err != nil {
+			return generated, err
+		}
+		generated++
+
+		if ticket.BlocksRoom {
+			to := sch.NextDueDate.AddDate(0, 0, sch.BlockDays-1)
+			if err := s.roomSvc.SetOutOfOrder(ticket.RoomNumber, sch.NextDueDate, to, blockReason(ticket.ID)); err != nil {
+				// The ticket must not claim a block the room does not have
+				ticket.BlocksRoom = false
+				if err := s.repo.UpdateTicket(ticket); err != nil {
+					return generated, err
+				}
+				blockErrs = append(blockErrs, fmt.Errorf("block room %s for ticket %d: %w", ticket.RoomNumber, ticket.ID, err))
+			}
+		}
+
+		s.hub.BroadcastToStaff("NEW_TICKET", ticket)
+	}
+	return generated, errors.Join(blockErrs...)
+}
+
+// blockedUntil returns the last day of the room's out-of-order window, nil when none is current or upcoming
+func (s *service) blockedUntil(roomNumber string, today time.Time) (*time.Time, error) {
+	rm, err := s.roomSvc.Find(roomNumber)
+	if err != nil || rm == nil {
+		return nil, err
+	}
+	if rm.OutOfOrderFrom == nil || rm.OutOfOrderTo == nil || rm.OutOfOrderTo.Before(today) {
+		return nil, nil
+	}
+	return rm.OutOfOrderTo, nil
+}
+
+// RunPreventiveScheduler runs GeneratePreventiveTickets periodically (start it in a goroutine)
+func (s *service) RunPreventiveScheduler(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for ; ; <-ticker.C {
+		if _, err := s.GeneratePreventiveTickets(domain.Today()); err != nil {
+			fmt.Println("Failed to generate preventive maintenance tickets:", err)
+		}
+	}
+}
+
+func preventiveDescription(sch domain.DueSchedule) string {
+	parts := []string{"Preventive: " + sch.Title}
+	if sch.AssetName != "" {
+		parts = append(parts, "asset "+sch.AssetName)
+	}
+	if sch.Location != "" {
+		parts = append(parts, "at "+sch.Location)
+	}
+	parts = append(parts, "due "+sch.NextDueDate.Format("2006-01-02"))
+	return strings.Join(parts, ", ")
+}
+
+// blockReason tags the out-of-order window so resolving the ticket only clears its own block
+func blockReason(ticketID int) string {
+	return fmt.Sprintf("Preventive maintenance (ticket #%d)", ticketID)
+}
+
+// releaseRoomBlock puts the room back in service if this ticket blocked it
+func (s *service) releaseRoomBlock(ticket *domain.MaintenanceTicket) error {
+	if !ticket.BlocksRoom || ticket.RoomNumber == "" {
+		return nil
+	}
+	rm, err := s.roomSvc.Find(ticket.RoomNumber)
+	if err != nil || rm == nil {
+		return err
+	}
+	if rm.OutOfOrderReason != blockReason(ticket.ID) {
+		return nil
+	}
+	return s.roomSvc.ClearOutOfOrder(ticket.RoomNumber)
+}
@@ -1,112 +1,138 @@
 package housekeeping
 
 import (
+	"fmt"
 	"oasis/backend/domain"
+	"oasis/backend/room"
+	"oasis/backend/storage"
 	"oasis/backend/ws" // Import the WebSocket package
 	"time"
 )
 
 type service struct {
-	repo Repository
-	hub  *ws.Hub // <--- INJECTED DEPENDENCY
+	repo       Repository
+	hub        *ws.Hub // <--- INJECTED DEPENDENCY
+	roomSvc    room.Service
+	storageSvc storage.Service // Ticket photos
 }
 
 // We ask for the Hub in the constructor
-func NewService(repo Repository, hub *ws.Hub) Service {
+func NewService(repo Repository, hub *ws.Hub, roomSvc room.Service, storageSvc storage.Service) Service {
 	return &service{
-		repo: repo,
-		hub:  hub,
+		repo:       repo,
+		hub:        hub,
+		roomSvc:    roomSvc,
+		storageSvc: storageSvc,
 	}
 }
 
 // 1. Request Cleaning (Guest -> Staff)
-func (s *service) RequestCleaning(roomNumber string) error {
-	status := "REQUESTED_CLEANING"
+func (s *service) RequestCleaning(roomNumber string, actor domain.Actor) error {
+	return s.UpdateHousekeepingStatus(roomNumber, domain.HousekeepingRequestedCleaning, actor, "Guest requested cleaning")
+}
 
-	// A. Update DB
-	err := s.repo.UpdateRoomStatus(roomNumber, status)
-	if err != nil {
+// 2. Staff Marks Clean (Staff -> Map Update)
+// UpdateHousekeepingStatus keeps the occupancy and only changes the cleanliness.
+// Guests may only ask for a make-up or close the room; cleanliness is for staff to report.
+func (s *service) UpdateHousekeepingStatus(roomNumber string, status domain.HousekeepingStatus, actor domain.Actor, reason string) error {
+	if status == domain.HousekeepingInspected {
+		return domain.ErrInspectionRequired
+	}
+	if actor.Role == domain.ActorRoleGuest &&
+		status != domain.HousekeepingRequestedCleaning && status != domain.HousekeepingDND {
+		return domain.ErrGuestRoomStatus
+	}
+	if err := s.checkGuestRoom(roomNumber, actor); err != nil {
 		return err
 	}
 
-	// B. REAL-TIME BROADCAST 📡
-	// This makes the Staff iPad blink instantly
-	s.hub.BroadcastToStaff("ROOM_UPDATE", map[string]string{
-		"room_number": roomNumber,
-		"status":      status,
-	})
+	current, err := s.repo.FetchRoomState(roomNumber)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		return domain.ErrRoomNotFound
+	}
 
-	return nil
+	return s.transition(roomNumber, *current, domain.RoomState{
+		Status:       current.Status,
+		Housekeeping: status,
+	}, actor, reason)
 }
 
-// 2. Staff Marks Clean (Staff -> Map Update)
-func (s *service) UpdateRoomStatus(roomNumber, status string) error {
-	err := s.repo.UpdateRoomStatus(roomNumber, status)
+// TransitionRoom moves a room to a full (occupancy + housekeeping) state
+func (s *service) TransitionRoom(roomNumber string, to domain.RoomState, actor domain.Actor, reason string) error {
+	if to.Housekeeping == domain.HousekeepingInspected {
+		return domain.ErrInspectionRequired
+	}
+
+	current, err := s.repo.FetchRoomState(roomNumber)
 	if err != nil {
 		return err
 	}
+	if current == nil {
+		return domain.ErrRoomNotFound
+	}
 
-	// Broadcast the fix (e.g. turning Green)
-	s.hub.BroadcastToStaff("ROOM_UPDATE", map[string]string{
-		"room_number": roomNumber,
-		"status":      status,
-	})
-	return nil
-}
-
-func (s *service) GetLiveStatus() ([]domain.RoomsStatus, error) {
-	return s.repo.FetchAllRoomStatuses()
+	return s.transition(roomNumber, *current, to, actor, reason)
 }
 
-func (s *service) RequestAmenity(guestID int, roomNumber, item string, qty int) error {
-	req := &domain.AmenityRequest{
-		GuestID:    guestID,
-		RoomNumber: roomNumber,
-		ItemName:   item,
-		Quantity:   qty,
-		Status:     "PENDING",
-		CreatedAt:  time.Now(),
+// checkGuestRoom lets guests act only on the room they are staying in; staff may act on any room
+func (s *service) checkGuestRoom(roomNumber string, actor domain.Actor) error {
+	if actor.Role != domain.ActorRoleGuest {
+		return nil
 	}
-	// Save to DB
-	err := s.repo.SaveAmenityRequest(req)
-
-	// Broadcast "New Task" event
-	if err == nil {
-		s.hub.BroadcastToStaff("NEW_TASK", req)
+	guestID, err := s.repo.FindCheckedInGuestID(roomNumber)
+	if err != nil {
+		return err
+	}
+	// Guests must not learn about other guests' rooms
+	if guestID == 0 || guestID != actor.ID {
+		return domain.ErrRoomNotFound
 	}
-	return err
+	return nil
 }
 
-func (s *service) ReportIssue(roomNumber, issueType, desc string) error {
-	ticket := &domain.MaintenanceTicket{
-		RoomNumber:  roomNumber,
-		IssueType:   issueType,
-		Description: desc,
-		Priority:    "NORMAL",
-		Status:      "OPEN",
-		ReportedAt:  time.Now(),
+// transition is the single place where the state machine is enforced
+func (s *service) transition(roomNumber string, from, to domain.RoomState, actor domain.Actor, reason string) error {
+	if !from.CanTransitionTo(to) {
+		return fmt.Errorf("%w: %s -> %s", domain.ErrIllegalTransition, from, to)
 	}
-	err := s.repo.SaveTicket(ticket)
 
-	if err == nil {
-		s.hub.BroadcastToStaff("NEW_TICKET", ticket)
+	change := newRoomChange(roomNumber, from, to, actor, reason)
+	if err := s.repo.SaveRoomTransition(change); err != nil {
+		return err
 	}
-	return err
-}
 
-func (s *service) GetAmenityRequests() ([]domain.AmenityRequest, error) {
-	return s.repo.FetchAmenityRequests()
+	s.roomChanged(change)
+	s.refreshTasksOnRequest(from, to)
+	return nil
 }
 
-func (s *service) GetMaintenanceTickets() ([]domain.MaintenanceTicket, error) {
-	return s.repo.FetchMaintenanceTickets()
+func newRoomChange(roomNumber string, from, to domain.RoomState, actor domain.Actor, reason string) *domain.RoomStatusChange {
+	return &domain.RoomStatusChange{
+		RoomNumber: roomNumber,
+		From:       from,
+		To:         to,
+		ChangedBy:  actor,
+		Reason:     reason,
+		ChangedAt:  time.Now(),
+	}
 }
 
-func (s *service) MarkAmenityDelivered(id int) error {
-	return s.repo.UpdateAmenityStatus(id, "DELIVERED")
+// roomChanged broadcasts a stored change (e.g. turning Green on the Staff iPad)
+func (s *service) roomChanged(change *domain.RoomStatusChange) {
+	s.hub.BroadcastToStaff("ROOM_UPDATE", map[string]string{
+		"room_number": change.RoomNumber,
+		"occupancy":   string(change.To.Status),
+		"status":      string(change.To.Housekeeping),
+	})
 }
 
-func (s *service) ResolveTicket(id int) error {
-	return s.repo.UpdateTicketStatus(id, "RESOLVED")
+func (s *service) GetRoomHistory(roomNumber string) ([]domain.RoomStatusChange, error) {
+	return s.repo.FetchRoomHistory(roomNumber)
 }
 
+func (s *service) GetLiveStatus() ([]domain.RoomsStatus, error) {
+	return s.repo.FetchAllRoomStatuses()
+}
@@ -0,0 +1,289 @@
+package housekeeping
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"oasis/backend/domain"
+)
+
+// wantedTasks decides which tasks a room needs today from its current state
+func wantedTasks(c domain.TaskCandidate) []domain.TaskType {
+	hk := c.Housekeeping
+	switch c.Status {
+	case domain.RoomStatusVacant:
+		switch hk {
+		case domain.HousekeepingDirty, domain.HousekeepingCleaning:
+			return []domain.TaskType{domain.TaskCheckoutClean}
+		case domain.HousekeepingClean:
+			return []domain.TaskType{domain.TaskInspection}
+		}
+	case domain.RoomStatusOccupied:
+		if hk == domain.HousekeepingDND {
+			return nil
+		}
+		tasks := []domain.TaskType{domain.TaskTurndown}
+		if c.Stayover || hk == domain.HousekeepingDirty || hk == domain.HousekeepingRequestedCleaning {
+			tasks = append(tasks, domain.TaskStayoverClean)
+		}
+		return tasks
+	}
+	return nil
+}
+
+// GenerateTasks creates the tasks rooms need for the day, cancels the ones
+// the room no longer needs (e.g. stayover after checkout) and assigns the rest.
+func (s *service) GenerateTasks(date time.Time) (int, error) {
+	rooms, err := s.repo.FetchTaskCandidates()
+	if err != nil {
+		return 0, err
+	}
+
+	wanted := map[string]bool{}
+	created := 0
+	for _, room := range rooms {
+		for _, taskType := range wantedTasks(room) {
+			wanted[room.RoomNumber+"/"+string(taskType)] = true
+
+			task := &domain.HousekeepingTask{
+				RoomNumber: room.RoomNumber,
+				Type:       taskType,
+				TaskDate:   date,
+				Credits:    room.CleaningCredits * taskType.CreditWeight(),
+				Status:     domain.TaskStatusPending,
+				CreatedAt:  time.Now(),
+			}
+			// The guest's preferred time only applies to servicing an occupied room
+			if taskType == domain.TaskStayoverClean {
+				task.WindowStart = room.WindowStart
+				task.WindowEnd = room.WindowEnd
+			}
+			ok, err := s.repo.CreateTask(task)
+			if err != nil {
+				return created, err
+			}
+			if ok {
+				created++
+			}
+		}
+	}
+
+	open, err := s.repo.FetchOpenTasks(date)
+	if err != nil {
+		return created, err
+	}
+	for _, task := range open {
+		if wanted[task.RoomNumber+"/"+string(task.Type)] {
+			continue
+		}
+		task.Status = domain.TaskStatusCancelled
+		if err := s.repo.UpdateTask(&task); err != nil {
+			return created, err
+		}
+	}
+
+	if _, err := s.AssignPendingTasks(date); err != nil {
+		return created, err
+	}
+	return created, nil
+}
+
+// AssignPendingTasks hands unassigned tasks to on-shift staff, biggest jobs
+// first, always to whoever has the fewest credits on their board.
+// Inspections go to supervisors, everything else to attendants.
+func (s *service) AssignPendingTasks(date time.Time) (int, error) {
+	open, err := s.repo.FetchOpenTasks(date)
+	if err != nil {
+		return 0, err
+	}
+
+	var pending []domain.HousekeepingTask
+	for _, task := range open {
+		if task.AssignedTo == nil {
+			pending = append(pending, task)
+		}
+	}
+	if len(pending) == 0 {
+		return 0, nil
+	}
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].Credits > pending[j].Credits
+	})
+
+	attendants, err := s.repo.FetchOnShiftStaff(date, domain.StaffRoleHousekeeping)
+	if err != nil {
+		return 0, err
+	}
+	supervisors, err := s.repo.FetchOnShiftStaff(date, domain.StaffRoleSupervisor)
+	if err != nil {
+		return 0, err
+	}
+
+	assigned := 0
+	for _, task := range pending {
+		pool := attendants
+		if task.Type == domain.TaskInspection {
+			pool = supervisors
+		}
+		if len(pool) == 0 {
+			continue
+		}
+
+		least := 0
+		for i := range pool {
+			if pool[i].Credits < pool[least].Credits {
+				least = i
+			}
+		}
+
+		staffID := pool[least].StaffID
+		task.AssignedTo = &staffID
+		task.Status = domain.TaskStatusAssigned
+		if err := s.repo.UpdateTask(&task); err != nil {
+			return assigned, err
+		}
+		pool[least].Credits += task.Credits
+		assigned++
+
+		s.hub.BroadcastToStaff("TASK_ASSIGNED", task)
+	}
+	return assigned, nil
+}
+
+// RunTaskScheduler regenerates today's tasks periodically (start it in a goroutine)
+func (s *service) RunTaskScheduler(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for ; ; <-ticker.C {
+		if _, err := s.GenerateTasks(domain.Today()); err != nil {
+			fmt.Println("Failed to generate housekeeping tasks:", err)
+		}
+	}
+}
+
+func (s *service) GetTasks(date time.Time) ([]domain.HousekeepingTask, error) {
+	return s.repo.FetchTasks(date, 0)
+}
+
+func (s *service) GetMyTasks(staffID int, date time.Time) ([]domain.HousekeepingTask, error) {
+	return s.repo.FetchTasks(date, staffID)
+}
+
+// ownTask loads a task and checks it belongs to the calling attendant
+func (s *service) ownTask(taskID int, actor domain.Actor, status domain.TaskStatus) (*domain.HousekeepingTask, error) {
+	task, err := s.repo.FindTask(taskID)
+	if err != nil {
+		return nil, err
+	}
+	if task == nil {
+		return nil, domain.ErrTaskNotFound
+	}
+	if task.AssignedTo == nil || *task.AssignedTo != actor.ID {
+		return nil, domain.ErrTaskNotYours
+	}
+	if task.Status != status {
+		return nil, domain.ErrTaskWrongStatus
+	}
+	return task, nil
+}
+
+// StartTask records the start time; cleaning tasks also move the room to CLEANING
+func (s *service) StartTask(taskID int, actor domain.Actor) (*domain.HousekeepingTask, error) {
+	task, err := s.ownTask(taskID, actor, domain.TaskStatusAssigned)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.Type.IsCleaning() {
+		current, err := s.repo.FetchRoomState(task.RoomNumber)
+		if err != nil {
+			return nil, err
+		}
+		if current == nil {
+			return nil, domain.ErrRoomNotFound
+		}
+		if current.Housekeeping == domain.HousekeepingDND {
+			return nil, domain.ErrRoomDND
+		}
+		// Finishing late is allowed, knocking before the guest's window is not
+		if !domain.HasStarted(task.WindowStart, time.Now()) {
+			return nil, fmt.Errorf("%w: window starts at %s", domain.ErrBeforeCleaningWindow, task.WindowStart)
+		}
+		next := domain.RoomState{Status: current.Status, Housekeeping: domain.HousekeepingCleaning}
+		if current.CanTransitionTo(next) {
+			if err := s.transition(task.RoomNumber, *current, next, actor, "Cleaning started"); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	now := time.Now()
+	task.Status = domain.TaskStatusInProgress
+	task.StartedAt = &now
+	if err := s.repo.UpdateTask(task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// FinishTask records the finish time and leaves a cleaned room CLEAN, ready for inspection.
+// Inspection tasks are closed by SubmitInspection instead.
+func (s *service) FinishTask(taskID int, actor domain.Actor) (*domain.HousekeepingTask, error) {
+	task, err := s.ownTask(taskID, actor, domain.TaskStatusInProgress)
+	if err != nil {
+		return nil, err
+	}
+	if task.Type == domain.TaskInspection {
+		return nil, domain.ErrInspectionRequired
+	}
+
+	current, err := s.repo.FetchRoomState(task.RoomNumber)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, domain.ErrRoomNotFound
+	}
+
+	if task.Type.IsCleaning() && current.Housekeeping == domain.HousekeepingCleaning {
+		next := domain.RoomState{Status: current.Status, Housekeeping: domain.HousekeepingClean}
+		if err := s.transition(task.RoomNumber, *current, next, actor, fmt.Sprintf("Task %s finished", task.Type)); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	task.Status = domain.TaskStatusDone
+	task.FinishedAt = &now
+	if err := s.repo.UpdateTask(task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// StartShift puts the staff member on shift and hands them a share of the open tasks
+func (s *service) StartShift(staffID int) error {
+	if err := s.repo.SetOnShift(staffID, true); err != nil {
+		return err
+	}
+	_, err := s.AssignPendingTasks(domain.Today())
+	return err
+}
+
+// EndShift returns the staff member's unstarted tasks to the pool and rebalances them
+func (s *service) EndShift(staffID int) error {
+	if err := s.repo.SetOnShift(staffID, false); err != nil {
+		return err
+	}
+	if err := s.repo.UnassignOpenTasks(staffID); err != nil {
+		return err
+	}
+	_, err := s.AssignPendingTasks(domain.Today())
+	return err
+}
+
+func (s *service) GetWorkload(date time.Time) ([]domain.AttendantWorkload, error) {
+	return s.repo.FetchWorkload(date)
+}
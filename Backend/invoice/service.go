@@ -2,8 +2,12 @@ package invoice
 
 import (
 	"errors"
+	"fmt"
+
 	"oasis/backend/domain"
+	"oasis/backend/group"
 	"oasis/backend/guest"
+	"oasis/backend/housekeeping"
 	"oasis/backend/laundry"
 	"oasis/backend/restaurant"
 	"oasis/backend/room"
@@ -15,6 +19,8 @@ type service struct {
 	roomSvc       room.Service
 	laundrySvc    laundry.Service
 	restaurantSvc restaurant.Service
+	groupSvc      group.Service
+	hkSvc         housekeeping.Service
 }
 
 // We inject EVERYTHING here. This is the central hub.
@@ -24,6 +30,8 @@ func NewService(
 	r room.Service,
 	l laundry.Service,
 	rest restaurant.Service,
+	grp group.Service,
+	hk housekeeping.Service,
 ) Service {
 	return &service{
 		repo:          repo,
@@ -31,49 +39,165 @@ func NewService(
 		roomSvc:       r,
 		laundrySvc:    l,
 		restaurantSvc: rest,
+		groupSvc:      grp,
+		hkSvc:         hk,
 	}
 }
 
 // 1. GeneratePreview (Read-Only Aggregation)
 func (s *service) GeneratePreview(guestID int) (*domain.InvoicePreview, error) {
 	// A. Get Guest Info
-	gst, _ := s.guestSvc.Get(guestID)
+	gst, err := s.guestSvc.Get(guestID)
+	if err != nil {
+		return nil, err
+	}
+	if gst == nil {
+		return nil, errors.New("guest not found")
+	}
 
-	// B. Calculate Room Charge based on check-in and check-out dates
-	days := int(gst.CheckOutDate.Sub(gst.CheckInDate).Hours() / 24)
-	if days == 0 {
-		days = 1
+	// B. Calculate Room Charge from the stay segments (check-in, moves, extensions)
+	segments, err := s.guestSvc.GetStaySegments(guestID)
+	if err != nil {
+		return nil, err
+	}
+
+	days := 0
+	var roomTotal float64
+	var lines []domain.RoomNightLine
+	for _, seg := range segments {
+		nights := seg.Nights()
+		if nights <= 0 {
+			continue
+		}
+		days += nights
+		roomTotal += float64(nights) * seg.NightlyRate
+		lines = append(lines, domain.RoomNightLine{
+			RoomNumber:  seg.RoomNumber,
+			Reason:      seg.Reason,
+			StartDate:   seg.StartDate,
+			EndDate:     seg.EndDate,
+			Nights:      nights,
+			NightlyRate: seg.NightlyRate,
+			Total:       float64(nights) * seg.NightlyRate,
+		})
 	}
 
-	roomPrice := 100.00 // In real app, fetch from Room Service
-	roomTotal := float64(days) * roomPrice
+	// Day-use: a same-day stay is still charged one night
+	if days == 0 && len(segments) > 0 {
+		last := segments[len(segments)-1]
+		days = 1
+		roomTotal = last.NightlyRate
+		lines = append(lines, domain.RoomNightLine{
+			RoomNumber:  last.RoomNumber,
+			Reason:      last.Reason,
+			StartDate:   last.StartDate,
+			EndDate:     last.EndDate,
+			Nights:      1,
+			NightlyRate: last.NightlyRate,
+			Total:       last.NightlyRate,
+		})
+	}
 
 	// C. Get Pending Laundry (We need to add this method to Laundry Svc!)
 	laundryReqs, _ := s.laundrySvc.GetGuestRequests(guestID)
 	var laundryTotal float64
+	var laundryUnconfirmed, laundryInProcess int
 	for _, req := range laundryReqs {
-		if req.Status != "PAID" {
-			laundryTotal += req.TotalPrice
+		if req.Status == domain.LaundryStatusPaid || req.Status == domain.LaundryStatusCancelled {
+			continue
 		}
+		if req.Status != domain.LaundryStatusDelivered {
+			laundryInProcess++
+		}
+		// Counts the guest has not confirmed yet are not billed, and hold up checkout
+		if req.AwaitingConfirmation {
+			laundryUnconfirmed++
+			continue
+		}
+		laundryTotal += req.TotalPrice
 	}
 
 	// D. Get Pending Food (We need to add this method to Restaurant Svc!)
 	foodOrders, _ := s.restaurantSvc.GetGuestOrders(guestID)
-	var foodTotal float64
+	var foodTotal, foodComps float64
+	var foodOpen int
 	for _, ord := range foodOrders {
-		if ord.Status != "PAID" {
+		// Cancelled orders are never billed; TotalPrice already leaves out comped and voided lines
+		switch {
+		case ord.Status.Closed(): // Cancelled, or settled on an earlier invoice
+		case ord.Status != domain.OrderStatusDelivered:
+			foodOpen++
+		default:
 			foodTotal += ord.TotalPrice
+			foodComps += ord.AdjustedTotal
 		}
 	}
 
-	return &domain.InvoicePreview{
-		GuestName:       gst.Name,
-		StayDays:        days,
-		RoomTotal:       roomTotal,
-		LaundryTotal:    laundryTotal,
-		RestaurantTotal: foodTotal,
-		GrandTotal:      roomTotal + laundryTotal + foodTotal,
-	}, nil
+	// E. Chargeable amenities delivered to the room
+	amenities, err := s.hkSvc.GetGuestAmenityCharges(guestID)
+	if err != nil {
+		return nil, err
+	}
+	var amenityTotal float64
+	for _, a := range amenities {
+		amenityTotal += a.TotalPrice
+	}
+
+	preview := &domain.InvoicePreview{
+		GuestName:          gst.Name,
+		RoomNumber:         gst.RoomNumber,
+		StayDays:           days,
+		RoomSegments:       lines,
+		RoomTotal:          roomTotal,
+		LaundryTotal:       laundryTotal,
+		LaundryUnconfirmed: laundryUnconfirmed,
+		LaundryInProcess:   laundryInProcess,
+		RestaurantTotal:    foodTotal,
+		RestaurantComps:    foodComps,
+		RestaurantOpen:     foodOpen,
+		Amenities:          amenities,
+		AmenityTotal:       amenityTotal,
+		GrandTotal:         roomTotal + laundryTotal + foodTotal + amenityTotal,
+	}
+
+	// F. Group members: route charges to the master folio per the group's billing rules
+	if gst.GroupID != nil {
+		if err := s.routeGroupCharges(preview, gst); err != nil {
+			return nil, err
+		}
+	}
+
+	return preview, nil
+}
+
+// routeGroupCharges moves the master-billed totals out of the guest's GrandTotal
+func (s *service) routeGroupCharges(preview *domain.InvoicePreview, gst *domain.Guest) error {
+	grp, err := s.groupSvc.GetGroup(*gst.GroupID)
+	if err != nil {
+		return err
+	}
+
+	charge := func(enabled bool, chargeType string, amount float64) {
+		if !enabled || amount <= 0 {
+			return
+		}
+		preview.GroupCharges = append(preview.GroupCharges, domain.GroupFolioEntry{
+			GroupID:     grp.ID,
+			GuestID:     gst.ID,
+			ChargeType:  chargeType,
+			Description: fmt.Sprintf("%s - Room %s (%s)", gst.Name, gst.RoomNumber, chargeType),
+			Amount:      amount,
+		})
+		preview.GroupBilled += amount
+	}
+
+	charge(grp.BillRoomToMaster, domain.ChargeTypeRoom, preview.RoomTotal)
+	charge(grp.BillRestaurantToMaster, domain.ChargeTypeRestaurant, preview.RestaurantTotal)
+	charge(grp.BillLaundryToMaster, domain.ChargeTypeLaundry, preview.LaundryTotal)
+
+	preview.GroupName = grp.Name
+	preview.GrandTotal -= preview.GroupBilled
+	return nil
 }
 
 // GeneratePreviewByRoom looks up guest by room number and generates preview
@@ -94,14 +218,35 @@ func (s *service) ProcessCheckout(guestID int) (*domain.Invoice, error) {
 	if err != nil {
 		return nil, err
 	}
+	// Nothing may be left off the bill: the guest settles laundry counts before leaving
+	if preview.LaundryUnconfirmed > 0 {
+		return nil, domain.ErrLaundryUnconfirmed
+	}
+	if preview.LaundryInProcess > 0 {
+		return nil, domain.ErrLaundryInProcess
+	}
+	if preview.RestaurantOpen > 0 {
+		return nil, domain.ErrRestaurantInProcess
+	}
+
+	gst, err := s.guestSvc.Get(guestID)
+	if err != nil {
+		return nil, err
+	}
 
 	// 2. Build the Invoice Object
 	inv := &domain.Invoice{
 		GuestID:          guestID,
+		RoomNumber:       preview.RoomNumber,
+		RoomSegments:     preview.RoomSegments,
 		RoomCharge:       preview.RoomTotal,
 		LaundryCharge:    preview.LaundryTotal,
 		RestaurantCharge: preview.RestaurantTotal,
+		AmenityCharge:    preview.AmenityTotal,
 		TotalAmount:      preview.GrandTotal,
+		GroupID:          gst.GroupID,
+		GroupCharge:      preview.GroupBilled,
+		GroupEntries:     preview.GroupCharges,
 		PaymentMethod:    "CREDIT_CARD",
 	}
 
@@ -126,4 +271,3 @@ func (s *service) ProcessCheckoutByRoom(roomNumber string) (*domain.Invoice, err
 	}
 	return s.ProcessCheckout(gst.ID)
 }
-
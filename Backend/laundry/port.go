@@ -1,7 +1,10 @@
 package laundry
 
-import ("oasis/backend/domain"
- laundryHandler "oasis/backend/rest/handlers/laundry"
+import (
+	"time"
+
+	"oasis/backend/domain"
+	laundryHandler "oasis/backend/rest/handlers/laundry"
 )
 
 // Service Port (Inbound)
@@ -12,10 +15,20 @@ type Service interface {
 // Repository Port (Outbound)
 type LaundryRepo interface {
 	FetchMenu() ([]domain.MenuItem, error)
+	FetchAllItems() ([]domain.MenuItem, error)
+	FindItem(id int) (*domain.MenuItem, error)
+	CreateItem(item *domain.MenuItem) error
+	UpdateItem(item *domain.MenuItem) error
+	DeleteItem(id int) error
 	SaveRequest(req *domain.ServiceRequest) error
 	FetchRequestsByGuest(guestID int) ([]domain.ServiceRequest, error)
-	SaveItemsAndUpdateTotal(reqID int, items []domain.RequestItem, total float64) error
-	UpdateStatus(reqID int, status string) error
+	FindRequest(reqID int) (*domain.ServiceRequest, error)
+	SaveItemsAndUpdateTotal(req *domain.ServiceRequest, items []domain.RequestItem, expected domain.LaundryStatus) error
+	UpdateStatus(reqID int, from, to domain.LaundryStatus, actor domain.Actor) error
+	FetchRequestEvents(reqID int) ([]domain.LaundryEvent, error)
+	FetchDeclaredItems(reqID int) ([]domain.DeclaredItem, error)
+	FetchRequestItems(reqID int) ([]domain.RequestItem, error)
+	ResolveCount(reqID int, accepted bool, dispute string) error
 	GetAllRequests() ([]domain.ServiceRequest, error)
+	FetchOverdueRequests(now time.Time) ([]domain.ServiceRequest, error)
 }
-
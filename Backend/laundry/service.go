@@ -1,17 +1,22 @@
 package laundry
 
 import (
-	"oasis/backend/domain"
+	"strings"
 	"time"
+
+	"oasis/backend/domain"
+	"oasis/backend/ws"
 )
 
 type service struct {
 	lndryRepo LaundryRepo
+	hub       *ws.Hub
 }
 
-func NewService(lndryRepo LaundryRepo) Service {
+func NewService(lndryRepo LaundryRepo, hub *ws.Hub) Service {
 	return &service{
 		lndryRepo: lndryRepo,
+		hub:       hub,
 	}
 }
 
@@ -19,26 +24,139 @@ func (s *service) GetMenu() ([]domain.MenuItem, error) {
 	return s.lndryRepo.FetchMenu()
 }
 
-func (s *service) CreateRequest(guestID int, roomNumber, notes string) (*domain.ServiceRequest, error) {
+func (s *service) GetCategories() []domain.LaundryCategory {
+	return domain.LaundryCategories()
+}
+
+// GetMenuItems is the staff view of the menu, including unavailable items
+func (s *service) GetMenuItems() ([]domain.MenuItem, error) {
+	return s.lndryRepo.FetchAllItems()
+}
+
+func (s *service) GetMenuItem(id int) (*domain.MenuItem, error) {
+	item, err := s.lndryRepo.FindItem(id)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, domain.ErrLaundryItemNotFound
+	}
+	return item, nil
+}
+
+func (s *service) AddMenuItem(item *domain.MenuItem) error {
+	if err := item.Validate(); err != nil {
+		return err
+	}
+	return s.lndryRepo.CreateItem(item)
+}
+
+func (s *service) UpdateMenuItem(item *domain.MenuItem) error {
+	if err := item.Validate(); err != nil {
+		return err
+	}
+	return s.lndryRepo.UpdateItem(item)
+}
+
+func (s *service) RemoveMenuItem(id int) error {
+	return s.lndryRepo.DeleteItem(id)
+}
+
+func (s *service) GetServiceLevels() []domain.LaundryServiceRule {
+	return domain.LaundryServiceRules()
+}
+
+// CreateRequest books a pickup. The promised return time counts from the end of the
+// pickup slot (or from now), so the promise holds even if the bag is collected late in the slot.
+func (s *service) CreateRequest(guestID int, input domain.LaundryRequestInput) (*domain.ServiceRequest, error) {
+	if input.ServiceLevel == "" {
+		input.ServiceLevel = domain.LaundryServiceStandard
+	}
+	rule, ok := domain.FindLaundryServiceRule(input.ServiceLevel)
+	if !ok {
+		return nil, domain.ErrInvalidServiceLevel
+	}
+
+	now := time.Now()
+	pickup := now
+	if input.PickupFrom != nil || input.PickupTo != nil {
+		if input.PickupFrom == nil || input.PickupTo == nil ||
+			!input.PickupTo.After(*input.PickupFrom) || !input.PickupTo.After(now) {
+			return nil, domain.ErrInvalidPickupSlot
+		}
+		pickup = *input.PickupTo
+	}
+	promised := rule.PromisedBy(pickup)
+
+	declared, err := s.declaredItems(input.Items)
+	if err != nil {
+		return nil, err
+	}
+
 	// 1. Construct the object
 	req := &domain.ServiceRequest{
-		GuestID:    guestID,
-		RoomNumber: roomNumber,
-		Notes:      notes,
-		Status:     "PENDING",
-		TotalPrice: 0.00,
-		CreatedAt:  time.Now(),
+		GuestID:      guestID,
+		RoomNumber:   input.RoomNumber,
+		Notes:        input.Notes,
+		Status:       domain.LaundryStatusPending,
+		TotalPrice:   0.00,
+		CreatedAt:    now,
+		ServiceLevel: rule.Level,
+		SurchargePct: rule.SurchargePct,
+		PickupFrom:   input.PickupFrom,
+		PickupTo:     input.PickupTo,
+		PromisedAt:   &promised,
+		Declared:     declared,
 	}
 
 	// 2. Save it
-	err := s.lndryRepo.SaveRequest(req)
+	err = s.lndryRepo.SaveRequest(req)
 	if err != nil {
 		return nil, err
 	}
+	req.TagCode = domain.LaundryTagCode(req.ID)
 
+	s.hub.BroadcastToStaff("LAUNDRY_NEW", req)
 	return req, nil
 }
 
+// declaredItems checks the guest's declaration against the menu
+func (s *service) declaredItems(items []domain.AddItemInput) ([]domain.DeclaredItem, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+	menu, err := s.menuByID()
+	if err != nil {
+		return nil, err
+	}
+
+	declared := make([]domain.DeclaredItem, 0, len(items))
+	for _, input := range items {
+		item, ok := menu[input.ItemID]
+		if !ok {
+			return nil, domain.ErrLaundryItemUnavailable
+		}
+		if input.Quantity <= 0 {
+			return nil, domain.ErrInvalidLaundryQuantity
+		}
+		declared = append(declared, domain.DeclaredItem{ItemID: item.ID, ItemName: item.Name, Quantity: input.Quantity})
+	}
+	return declared, nil
+}
+
+// menuByID indexes the guest-facing menu for price and name lookups
+func (s *service) menuByID() (map[int]domain.MenuItem, error) {
+	menu, err := s.lndryRepo.FetchMenu()
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int]domain.MenuItem, len(menu))
+	for _, m := range menu {
+		byID[m.ID] = m
+	}
+	return byID, nil
+}
+
 func (s *service) GetGuestRequests(guestID int) ([]domain.ServiceRequest, error) {
 	return s.lndryRepo.FetchRequestsByGuest(guestID)
 }
@@ -47,42 +165,241 @@ func (s *service) GetAllRequests() ([]domain.ServiceRequest, error) {
 	return s.lndryRepo.GetAllRequests()
 }
 
-// AddItemsToRequest handles the billing logic
+// GetOverdueRequests is the laundry team's list of bags that missed their promised time
+func (s *service) GetOverdueRequests() ([]domain.ServiceRequest, error) {
+	return s.lndryRepo.FetchOverdueRequests(time.Now())
+}
+
+// GetRequest loads one request with its status history
+func (s *service) GetRequest(reqID int) (*domain.ServiceRequest, error) {
+	req, err := s.lndryRepo.FindRequest(reqID)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil {
+		return nil, domain.ErrLaundryRequestNotFound
+	}
+	req.Events, err = s.lndryRepo.FetchRequestEvents(reqID)
+	if err != nil {
+		return nil, err
+	}
+	req.Declared, err = s.lndryRepo.FetchDeclaredItems(reqID)
+	if err != nil {
+		return nil, err
+	}
+	req.Items, err = s.lndryRepo.FetchRequestItems(reqID)
+	if err != nil {
+		return nil, err
+	}
+	req.Discrepancies = domain.FindDiscrepancies(req.Declared, req.Items)
+	return req, nil
+}
+
+// AddItemsToRequest records the staff count and handles the billing logic.
+// The bag can only be counted before washing starts; moving on is a separate status change.
+// If the count differs from the guest's declaration, the guest has to confirm it before it is billed.
 func (s *service) AddItemsToRequest(reqID int, items []domain.AddItemInput) error {
-	// 1. Fetch the Menu to get current prices
-	menu, err := s.lndryRepo.FetchMenu()
+	req, err := s.lndryRepo.FindRequest(reqID)
 	if err != nil {
 		return err
 	}
+	if req == nil {
+		return domain.ErrLaundryRequestNotFound
+	}
+	if req.Status == domain.LaundryStatusPaid {
+		return domain.ErrLaundryRequestPaid
+	}
+	if !req.Status.AcceptsItems() {
+		return domain.ErrLaundryRequestClosed
+	}
 
-	// Create a map for fast price lookup
-	priceMap := make(map[int]float64)
-	for _, m := range menu {
-		priceMap[m.ID] = m.Price
+	// 1. Fetch the Menu to get current prices
+	menuMap, err := s.menuByID()
+	if err != nil {
+		return err
 	}
 
 	var totalBill float64
 	var domainItems []domain.RequestItem
+	longestTurnaround := 0
 
 	// 2. Calculate Total and Prepare Structs
 	for _, input := range items {
-		price := priceMap[input.ItemID]
-		cost := price * float64(input.Quantity)
+		item, ok := menuMap[input.ItemID]
+		if !ok {
+			return domain.ErrLaundryItemUnavailable
+		}
+		if input.Quantity <= 0 {
+			return domain.ErrInvalidLaundryQuantity
+		}
+		cost := item.Price * float64(input.Quantity)
 		totalBill += cost
+		if item.TurnaroundHours > longestTurnaround {
+			longestTurnaround = item.TurnaroundHours
+		}
 
 		domainItems = append(domainItems, domain.RequestItem{
 			RequestID: reqID,
 			ItemID:    input.ItemID,
+			ItemName:  item.Name,
 			Quantity:  input.Quantity,
-			SnapPrice: price, // Save the price NOW in case menu changes later
+			SnapPrice: item.Price, // Save the price NOW in case menu changes later
+			Notes:     strings.TrimSpace(input.Notes),
 		})
 	}
 
-	// 3. Call Repository to Save Items AND Update Total Price
-	return s.lndryRepo.SaveItemsAndUpdateTotal(reqID, domainItems, totalBill)
+	// 3. Express / same-day surcharge, at the rate agreed when the request was made
+	req.SurchargeAmount = domain.LaundrySurcharge(totalBill, req.SurchargePct)
+	req.TotalPrice = totalBill + req.SurchargeAmount
+
+	// Slow items (e.g. dry cleaning in an express bag) push the promise back
+	earliest := time.Now().Add(time.Duration(longestTurnaround) * time.Hour)
+	if req.PromisedAt == nil || req.PromisedAt.Before(earliest) {
+		req.PromisedAt = &earliest
+	}
+
+	// 4. Compare with what the guest declared
+	req.Declared, err = s.lndryRepo.FetchDeclaredItems(reqID)
+	if err != nil {
+		return err
+	}
+	req.Items = domainItems
+	req.Discrepancies = domain.FindDiscrepancies(req.Declared, req.Items)
+	req.AwaitingConfirmation = len(req.Discrepancies) > 0
+
+	// 5. Call Repository to Save Items AND Update Total Price
+	if err := s.lndryRepo.SaveItemsAndUpdateTotal(req, domainItems, req.Status); err != nil {
+		return err
+	}
+
+	if req.AwaitingConfirmation {
+		s.hub.BroadcastToStaff("LAUNDRY_COUNT_DISCREPANCY", req)
+		s.hub.BroadcastToGuest(req.GuestID, "LAUNDRY_COUNT_DISCREPANCY", req)
+	}
+	return nil
 }
 
-func (s *service) UpdateStatus(reqID int, status string) error {
-	return s.lndryRepo.UpdateStatus(reqID, status)
+// ResolveCount is the guest's answer to a count that did not match their declaration.
+// A dispute keeps the request flagged until staff recount the bag.
+func (s *service) ResolveCount(reqID int, accepted bool, comment string, actor domain.Actor) (*domain.ServiceRequest, error) {
+	req, err := s.lndryRepo.FindRequest(reqID)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil || (actor.Role == domain.ActorRoleGuest && req.GuestID != actor.ID) {
+		return nil, domain.ErrLaundryRequestNotFound
+	}
+	if req.Status == domain.LaundryStatusPaid {
+		return nil, domain.ErrLaundryRequestPaid
+	}
+
+	if err := s.lndryRepo.ResolveCount(reqID, accepted, strings.TrimSpace(comment)); err != nil {
+		return nil, err
+	}
+
+	req, err = s.GetRequest(reqID)
+	if err != nil {
+		return nil, err
+	}
+	if accepted {
+		s.hub.BroadcastToStaff("LAUNDRY_COUNT_CONFIRMED", req)
+	} else {
+		s.hub.BroadcastToStaff("LAUNDRY_COUNT_DISPUTED", req)
+	}
+	return req, nil
 }
 
+// UpdateStatus moves a request one step along the lifecycle.
+// PAID is reserved for the checkout invoice, and paid requests are frozen.
+func (s *service) UpdateStatus(reqID int, status string, actor domain.Actor) (*domain.ServiceRequest, error) {
+	next := domain.LaundryStatus(strings.ToUpper(strings.TrimSpace(status)))
+	if !next.Valid() {
+		return nil, domain.ErrInvalidLaundryStatus
+	}
+
+	req, err := s.lndryRepo.FindRequest(reqID)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil {
+		return nil, domain.ErrLaundryRequestNotFound
+	}
+	return s.transition(req, next, actor)
+}
+
+// CancelRequest lets the guest (or staff) withdraw a pickup that has not been collected yet
+func (s *service) CancelRequest(reqID int, actor domain.Actor) (*domain.ServiceRequest, error) {
+	req, err := s.lndryRepo.FindRequest(reqID)
+	if err != nil {
+		return nil, err
+	}
+	// Guests must not learn about other guests' requests
+	if req == nil || (actor.Role == domain.ActorRoleGuest && req.GuestID != actor.ID) {
+		return nil, domain.ErrLaundryRequestNotFound
+	}
+	return s.transition(req, domain.LaundryStatusCancelled, actor)
+}
+
+// transition validates and stores one status change, then tells staff and the guest
+func (s *service) transition(req *domain.ServiceRequest, next domain.LaundryStatus, actor domain.Actor) (*domain.ServiceRequest, error) {
+	if req.Status == domain.LaundryStatusPaid {
+		return nil, domain.ErrLaundryRequestPaid
+	}
+	if !req.Status.CanTransitionTo(next) {
+		return nil, domain.ErrIllegalLaundryTransition
+	}
+
+	if err := s.lndryRepo.UpdateStatus(req.ID, req.Status, next, actor); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	req.Status = next
+	req.UpdatedAt = &now
+
+	s.hub.BroadcastToStaff("LAUNDRY_UPDATE", req)
+	s.hub.BroadcastToGuest(req.GuestID, "LAUNDRY_UPDATE", req)
+	return req, nil
+}
+
+// GetTag renders the printable bag tag of a request
+func (s *service) GetTag(reqID int, format string) (string, []byte, error) {
+	req, err := s.lndryRepo.FindRequest(reqID)
+	if err != nil {
+		return "", nil, err
+	}
+	if req == nil {
+		return "", nil, domain.ErrLaundryRequestNotFound
+	}
+	return renderTag(req.TagCode, format)
+}
+
+// ScanTag moves the scanned bag to the given stage, or one stage on when the scanner sends none.
+// Stations that send their stage make a double scan harmless (the second one is rejected).
+func (s *service) ScanTag(tag, stage string, actor domain.Actor) (*domain.ServiceRequest, error) {
+	reqID, err := domain.ParseLaundryTag(tag)
+	if err != nil {
+		return nil, err
+	}
+	req, err := s.lndryRepo.FindRequest(reqID)
+	if err != nil {
+		return nil, err
+	}
+	if req == nil {
+		return nil, domain.ErrLaundryRequestNotFound
+	}
+
+	next := domain.LaundryStatus(strings.ToUpper(strings.TrimSpace(stage)))
+	if next == "" {
+		var ok bool
+		if next, ok = req.Status.NextStage(); !ok {
+			if req.Status == domain.LaundryStatusPaid {
+				return nil, domain.ErrLaundryRequestPaid
+			}
+			return nil, domain.ErrIllegalLaundryTransition
+		}
+	} else if !next.Valid() {
+		return nil, domain.ErrInvalidLaundryStatus
+	}
+	return s.transition(req, next, actor)
+}
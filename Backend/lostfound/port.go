@@ -0,0 +1,25 @@
+package lostfound
+
+import (
+	"time"
+
+	"oasis/backend/domain"
+	lostfoundHandler "oasis/backend/rest/handlers/lostfound"
+)
+
+// Service defines what the "Lost & Found Module" is capable of doing.
+type Service interface {
+	lostfoundHandler.Service
+	SendDisposalReminders(today time.Time) (int, error)
+	RunDisposalReminder(interval time.Duration)
+}
+
+// Repository defines how the "Lost & Found Module" talks to the database.
+type Repository interface {
+	SaveLostItem(item *domain.LostItem) error
+	FindLostItem(id int) (*domain.LostItem, error)
+	FetchLostItems(status domain.LostItemStatus) ([]domain.LostItem, error)
+	FetchItemsPastRetention(today time.Time) ([]domain.LostItem, error)
+	CloseLostItem(item *domain.LostItem) error
+	MarkReminderSent(ids []int, at time.Time) error
+}
@@ -0,0 +1,240 @@
+package lostfound
+
+import (
+	"fmt"
+	"time"
+
+	"oasis/backend/domain"
+	"oasis/backend/guest"
+	"oasis/backend/room"
+	"oasis/backend/storage"
+	"oasis/backend/ws"
+)
+
+type service struct {
+	repo       Repository
+	hub        *ws.Hub
+	roomSvc    room.Service
+	guestSvc   guest.Service
+	storageSvc storage.Service
+}
+
+func NewService(repo Repository, hub *ws.Hub, roomSvc room.Service, guestSvc guest.Service, storageSvc storage.Service) Service {
+	return &service{
+		repo:       repo,
+		hub:        hub,
+		roomSvc:    roomSvc,
+		guestSvc:   guestSvc,
+		storageSvc: storageSvc,
+	}
+}
+
+// LogItem records a found item. The owner is guessed from the room's stay history and
+// the retention period comes from the category. A rejected photo is listed in PhotoErrors.
+func (s *service) LogItem(item domain.LostItem, photos []domain.Upload, actor domain.Actor) (*domain.LostItem, error) {
+	if item.Category == "" {
+		item.Category = domain.LostCategoryOther
+	}
+	days, ok := domain.RetentionDays(item.Category)
+	if !ok {
+		return nil, domain.ErrInvalidLostCategory
+	}
+
+	rm, err := s.roomSvc.Find(item.RoomNumber)
+	if err != nil {
+		return nil, err
+	}
+	if rm == nil {
+		return nil, domain.ErrRoomNotFound
+	}
+
+	if item.FoundOn.IsZero() {
+		item.FoundOn = domain.Today()
+	}
+	owner, err := s.guestSvc.FindLastGuestInRoom(item.RoomNumber, item.FoundOn)
+	if err != nil {
+		return nil, err
+	}
+	if owner != nil {
+		item.GuestID = &owner.ID
+		item.GuestName = owner.Name
+		item.GuestPhone = owner.PhoneNumber
+	}
+
+	if actor.ID != 0 {
+		item.FoundBy = &actor.ID
+	}
+	item.Status = domain.LostItemStored
+	item.RetainUntil = item.FoundOn.AddDate(0, 0, days)
+	item.CreatedAt = time.Now()
+	if err := s.repo.SaveLostItem(&item); err != nil {
+		return nil, err
+	}
+
+	for _, photo := range photos {
+		att, err := s.storageSvc.Upload(domain.AttachmentOwnerLostItem, item.ID, photo.FileName, photo.Data, actor)
+		if err != nil {
+			item.PhotoErrors = append(item.PhotoErrors, fmt.Sprintf("%s: %v", photo.FileName, err))
+			continue
+		}
+		item.Photos = append(item.Photos, *att)
+	}
+
+	s.hub.BroadcastToStaff("LOST_ITEM_LOGGED", item)
+	return &item, nil
+}
+
+// GetItems lists the log, optionally filtered by status
+func (s *service) GetItems(status domain.LostItemStatus) ([]domain.LostItem, error) {
+	return s.repo.FetchLostItems(status)
+}
+
+func (s *service) GetItem(id int) (*domain.LostItem, error) {
+	item, err := s.repo.FindLostItem(id)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, domain.ErrLostItemNotFound
+	}
+
+	photos, err := s.storageSvc.List(domain.AttachmentOwnerLostItem, id)
+	if err != nil {
+		return nil, err
+	}
+	item.Photos = photos
+	return item, nil
+}
+
+func (s *service) AttachPhoto(id int, photo domain.Upload, actor domain.Actor) (*domain.Attachment, error) {
+	item, err := s.repo.FindLostItem(id)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, domain.ErrLostItemNotFound
+	}
+	return s.storageSvc.Upload(domain.AttachmentOwnerLostItem, id, photo.FileName, photo.Data, actor)
+}
+
+// storedItem loads an item that is still in the hotel's custody
+func (s *service) storedItem(id int) (*domain.LostItem, error) {
+	item, err := s.GetItem(id)
+	if err != nil {
+		return nil, err
+	}
+	if item.Status != domain.LostItemStored {
+		return nil, domain.ErrLostItemClosed
+	}
+	return item, nil
+}
+
+// ReturnItem hands the item back, to the matched guest unless someone else is named
+func (s *service) ReturnItem(id int, returnedTo, notes string, actor domain.Actor) (*domain.LostItem, error) {
+	item, err := s.storedItem(id)
+	if err != nil {
+		return nil, err
+	}
+	if returnedTo == "" {
+		if item.GuestName == "" {
+			return nil, domain.ErrReturnRecipientEmpty
+		}
+		returnedTo = item.GuestName
+	}
+
+	now := time.Now()
+	item.Status = domain.LostItemReturned
+	item.ReturnedTo = returnedTo
+	item.ReturnedAt = &now
+	s.closeWith(item, notes, actor)
+	if err := s.repo.CloseLostItem(item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// DisposeItem records how an unclaimed item left the hotel, once its retention period is over
+func (s *service) DisposeItem(id int, method, notes string, actor domain.Actor) (*domain.LostItem, error) {
+	if !domain.IsValidDisposal(method) {
+		return nil, domain.ErrInvalidDisposal
+	}
+	item, err := s.storedItem(id)
+	if err != nil {
+		return nil, err
+	}
+	// Documents and valuables may be handed to the police at any time
+	if domain.Today().Before(item.RetainUntil) && method != domain.DisposalPolice {
+		return nil, fmt.Errorf("%w: kept until %s", domain.ErrRetentionNotOver, item.RetainUntil.Format("2006-01-02"))
+	}
+
+	now := time.Now()
+	item.Status = domain.LostItemDisposed
+	item.DisposalMethod = method
+	item.DisposedAt = &now
+	s.closeWith(item, notes, actor)
+	if err := s.repo.CloseLostItem(item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// closeWith records who handled the item and appends the closing note
+func (s *service) closeWith(item *domain.LostItem, notes string, actor domain.Actor) {
+	if actor.ID != 0 {
+		item.HandledBy = &actor.ID
+	}
+	if notes == "" {
+		return
+	}
+	if item.Notes != "" {
+		item.Notes += "\n"
+	}
+	item.Notes += notes
+}
+
+func (s *service) GetDueForDisposal() ([]domain.LostItem, error) {
+	return s.repo.FetchItemsPastRetention(domain.Today())
+}
+
+// SendDisposalReminders alerts the staff once about every item whose retention period ended
+func (s *service) SendDisposalReminders(today time.Time) (int, error) {
+	items, err := s.repo.FetchItemsPastRetention(today)
+	if err != nil {
+		return 0, err
+	}
+
+	var due []domain.LostItem
+	var ids []int
+	for _, item := range items {
+		if item.ReminderSentAt == nil {
+			due = append(due, item)
+			ids = append(ids, item.ID)
+		}
+	}
+	if len(due) == 0 {
+		return 0, nil
+	}
+
+	if err := s.repo.MarkReminderSent(ids, time.Now()); err != nil {
+		return 0, err
+	}
+	s.hub.BroadcastToStaff("LOST_ITEMS_DISPOSAL_DUE", due)
+	return len(due), nil
+}
+
+// RunDisposalReminder runs SendDisposalReminders periodically (start it in a goroutine)
+func (s *service) RunDisposalReminder(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for ; ; <-ticker.C {
+		sent, err := s.SendDisposalReminders(domain.Today())
+		if err != nil {
+			fmt.Println("Failed to send lost-and-found reminders:", err)
+			continue
+		}
+		if sent > 0 {
+			fmt.Println("Lost and found:", sent, "items are due for disposal")
+		}
+	}
+}
@@ -0,0 +1,26 @@
+-- +migrate Up
+-- Room inventory attributes managed from the manager console
+ALTER TABLE rooms ADD COLUMN IF NOT EXISTS floor INT;
+ALTER TABLE rooms ADD COLUMN IF NOT EXISTS features TEXT[] DEFAULT '{}';
+ALTER TABLE rooms ADD COLUMN IF NOT EXISTS max_occupancy INT DEFAULT 2;
+
+-- Out-of-order window (e.g. renovation, broken AC)
+ALTER TABLE rooms ADD COLUMN IF NOT EXISTS out_of_order_from DATE;
+ALTER TABLE rooms ADD COLUMN IF NOT EXISTS out_of_order_to DATE;
+ALTER TABLE rooms ADD COLUMN IF NOT EXISTS out_of_order_reason TEXT;
+
+-- Retired rooms are kept for history but hidden from inventory
+ALTER TABLE rooms ADD COLUMN IF NOT EXISTS retired_at TIMESTAMP;
+
+-- Backfill floor from the room number ('203' -> 2)
+UPDATE rooms SET floor = CAST(LEFT(room_number, LENGTH(room_number) - 2) AS INT)
+WHERE floor IS NULL AND room_number ~ '^[0-9]{3,}$';
+
+-- +migrate Down
+ALTER TABLE rooms DROP COLUMN IF EXISTS retired_at;
+ALTER TABLE rooms DROP COLUMN IF EXISTS out_of_order_reason;
+ALTER TABLE rooms DROP COLUMN IF EXISTS out_of_order_to;
+ALTER TABLE rooms DROP COLUMN IF EXISTS out_of_order_from;
+ALTER TABLE rooms DROP COLUMN IF EXISTS max_occupancy;
+ALTER TABLE rooms DROP COLUMN IF EXISTS features;
+ALTER TABLE rooms DROP COLUMN IF EXISTS floor;
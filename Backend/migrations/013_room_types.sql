@@ -0,0 +1,46 @@
+-- +migrate Up
+-- 1. Room Types (what the guest actually books)
+CREATE TABLE IF NOT EXISTS room_types (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(50) NOT NULL UNIQUE,       -- e.g. 'Deluxe Suite'
+    description TEXT,
+    base_occupancy INT NOT NULL DEFAULT 2,  -- Guests included in the base rate
+    max_occupancy INT NOT NULL DEFAULT 2,
+    bed_configuration VARCHAR(100),         -- e.g. '1 King', '2 Queens'
+    amenities TEXT[] DEFAULT '{}',
+    photos TEXT[] DEFAULT '{}',
+    base_rate DECIMAL(10, 2) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- 2. Seed the types already used by the room inventory
+INSERT INTO room_types (name, description, base_occupancy, max_occupancy, bed_configuration, amenities, base_rate) VALUES
+('Standard Room', 'Comfortable room with city view', 2, 2, '1 Queen', '{"WiFi","TV","Air Conditioning"}', 100.00),
+('Deluxe Suite', 'Spacious suite with separate living area', 2, 3, '1 King', '{"WiFi","TV","Air Conditioning","Minibar","Bathtub"}', 150.00),
+('Presidential Suite', 'Top floor suite with panoramic views', 2, 4, '1 King + Sofa Bed', '{"WiFi","TV","Air Conditioning","Minibar","Jacuzzi","Butler Service"}', 500.00)
+ON CONFLICT (name) DO NOTHING;
+
+-- Catch any free-form types created through the API before this migration
+INSERT INTO room_types (name, base_rate)
+SELECT type, MAX(price) FROM rooms GROUP BY type
+ON CONFLICT (name) DO NOTHING;
+
+-- 3. Rooms reference their type; price becomes an optional per-room override
+ALTER TABLE rooms ADD COLUMN IF NOT EXISTS room_type_id INT REFERENCES room_types(id);
+UPDATE rooms r SET room_type_id = t.id FROM room_types t WHERE t.name = r.type AND r.room_type_id IS NULL;
+ALTER TABLE rooms ALTER COLUMN price DROP NOT NULL;
+UPDATE rooms r SET price = NULL FROM room_types t WHERE t.id = r.room_type_id AND r.price = t.base_rate;
+
+-- 4. Max occupancy is inherited from the type too. 012 defaulted every room to 2,
+-- so only values that differ from both that default and the type are kept as overrides
+ALTER TABLE rooms ALTER COLUMN max_occupancy DROP DEFAULT;
+UPDATE rooms r SET max_occupancy = NULL FROM room_types t
+WHERE t.id = r.room_type_id AND (r.max_occupancy = 2 OR r.max_occupancy = t.max_occupancy);
+
+-- +migrate Down
+UPDATE rooms r SET max_occupancy = t.max_occupancy FROM room_types t WHERE t.id = r.room_type_id AND r.max_occupancy IS NULL;
+ALTER TABLE rooms ALTER COLUMN max_occupancy SET DEFAULT 2;
+UPDATE rooms r SET price = t.base_rate FROM room_types t WHERE t.id = r.room_type_id AND r.price IS NULL;
+ALTER TABLE rooms ALTER COLUMN price SET NOT NULL;
+ALTER TABLE rooms DROP COLUMN IF EXISTS room_type_id;
+DROP TABLE IF EXISTS room_types;
@@ -0,0 +1,29 @@
+-- +migrate Up
+-- 1. Normalise legacy values: "CLEANING" was an occupancy status, now it is a housekeeping status
+UPDATE rooms SET housekeeping_status = 'CLEANING' WHERE housekeeping_status = 'IN_PROGRESS';
+UPDATE rooms SET status = 'VACANT', housekeeping_status = 'CLEANING' WHERE status = 'CLEANING';
+UPDATE rooms SET housekeeping_status = 'CLEAN' WHERE housekeeping_status IS NULL;
+ALTER TABLE rooms ALTER COLUMN housekeeping_status SET NOT NULL;
+-- rooms.status: VACANT, OCCUPIED
+-- rooms.housekeeping_status: CLEAN, DIRTY, REQUESTED_CLEANING, CLEANING, INSPECTED, DND
+
+-- 2. Audit trail of every room state change
+CREATE TABLE IF NOT EXISTS room_status_history (
+    id SERIAL PRIMARY KEY,
+    room_number VARCHAR(10) NOT NULL REFERENCES rooms(room_number),
+    from_status VARCHAR(20) NOT NULL,
+    from_housekeeping_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    to_housekeeping_status VARCHAR(20) NOT NULL,
+    changed_by_id INT NOT NULL DEFAULT 0,           -- Staff or Guest ID (0 = system)
+    changed_by_role VARCHAR(20) NOT NULL,           -- MANAGER, HOUSEKEEPING, GUEST, SYSTEM...
+    reason TEXT,
+    changed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_room_status_history_room ON room_status_history(room_number, changed_at DESC);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_room_status_history_room;
+DROP TABLE IF EXISTS room_status_history;
+ALTER TABLE rooms ALTER COLUMN housekeeping_status DROP NOT NULL;
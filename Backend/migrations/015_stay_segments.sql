@@ -0,0 +1,29 @@
+-- +migrate Up
+-- A stay is split into room-night segments: check-in, room moves and extensions
+-- each open a new segment so the invoice can show (and price) them separately.
+CREATE TABLE IF NOT EXISTS stay_segments (
+    id SERIAL PRIMARY KEY,
+    guest_id INT NOT NULL REFERENCES guests(id),
+    room_number VARCHAR(10) NOT NULL REFERENCES rooms(room_number),
+    nightly_rate DECIMAL(10, 2) NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    reason VARCHAR(20) NOT NULL DEFAULT 'CHECK_IN', -- CHECK_IN, ROOM_MOVE, EXTENSION
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_stay_segments_guest ON stay_segments(guest_id, start_date);
+
+-- Backfill one segment per existing stay at the room's current rate
+INSERT INTO stay_segments (guest_id, room_number, nightly_rate, start_date, end_date, reason)
+SELECT g.id, g.room_number, COALESCE(r.price, t.base_rate, 100.00),
+       COALESCE(g.check_in_date, g.created_at::date),
+       COALESCE(g.check_out_date, COALESCE(g.check_in_date, g.created_at::date) + 1),
+       'CHECK_IN'
+FROM guests g
+JOIN rooms r ON r.room_number = g.room_number
+LEFT JOIN room_types t ON t.id = r.room_type_id;
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_stay_segments_guest;
+DROP TABLE IF EXISTS stay_segments;
@@ -0,0 +1,55 @@
+-- +migrate Up
+-- 1. Groups (weddings, conferences) with a master folio
+CREATE TABLE IF NOT EXISTS booking_groups (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    contact_name VARCHAR(100),
+    contact_phone VARCHAR(20),
+    check_in_date DATE NOT NULL,
+    check_out_date DATE NOT NULL,
+    cutoff_date DATE NOT NULL,                         -- Unassigned rooms are released after this date
+    bill_room_to_master BOOLEAN DEFAULT TRUE,          -- Charge routing: master folio vs individual guest
+    bill_restaurant_to_master BOOLEAN DEFAULT FALSE,
+    bill_laundry_to_master BOOLEAN DEFAULT FALSE,
+    status VARCHAR(20) DEFAULT 'ACTIVE',               -- ACTIVE, CLOSED
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- 2. Rooms held for the group
+CREATE TABLE IF NOT EXISTS group_room_blocks (
+    id SERIAL PRIMARY KEY,
+    group_id INT NOT NULL REFERENCES booking_groups(id),
+    room_number VARCHAR(10) NOT NULL REFERENCES rooms(room_number),
+    guest_id INT REFERENCES guests(id),
+    status VARCHAR(20) DEFAULT 'HELD',                 -- HELD, ASSIGNED, RELEASED
+    released_at TIMESTAMP
+);
+
+CREATE INDEX idx_group_room_blocks_room ON group_room_blocks(room_number, status);
+
+-- 3. Guests can belong to a group
+ALTER TABLE guests ADD COLUMN IF NOT EXISTS group_id INT REFERENCES booking_groups(id);
+
+-- 4. Charges routed to the master folio when members check out
+CREATE TABLE IF NOT EXISTS group_folio_entries (
+    id SERIAL PRIMARY KEY,
+    group_id INT NOT NULL REFERENCES booking_groups(id),
+    guest_id INT NOT NULL REFERENCES guests(id),
+    charge_type VARCHAR(20) NOT NULL,                  -- ROOM, RESTAURANT, LAUNDRY
+    description TEXT,
+    amount DECIMAL(10, 2) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- 5. Invoices record what was sent to the master folio
+ALTER TABLE invoices ADD COLUMN IF NOT EXISTS group_id INT REFERENCES booking_groups(id);
+ALTER TABLE invoices ADD COLUMN IF NOT EXISTS group_charge DECIMAL(10, 2) DEFAULT 0.00;
+
+-- +migrate Down
+ALTER TABLE invoices DROP COLUMN IF EXISTS group_charge;
+ALTER TABLE invoices DROP COLUMN IF EXISTS group_id;
+DROP TABLE IF EXISTS group_folio_entries;
+ALTER TABLE guests DROP COLUMN IF EXISTS group_id;
+DROP INDEX IF EXISTS idx_group_room_blocks_room;
+DROP TABLE IF EXISTS group_room_blocks;
+DROP TABLE IF EXISTS booking_groups;
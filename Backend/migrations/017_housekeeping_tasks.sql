@@ -0,0 +1,36 @@
+-- +migrate Up
+-- 1. Workload credits per room type (a full checkout clean of the room)
+ALTER TABLE room_types ADD COLUMN IF NOT EXISTS cleaning_credits DECIMAL(4, 1) NOT NULL DEFAULT 1.0;
+UPDATE room_types SET cleaning_credits = 1.5 WHERE name = 'Deluxe Suite';
+UPDATE room_types SET cleaning_credits = 3.0 WHERE name = 'Presidential Suite';
+
+-- 2. Who is working right now
+ALTER TABLE staff ADD COLUMN IF NOT EXISTS on_shift BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE staff ADD COLUMN IF NOT EXISTS shift_started_at TIMESTAMP;
+
+-- 3. Housekeeping tasks generated from room states
+CREATE TABLE IF NOT EXISTS housekeeping_tasks (
+    id SERIAL PRIMARY KEY,
+    room_number VARCHAR(10) NOT NULL REFERENCES rooms(room_number),
+    task_type VARCHAR(20) NOT NULL,                -- CHECKOUT_CLEAN, STAYOVER_CLEAN, TURNDOWN, INSPECTION
+    task_date DATE NOT NULL,
+    credits DECIMAL(4, 2) NOT NULL DEFAULT 1.0,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING', -- PENDING, ASSIGNED, IN_PROGRESS, DONE, CANCELLED
+    assigned_to INT REFERENCES staff(id),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP,
+    finished_at TIMESTAMP
+);
+
+-- One live task of each type per room per day
+CREATE UNIQUE INDEX idx_housekeeping_tasks_unique
+    ON housekeeping_tasks(room_number, task_type, task_date) WHERE status != 'CANCELLED';
+CREATE INDEX idx_housekeeping_tasks_assignee ON housekeeping_tasks(assigned_to, task_date);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_housekeeping_tasks_assignee;
+DROP INDEX IF EXISTS idx_housekeeping_tasks_unique;
+DROP TABLE IF EXISTS housekeeping_tasks;
+ALTER TABLE staff DROP COLUMN IF EXISTS shift_started_at;
+ALTER TABLE staff DROP COLUMN IF EXISTS on_shift;
+ALTER TABLE room_types DROP COLUMN IF EXISTS cleaning_credits;
@@ -0,0 +1,52 @@
+-- +migrate Up
+-- 1. Checklist items per room type (what the supervisor walks through)
+CREATE TABLE IF NOT EXISTS inspection_checklist_items (
+    id SERIAL PRIMARY KEY,
+    room_type_id INT NOT NULL REFERENCES room_types(id),
+    area VARCHAR(30) NOT NULL,          -- BATHROOM, LINEN, MINIBAR, FLOOR, ...
+    description TEXT NOT NULL,
+    sort_order INT NOT NULL DEFAULT 0,
+    active BOOLEAN NOT NULL DEFAULT TRUE -- Replaced items are kept for old inspections
+);
+
+-- Seed a default checklist for every existing room type
+INSERT INTO inspection_checklist_items (room_type_id, area, description, sort_order)
+SELECT t.id, c.area, c.description, c.sort_order
+FROM room_types t
+CROSS JOIN (VALUES
+    ('BATHROOM', 'Toilet, shower and sink cleaned and dry', 1),
+    ('BATHROOM', 'Towels and toiletries restocked', 2),
+    ('LINEN', 'Bed made with fresh linen, no stains', 3),
+    ('FLOOR', 'Floor vacuumed, no debris under furniture', 4),
+    ('MINIBAR', 'Minibar restocked and counted', 5),
+    ('GENERAL', 'Lights, TV and AC working', 6)
+) AS c(area, description, sort_order);
+
+-- 2. One row per supervisor inspection
+CREATE TABLE IF NOT EXISTS room_inspections (
+    id SERIAL PRIMARY KEY,
+    room_number VARCHAR(10) NOT NULL REFERENCES rooms(room_number),
+    cleaning_task_id INT REFERENCES housekeeping_tasks(id), -- The clean being inspected
+    attendant_id INT REFERENCES staff(id),                  -- Who cleaned the room
+    inspector_id INT REFERENCES staff(id),
+    passed BOOLEAN NOT NULL,
+    notes TEXT,
+    inspected_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_room_inspections_attendant ON room_inspections(attendant_id, inspected_at);
+
+-- 3. The answer for each checklist item
+CREATE TABLE IF NOT EXISTS room_inspection_results (
+    inspection_id INT NOT NULL REFERENCES room_inspections(id) ON DELETE CASCADE,
+    checklist_item_id INT NOT NULL REFERENCES inspection_checklist_items(id),
+    passed BOOLEAN NOT NULL,
+    note TEXT,
+    PRIMARY KEY (inspection_id, checklist_item_id)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS room_inspection_results;
+DROP INDEX IF EXISTS idx_room_inspections_attendant;
+DROP TABLE IF EXISTS room_inspections;
+DROP TABLE IF EXISTS inspection_checklist_items;
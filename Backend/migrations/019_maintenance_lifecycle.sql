@@ -0,0 +1,39 @@
+-- +migrate Up
+-- 1. Assignment, SLA and resolution details on tickets
+ALTER TABLE maintenance_tickets ADD COLUMN IF NOT EXISTS assigned_to INT REFERENCES staff(id);
+ALTER TABLE maintenance_tickets ADD COLUMN IF NOT EXISTS assigned_at TIMESTAMP;
+ALTER TABLE maintenance_tickets ADD COLUMN IF NOT EXISTS started_at TIMESTAMP;
+ALTER TABLE maintenance_tickets ADD COLUMN IF NOT EXISTS on_hold_reason TEXT;
+ALTER TABLE maintenance_tickets ADD COLUMN IF NOT EXISTS sla_due_at TIMESTAMP;
+ALTER TABLE maintenance_tickets ADD COLUMN IF NOT EXISTS escalated_at TIMESTAMP;
+ALTER TABLE maintenance_tickets ADD COLUMN IF NOT EXISTS resolution_notes TEXT;
+ALTER TABLE maintenance_tickets ADD COLUMN IF NOT EXISTS resolved_by INT REFERENCES staff(id);
+-- status: OPEN, IN_PROGRESS, ON_HOLD, RESOLVED
+-- priority: URGENT, HIGH, NORMAL, LOW
+
+-- Existing tickets get the NORMAL (24h) SLA
+UPDATE maintenance_tickets SET sla_due_at = created_at + INTERVAL '24 hours' WHERE sla_due_at IS NULL;
+ALTER TABLE maintenance_tickets ALTER COLUMN sla_due_at SET NOT NULL;
+
+CREATE INDEX idx_maintenance_tickets_sla ON maintenance_tickets(status, sla_due_at);
+
+-- 2. Parts consumed by a repair
+CREATE TABLE IF NOT EXISTS maintenance_ticket_parts (
+    id SERIAL PRIMARY KEY,
+    ticket_id INT NOT NULL REFERENCES maintenance_tickets(id) ON DELETE CASCADE,
+    part_name VARCHAR(100) NOT NULL,
+    quantity INT NOT NULL DEFAULT 1,
+    unit_cost DECIMAL(10, 2) NOT NULL DEFAULT 0.00
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS maintenance_ticket_parts;
+DROP INDEX IF EXISTS idx_maintenance_tickets_sla;
+ALTER TABLE maintenance_tickets DROP COLUMN IF EXISTS resolved_by;
+ALTER TABLE maintenance_tickets DROP COLUMN IF EXISTS resolution_notes;
+ALTER TABLE maintenance_tickets DROP COLUMN IF EXISTS escalated_at;
+ALTER TABLE maintenance_tickets DROP COLUMN IF EXISTS sla_due_at;
+ALTER TABLE maintenance_tickets DROP COLUMN IF EXISTS on_hold_reason;
+ALTER TABLE maintenance_tickets DROP COLUMN IF EXISTS started_at;
+ALTER TABLE maintenance_tickets DROP COLUMN IF EXISTS assigned_at;
+ALTER TABLE maintenance_tickets DROP COLUMN IF EXISTS assigned_to;
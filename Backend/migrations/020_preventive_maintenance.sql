@@ -0,0 +1,52 @@
+-- +migrate Up
+-- 1. Asset register: equipment in rooms and public areas
+CREATE TABLE IF NOT EXISTS assets (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,                         -- e.g. 'Split AC 1.5T'
+    category VARCHAR(30) NOT NULL,                      -- AC, BOILER, ELEVATOR, GENERATOR, ...
+    room_number VARCHAR(10) REFERENCES rooms(room_number),
+    location VARCHAR(100),                              -- Public areas: 'Lobby', 'Roof', 'Basement'
+    serial_number VARCHAR(100),
+    installed_at DATE,
+    retired_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (room_number IS NOT NULL OR location IS NOT NULL)
+);
+
+-- 2. Recurrence rules against a room or an asset
+CREATE TABLE IF NOT EXISTS maintenance_schedules (
+    id SERIAL PRIMARY KEY,
+    asset_id INT REFERENCES assets(id),
+    room_number VARCHAR(10) REFERENCES rooms(room_number),
+    title VARCHAR(100) NOT NULL,                        -- e.g. 'Clean AC filter'
+    issue_type VARCHAR(50) NOT NULL,
+    interval_count INT NOT NULL,                        -- Every N ...
+    interval_unit VARCHAR(10) NOT NULL,                 -- ... DAY, WEEK, MONTH
+    lead_days INT NOT NULL DEFAULT 7,                   -- Ticket is created this many days before due
+    next_due_date DATE NOT NULL,
+    blocks_room BOOLEAN NOT NULL DEFAULT FALSE,         -- Put the room out of order while the work is done
+    block_days INT NOT NULL DEFAULT 1,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    last_generated_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (asset_id IS NOT NULL OR room_number IS NOT NULL),
+    CHECK (interval_count > 0)
+);
+
+CREATE INDEX idx_maintenance_schedules_due ON maintenance_schedules(active, next_due_date);
+
+-- 3. Tickets know where they came from; public-area tickets have no room
+ALTER TABLE maintenance_tickets ALTER COLUMN room_number DROP NOT NULL;
+ALTER TABLE maintenance_tickets ADD COLUMN IF NOT EXISTS schedule_id INT REFERENCES maintenance_schedules(id);
+ALTER TABLE maintenance_tickets ADD COLUMN IF NOT EXISTS asset_id INT REFERENCES assets(id);
+ALTER TABLE maintenance_tickets ADD COLUMN IF NOT EXISTS blocks_room BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +migrate Down
+ALTER TABLE maintenance_tickets DROP COLUMN IF EXISTS blocks_room;
+ALTER TABLE maintenance_tickets DROP COLUMN IF EXISTS asset_id;
+ALTER TABLE maintenance_tickets DROP COLUMN IF EXISTS schedule_id;
+DELETE FROM maintenance_tickets WHERE room_number IS NULL;
+ALTER TABLE maintenance_tickets ALTER COLUMN room_number SET NOT NULL;
+DROP INDEX IF EXISTS idx_maintenance_schedules_due;
+DROP TABLE IF EXISTS maintenance_schedules;
+DROP TABLE IF EXISTS assets;
@@ -0,0 +1,58 @@
+-- +migrate Up
+-- 1. What guests can ask for
+CREATE TABLE IF NOT EXISTS amenity_items (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(30) NOT NULL UNIQUE,            -- e.g. 'TOWEL'
+    name VARCHAR(100) NOT NULL,
+    category VARCHAR(20) NOT NULL,               -- LINEN, CONSUMABLE
+    per_stay_limit INT,                          -- NULL = unlimited
+    price DECIMAL(10, 2) NOT NULL DEFAULT 0.00,  -- > 0 means the item is charged to the folio
+    stock_on_hand INT NOT NULL DEFAULT 0,
+    par_level INT NOT NULL DEFAULT 0,            -- Alert when stock falls to this level
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO amenity_items (code, name, category, per_stay_limit, price, stock_on_hand, par_level) VALUES
+('TOWEL', 'Bath Towel', 'LINEN', 4, 0.00, 200, 40),
+('EXTRA_PILLOW', 'Extra Pillow', 'LINEN', 2, 0.00, 50, 10),
+('BLANKET', 'Extra Blanket', 'LINEN', 1, 0.00, 40, 8),
+('TOOTHBRUSH_KIT', 'Toothbrush Kit', 'CONSUMABLE', 2, 0.00, 300, 60),
+('WATER_BOTTLE', 'Water Bottle (1L)', 'CONSUMABLE', NULL, 2.00, 500, 100),
+('SLIPPERS', 'Slippers', 'CONSUMABLE', 2, 5.00, 100, 20)
+ON CONFLICT (code) DO NOTHING;
+
+-- 2. Stock ledger: every change to stock_on_hand
+CREATE TABLE IF NOT EXISTS amenity_stock_movements (
+    id SERIAL PRIMARY KEY,
+    item_id INT NOT NULL REFERENCES amenity_items(id),
+    change INT NOT NULL,                         -- Negative for deliveries
+    reason VARCHAR(20) NOT NULL,                 -- DELIVERY, RESTOCK, ADJUSTMENT
+    amenity_request_id INT REFERENCES amenity_requests(id),
+    staff_id INT,
+    note TEXT,
+    balance_after INT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_amenity_stock_movements_item ON amenity_stock_movements(item_id, created_at);
+
+-- 3. Requests point at the catalog and carry their price
+ALTER TABLE amenity_requests ADD COLUMN IF NOT EXISTS item_id INT REFERENCES amenity_items(id);
+ALTER TABLE amenity_requests ADD COLUMN IF NOT EXISTS unit_price DECIMAL(10, 2) NOT NULL DEFAULT 0.00;
+ALTER TABLE amenity_requests ADD COLUMN IF NOT EXISTS total_price DECIMAL(10, 2) NOT NULL DEFAULT 0.00;
+ALTER TABLE amenity_requests ADD COLUMN IF NOT EXISTS delivered_at TIMESTAMP;
+-- status: PENDING, DELIVERED, PAID
+
+-- 4. Chargeable amenities on the invoice
+ALTER TABLE invoices ADD COLUMN IF NOT EXISTS amenity_charge DECIMAL(10, 2) NOT NULL DEFAULT 0.00;
+
+-- +migrate Down
+ALTER TABLE invoices DROP COLUMN IF EXISTS amenity_charge;
+ALTER TABLE amenity_requests DROP COLUMN IF EXISTS delivered_at;
+ALTER TABLE amenity_requests DROP COLUMN IF EXISTS total_price;
+ALTER TABLE amenity_requests DROP COLUMN IF EXISTS unit_price;
+ALTER TABLE amenity_requests DROP COLUMN IF EXISTS item_id;
+DROP INDEX IF EXISTS idx_amenity_stock_movements_item;
+DROP TABLE IF EXISTS amenity_stock_movements;
+DROP TABLE IF EXISTS amenity_items;
@@ -0,0 +1,21 @@
+-- +migrate Up
+-- Photos and other files attached to tickets, lost-and-found items...
+CREATE TABLE IF NOT EXISTS attachments (
+    id SERIAL PRIMARY KEY,
+    owner_type VARCHAR(30) NOT NULL,             -- MAINTENANCE_TICKET, LOST_ITEM
+    owner_id INT NOT NULL,
+    file_name VARCHAR(255) NOT NULL,             -- Original name from the upload
+    content_type VARCHAR(50) NOT NULL,           -- Sniffed from the bytes, not trusted from the client
+    size_bytes BIGINT NOT NULL,
+    storage_key VARCHAR(255) NOT NULL UNIQUE,    -- Key in the file store (e.g. 'maintenance_ticket/12/ab34.jpg')
+    thumbnail_key VARCHAR(255),
+    uploaded_by INT,
+    uploader_role VARCHAR(20) NOT NULL,          -- Staff role or GUEST
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_attachments_owner ON attachments(owner_type, owner_id);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_attachments_owner;
+DROP TABLE IF EXISTS attachments;
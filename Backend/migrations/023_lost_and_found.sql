@@ -0,0 +1,28 @@
+-- +migrate Up
+-- Items found by staff (mostly in rooms after checkout)
+CREATE TABLE IF NOT EXISTS lost_items (
+    id SERIAL PRIMARY KEY,
+    room_number VARCHAR(10) NOT NULL REFERENCES rooms(room_number),
+    found_on DATE NOT NULL,
+    found_by INT,                                -- Staff member who logged it
+    description TEXT NOT NULL,
+    category VARCHAR(20) NOT NULL,               -- VALUABLES, ELECTRONICS, DOCUMENTS, CLOTHING, PERISHABLE, OTHER
+    storage_location VARCHAR(100) NOT NULL,      -- e.g. 'Safe B, shelf 2'
+    guest_id INT REFERENCES guests(id),          -- Last guest of the room, when one was found
+    status VARCHAR(20) NOT NULL DEFAULT 'STORED', -- STORED, RETURNED, DISPOSED
+    retain_until DATE NOT NULL,
+    returned_to VARCHAR(100),
+    returned_at TIMESTAMP,
+    disposal_method VARCHAR(20),                 -- DONATED, DISCARDED, POLICE
+    disposed_at TIMESTAMP,
+    handled_by INT,                              -- Staff member who returned or disposed of it
+    notes TEXT,
+    reminder_sent_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_lost_items_status ON lost_items(status, retain_until);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_lost_items_status;
+DROP TABLE IF EXISTS lost_items;
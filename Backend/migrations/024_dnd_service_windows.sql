@@ -0,0 +1,26 @@
+-- +migrate Up
+-- 1. How long a room has been on Do Not Disturb (welfare checks)
+ALTER TABLE rooms ADD COLUMN IF NOT EXISTS dnd_since TIMESTAMP;
+ALTER TABLE rooms ADD COLUMN IF NOT EXISTS dnd_alerted_at TIMESTAMP;  -- Welfare check already raised for this DND
+UPDATE rooms SET dnd_since = NOW() WHERE housekeeping_status = 'DND' AND dnd_since IS NULL;
+
+-- 2. Preferred cleaning time chosen by the in-house guest
+CREATE TABLE IF NOT EXISTS cleaning_windows (
+    room_number VARCHAR(10) PRIMARY KEY REFERENCES rooms(room_number),
+    guest_id INT NOT NULL REFERENCES guests(id),  -- Only applies while this guest is checked in
+    window_start TIME NOT NULL,
+    window_end TIME NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (window_start < window_end)
+);
+
+-- 3. Tasks carry the window so attendants plan around it
+ALTER TABLE housekeeping_tasks ADD COLUMN IF NOT EXISTS window_start TIME;
+ALTER TABLE housekeeping_tasks ADD COLUMN IF NOT EXISTS window_end TIME;
+
+-- +migrate Down
+ALTER TABLE housekeeping_tasks DROP COLUMN IF EXISTS window_end;
+ALTER TABLE housekeeping_tasks DROP COLUMN IF EXISTS window_start;
+DROP TABLE IF EXISTS cleaning_windows;
+ALTER TABLE rooms DROP COLUMN IF EXISTS dnd_alerted_at;
+ALTER TABLE rooms DROP COLUMN IF EXISTS dnd_since;
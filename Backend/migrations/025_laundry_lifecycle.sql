@@ -0,0 +1,20 @@
+-- +migrate Up
+-- 1. Map the old IN_PROGRESS state onto the formal lifecycle
+UPDATE laundry_requests SET status = 'WASHING' WHERE status = 'IN_PROGRESS';
+ALTER TABLE laundry_requests ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP;
+
+-- 2. One row per status change (who moved it, and when)
+CREATE TABLE IF NOT EXISTS laundry_request_events (
+    id SERIAL PRIMARY KEY,
+    request_id INT NOT NULL REFERENCES laundry_requests(id),
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    actor_id INT NOT NULL DEFAULT 0,
+    actor_role VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_laundry_events_request ON laundry_request_events(request_id);
+
+-- +migrate Down
+DROP TABLE IF EXISTS laundry_request_events;
+ALTER TABLE laundry_requests DROP COLUMN IF EXISTS updated_at;
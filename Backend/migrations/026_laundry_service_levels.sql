@@ -0,0 +1,18 @@
+-- +migrate Up
+-- Express / same-day service: surcharge is copied onto the request so later rule changes don't reprice it
+ALTER TABLE laundry_requests ADD COLUMN IF NOT EXISTS service_level VARCHAR(20) NOT NULL DEFAULT 'STANDARD';
+ALTER TABLE laundry_requests ADD COLUMN IF NOT EXISTS surcharge_pct DECIMAL(5, 2) NOT NULL DEFAULT 0;
+ALTER TABLE laundry_requests ADD COLUMN IF NOT EXISTS surcharge_amount DECIMAL(10, 2) NOT NULL DEFAULT 0;
+ALTER TABLE laundry_requests ADD COLUMN IF NOT EXISTS pickup_from TIMESTAMP;  -- Slot the guest asked for
+ALTER TABLE laundry_requests ADD COLUMN IF NOT EXISTS pickup_to TIMESTAMP;
+ALTER TABLE laundry_requests ADD COLUMN IF NOT EXISTS promised_at TIMESTAMP;  -- When the bag is due back in the room
+CREATE INDEX IF NOT EXISTS idx_laundry_requests_promised ON laundry_requests(promised_at);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_laundry_requests_promised;
+ALTER TABLE laundry_requests DROP COLUMN IF EXISTS promised_at;
+ALTER TABLE laundry_requests DROP COLUMN IF EXISTS pickup_to;
+ALTER TABLE laundry_requests DROP COLUMN IF EXISTS pickup_from;
+ALTER TABLE laundry_requests DROP COLUMN IF EXISTS surcharge_amount;
+ALTER TABLE laundry_requests DROP COLUMN IF EXISTS surcharge_pct;
+ALTER TABLE laundry_requests DROP COLUMN IF EXISTS service_level;
@@ -0,0 +1,15 @@
+-- +migrate Up
+-- Laundry menu management: categories, turnaround times, availability and soft delete
+ALTER TABLE laundry_items ADD COLUMN IF NOT EXISTS category VARCHAR(20) NOT NULL DEFAULT 'WASH_FOLD';
+ALTER TABLE laundry_items ADD COLUMN IF NOT EXISTS turnaround_hours INT NOT NULL DEFAULT 8;
+ALTER TABLE laundry_items ADD COLUMN IF NOT EXISTS is_available BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE laundry_items ADD COLUMN IF NOT EXISTS is_deleted BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Existing items: the dry clean flag becomes the category
+UPDATE laundry_items SET category = 'DRY_CLEAN', turnaround_hours = 24 WHERE is_dry_clean = TRUE;
+
+-- +migrate Down
+ALTER TABLE laundry_items DROP COLUMN IF EXISTS is_deleted;
+ALTER TABLE laundry_items DROP COLUMN IF EXISTS is_available;
+ALTER TABLE laundry_items DROP COLUMN IF EXISTS turnaround_hours;
+ALTER TABLE laundry_items DROP COLUMN IF EXISTS category;
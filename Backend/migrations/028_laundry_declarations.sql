@@ -0,0 +1,24 @@
+-- +migrate Up
+-- 1. What the guest says is in the bag
+CREATE TABLE IF NOT EXISTS laundry_declared_items (
+    id SERIAL PRIMARY KEY,
+    request_id INT NOT NULL REFERENCES laundry_requests(id),
+    item_id INT NOT NULL REFERENCES laundry_items(id),
+    quantity INT NOT NULL CHECK (quantity > 0)
+);
+CREATE INDEX IF NOT EXISTS idx_laundry_declared_request ON laundry_declared_items(request_id);
+
+-- 2. Damage / stain notes on the counted lines
+ALTER TABLE laundry_request_items ADD COLUMN IF NOT EXISTS notes TEXT;
+
+-- 3. Count differs from the declaration: the guest must confirm before it is billed
+ALTER TABLE laundry_requests ADD COLUMN IF NOT EXISTS awaiting_confirmation BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE laundry_requests ADD COLUMN IF NOT EXISTS count_confirmed_at TIMESTAMP;
+ALTER TABLE laundry_requests ADD COLUMN IF NOT EXISTS count_dispute TEXT;  -- Guest's comment when they reject the count
+
+-- +migrate Down
+ALTER TABLE laundry_requests DROP COLUMN IF EXISTS count_dispute;
+ALTER TABLE laundry_requests DROP COLUMN IF EXISTS count_confirmed_at;
+ALTER TABLE laundry_requests DROP COLUMN IF EXISTS awaiting_confirmation;
+ALTER TABLE laundry_request_items DROP COLUMN IF EXISTS notes;
+DROP TABLE IF EXISTS laundry_declared_items;
@@ -0,0 +1,57 @@
+-- +migrate Up
+-- 1. Modifier groups hang off a menu item ("Doneness", "Extras", "Remove")
+CREATE TABLE IF NOT EXISTS modifier_groups (
+    id SERIAL PRIMARY KEY,
+    menu_item_id INT NOT NULL REFERENCES menu_items(id),
+    name VARCHAR(50) NOT NULL,
+    min_select INT NOT NULL DEFAULT 0,  -- > 0 makes the group required
+    max_select INT NOT NULL DEFAULT 1,
+    position INT NOT NULL DEFAULT 0,
+    CHECK (min_select >= 0 AND max_select >= 1 AND min_select <= max_select)
+);
+CREATE INDEX IF NOT EXISTS idx_modifier_groups_item ON modifier_groups(menu_item_id);
+
+CREATE TABLE IF NOT EXISTS modifier_options (
+    id SERIAL PRIMARY KEY,
+    group_id INT NOT NULL REFERENCES modifier_groups(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    price_delta DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    is_available BOOLEAN NOT NULL DEFAULT TRUE,
+    position INT NOT NULL DEFAULT 0
+);
+
+-- 2. Chosen modifiers per order line, copied so menu edits don't rewrite old tickets
+ALTER TABLE restaurant_order_items ADD COLUMN IF NOT EXISTS modifiers_price DECIMAL(10, 2) NOT NULL DEFAULT 0;  -- Per unit
+CREATE TABLE IF NOT EXISTS restaurant_order_item_modifiers (
+    id SERIAL PRIMARY KEY,
+    order_item_id INT NOT NULL REFERENCES restaurant_order_items(id),
+    option_id INT REFERENCES modifier_options(id) ON DELETE SET NULL,
+    group_name VARCHAR(50) NOT NULL,
+    option_name VARCHAR(50) NOT NULL,
+    price_delta DECIMAL(10, 2) NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS idx_order_item_modifiers_line ON restaurant_order_item_modifiers(order_item_id);
+
+-- 3. Seed: the burger
+INSERT INTO modifier_groups (menu_item_id, name, min_select, max_select, position)
+SELECT id, g.name, g.min_select, g.max_select, g.position
+FROM menu_items, (VALUES ('Doneness', 1, 1, 1), ('Extras', 0, 3, 2), ('Remove', 0, 3, 3)) AS g(name, min_select, max_select, position)
+WHERE menu_items.name = 'Wagyu Burger'
+  AND NOT EXISTS (SELECT 1 FROM modifier_groups mg WHERE mg.menu_item_id = menu_items.id);
+
+INSERT INTO modifier_options (group_id, name, price_delta, position)
+SELECT mg.id, o.name, o.price_delta, o.position
+FROM modifier_groups mg
+JOIN menu_items m ON m.id = mg.menu_item_id AND m.name = 'Wagyu Burger'
+JOIN (VALUES
+    ('Doneness', 'Rare', 0.00, 1), ('Doneness', 'Medium-rare', 0.00, 2), ('Doneness', 'Medium', 0.00, 3), ('Doneness', 'Well done', 0.00, 4),
+    ('Extras', 'Bacon', 3.00, 1), ('Extras', 'Fried egg', 2.00, 2), ('Extras', 'Extra cheddar', 1.50, 3),
+    ('Remove', 'No onions', 0.00, 1), ('Remove', 'No truffle mayo', 0.00, 2), ('Remove', 'No fries', 0.00, 3)
+) AS o(group_name, name, price_delta, position) ON o.group_name = mg.name
+WHERE NOT EXISTS (SELECT 1 FROM modifier_options mo WHERE mo.group_id = mg.id);
+
+-- +migrate Down
+DROP TABLE IF EXISTS restaurant_order_item_modifiers;
+ALTER TABLE restaurant_order_items DROP COLUMN IF EXISTS modifiers_price;
+DROP TABLE IF EXISTS modifier_options;
+DROP TABLE IF EXISTS modifier_groups;
@@ -0,0 +1,29 @@
+-- +migrate Up
+-- Allergen and dietary tags on the menu, allergies on the guest profile, and an allergy flag on orders
+ALTER TABLE menu_items ADD COLUMN IF NOT EXISTS allergens TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE menu_items ADD COLUMN IF NOT EXISTS dietary TEXT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE guests ADD COLUMN IF NOT EXISTS allergies TEXT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE restaurant_orders ADD COLUMN IF NOT EXISTS allergy_alert BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE restaurant_orders ADD COLUMN IF NOT EXISTS allergens TEXT[] NOT NULL DEFAULT '{}'; -- The guest's allergens found in the order
+
+-- Seed: tag the dishes from 006
+UPDATE menu_items SET allergens = t.allergens, dietary = t.dietary
+FROM (VALUES
+    ('Eggs Benedict',      ARRAY['GLUTEN', 'EGGS', 'DAIRY'],           ARRAY[]::TEXT[]),
+    ('Pancakes Stack',     ARRAY['GLUTEN', 'EGGS', 'DAIRY'],           ARRAY['VEGETARIAN']),
+    ('Wagyu Burger',       ARRAY['GLUTEN', 'DAIRY', 'EGGS', 'SESAME'], ARRAY[]::TEXT[]),
+    ('Caesar Salad',       ARRAY['GLUTEN', 'DAIRY', 'EGGS', 'FISH'],   ARRAY[]::TEXT[]),
+    ('Club Sandwich',      ARRAY['GLUTEN', 'EGGS'],                    ARRAY[]::TEXT[]),
+    ('Fresh Orange Juice', ARRAY[]::TEXT[],                            ARRAY['VEGAN', 'VEGETARIAN', 'HALAL', 'KOSHER']),
+    ('Cappuccino',         ARRAY['DAIRY'],                             ARRAY['VEGETARIAN', 'HALAL', 'KOSHER'])
+) AS t(name, allergens, dietary)
+WHERE menu_items.name = t.name;
+
+-- +migrate Down
+ALTER TABLE restaurant_orders DROP COLUMN IF EXISTS allergens;
+ALTER TABLE restaurant_orders DROP COLUMN IF EXISTS allergy_alert;
+ALTER TABLE guests DROP COLUMN IF EXISTS allergies;
+ALTER TABLE menu_items DROP COLUMN IF EXISTS dietary;
+ALTER TABLE menu_items DROP COLUMN IF EXISTS allergens;
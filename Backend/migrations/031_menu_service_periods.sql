@@ -0,0 +1,33 @@
+-- +migrate Up
+-- 1. When a category (or a single item, overriding its category) can be ordered
+CREATE TABLE IF NOT EXISTS service_periods (
+    id SERIAL PRIMARY KEY,
+    category_id INT REFERENCES menu_categories(id) ON DELETE CASCADE,
+    menu_item_id INT REFERENCES menu_items(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,          -- e.g. "Breakfast", "Late night"
+    starts_at TIME NOT NULL,
+    ends_at TIME NOT NULL,              -- Before starts_at: runs past midnight
+    days INT[] NOT NULL DEFAULT '{}',   -- Days the period starts on, 0 = Sunday; empty = every day
+    CHECK ((category_id IS NULL) <> (menu_item_id IS NULL)),
+    CHECK (starts_at <> ends_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_service_periods_category ON service_periods(category_id);
+CREATE INDEX IF NOT EXISTS idx_service_periods_item ON service_periods(menu_item_id);
+
+-- 2. Items 86'd by the kitchen come back on their own at the next service
+ALTER TABLE menu_items ADD COLUMN IF NOT EXISTS sold_out_until TIMESTAMP;
+
+-- 3. Seed: breakfast runs later at the weekend
+INSERT INTO service_periods (category_id, name, starts_at, ends_at, days)
+SELECT c.id, p.name, p.starts_at::TIME, p.ends_at::TIME, p.days
+FROM menu_categories c, (VALUES
+    ('Breakfast', '06:00', '11:00', ARRAY[1, 2, 3, 4, 5]),
+    ('Weekend breakfast', '07:00', '12:00', ARRAY[0, 6])
+) AS p(name, starts_at, ends_at, days)
+WHERE c.name = 'Breakfast'
+  AND NOT EXISTS (SELECT 1 FROM service_periods sp WHERE sp.category_id = c.id);
+
+-- +migrate Down
+ALTER TABLE menu_items DROP COLUMN IF EXISTS sold_out_until;
+DROP TABLE IF EXISTS service_periods;
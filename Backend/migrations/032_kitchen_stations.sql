@@ -0,0 +1,32 @@
+-- +migrate Up
+-- 1. Station routing: categories pick the station, items may override it
+ALTER TABLE menu_categories ADD COLUMN IF NOT EXISTS station VARCHAR(10) NOT NULL DEFAULT 'GRILL';
+ALTER TABLE menu_items ADD COLUMN IF NOT EXISTS station VARCHAR(10);  -- NULL = the category's station
+
+UPDATE menu_categories SET station = 'BAR' WHERE name = 'Beverages';
+UPDATE menu_items SET station = 'COLD' WHERE name = 'Caesar Salad';
+
+-- 2. Lines keep the station they were routed to and are bumped one by one
+ALTER TABLE restaurant_order_items ADD COLUMN IF NOT EXISTS station VARCHAR(10) NOT NULL DEFAULT 'GRILL';
+ALTER TABLE restaurant_order_items ADD COLUMN IF NOT EXISTS status VARCHAR(10) NOT NULL DEFAULT 'PENDING'; -- PENDING, READY
+ALTER TABLE restaurant_order_items ADD COLUMN IF NOT EXISTS ready_at TIMESTAMP;
+
+UPDATE restaurant_order_items i
+SET station = COALESCE(m.station, c.station)
+FROM menu_items m
+JOIN menu_categories c ON c.id = m.category_id
+WHERE m.id = i.item_id;
+
+CREATE INDEX IF NOT EXISTS idx_restaurant_order_items_order ON restaurant_order_items(order_id);
+
+-- 3. Ticket timer: alert the kitchen once per late order
+ALTER TABLE restaurant_orders ADD COLUMN IF NOT EXISTS late_alerted_at TIMESTAMP;
+
+-- +migrate Down
+ALTER TABLE restaurant_orders DROP COLUMN IF EXISTS late_alerted_at;
+DROP INDEX IF EXISTS idx_restaurant_order_items_order;
+ALTER TABLE restaurant_order_items DROP COLUMN IF EXISTS ready_at;
+ALTER TABLE restaurant_order_items DROP COLUMN IF EXISTS status;
+ALTER TABLE restaurant_order_items DROP COLUMN IF EXISTS station;
+ALTER TABLE menu_items DROP COLUMN IF EXISTS station;
+ALTER TABLE menu_categories DROP COLUMN IF EXISTS station;
@@ -0,0 +1,22 @@
+-- +migrate Up
+-- 1. Timestamp the last status change; cancelled orders keep the kitchen's reason
+ALTER TABLE restaurant_orders ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP;
+ALTER TABLE restaurant_orders ADD COLUMN IF NOT EXISTS cancel_reason TEXT;
+
+-- 2. One row per status change (who moved it, and when)
+CREATE TABLE IF NOT EXISTS restaurant_order_events (
+    id SERIAL PRIMARY KEY,
+    order_id INT NOT NULL REFERENCES restaurant_orders(id),
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    actor_id INT NOT NULL DEFAULT 0,
+    actor_role VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_restaurant_order_events_order ON restaurant_order_events(order_id);
+
+-- +migrate Down
+DROP TABLE IF EXISTS restaurant_order_events;
+ALTER TABLE restaurant_orders DROP COLUMN IF EXISTS cancel_reason;
+ALTER TABLE restaurant_orders DROP COLUMN IF EXISTS updated_at;
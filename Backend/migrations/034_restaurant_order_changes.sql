@@ -0,0 +1,23 @@
+-- +migrate Up
+-- 1. A guest cancelling after cooking started waits for the kitchen to approve
+ALTER TABLE restaurant_orders ADD COLUMN IF NOT EXISTS cancel_requested_at TIMESTAMP;
+ALTER TABLE restaurant_orders ADD COLUMN IF NOT EXISTS cancel_request_reason TEXT;
+
+-- 2. Manager comps and voids of single lines; total_price only keeps what is still billed
+ALTER TABLE restaurant_orders ADD COLUMN IF NOT EXISTS adjusted_total DECIMAL(10, 2) NOT NULL DEFAULT 0.00;
+
+ALTER TABLE restaurant_order_items ADD COLUMN IF NOT EXISTS adjustment VARCHAR(10) CHECK (adjustment IN ('COMP', 'VOID'));
+ALTER TABLE restaurant_order_items ADD COLUMN IF NOT EXISTS adjust_reason VARCHAR(30);
+ALTER TABLE restaurant_order_items ADD COLUMN IF NOT EXISTS adjust_note TEXT;
+ALTER TABLE restaurant_order_items ADD COLUMN IF NOT EXISTS adjusted_by INT;
+ALTER TABLE restaurant_order_items ADD COLUMN IF NOT EXISTS adjusted_at TIMESTAMP;
+
+-- +migrate Down
+ALTER TABLE restaurant_order_items DROP COLUMN IF EXISTS adjusted_at;
+ALTER TABLE restaurant_order_items DROP COLUMN IF EXISTS adjusted_by;
+ALTER TABLE restaurant_order_items DROP COLUMN IF EXISTS adjust_note;
+ALTER TABLE restaurant_order_items DROP COLUMN IF EXISTS adjust_reason;
+ALTER TABLE restaurant_order_items DROP COLUMN IF EXISTS adjustment;
+ALTER TABLE restaurant_orders DROP COLUMN IF EXISTS adjusted_total;
+ALTER TABLE restaurant_orders DROP COLUMN IF EXISTS cancel_request_reason;
+ALTER TABLE restaurant_orders DROP COLUMN IF EXISTS cancel_requested_at;
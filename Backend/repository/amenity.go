@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"database/sql"
+
+	"oasis/backend/domain"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const amenityItemColumns = `id, code, name, category, per_stay_limit, price, stock_on_hand, par_level, active, created_at`
+
+const amenityRequestColumns = `
+	id, guest_id, room_number, item_id, item_name, quantity, unit_price, total_price, status, created_at, delivered_at`
+
+func (r *hkRepo) FetchAmenityItems(includeInactive bool) ([]domain.AmenityItem, error) {
+	items := []domain.AmenityItem{}
+	query := `SELECT ` + amenityItemColumns + ` FROM amenity_items WHERE $1 OR active = TRUE ORDER BY category, name`
+	err := r.db.Select(&items, query, includeInactive)
+	return items, err
+}
+
+func (r *hkRepo) FindAmenityItem(id int) (*domain.AmenityItem, error) {
+	var item domain.AmenityItem
+	err := r.db.Get(&item, `SELECT `+amenityItemColumns+` FROM amenity_items WHERE id = $1`, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+// FindAmenityItemByCode matches the catalog code or the display name, ignoring case
+func (r *hkRepo) FindAmenityItemByCode(code string) (*domain.AmenityItem, error) {
+	var item domain.AmenityItem
+	query := `SELECT ` + amenityItemColumns + ` FROM amenity_items WHERE UPPER(code) = UPPER($1) OR LOWER(name) = LOWER($1) LIMIT 1`
+	err := r.db.Get(&item, query, code)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *hkRepo) CreateAmenityItem(item *domain.AmenityItem) error {
+	query := `
+	INSERT INTO amenity_items (code, name, category, per_stay_limit, price, stock_on_hand, par_level, active, created_at)
+	VALUES (:code, :name, :category, :per_stay_limit, :price, :stock_on_hand, :par_level, :active, :created_at)
+	RETURNING id`
+	rows, err := r.db.NamedQuery(query, item)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		return rows.Scan(&item.ID)
+	}
+	return nil
+}
+
+// UpdateAmenityItem edits the catalog entry; stock only changes through the ledger
+func (r *hkRepo) UpdateAmenityItem(item *domain.AmenityItem) error {
+	query := `
+	UPDATE amenity_items
+	SET code = :code, name = :name, category = :category, per_stay_limit = :per_stay_limit,
+	    price = :price, par_level = :par_level, active = :active
+	WHERE id = :id`
+	_, err := r.db.NamedExec(query, item)
+	return err
+}
+
+// CountGuestAmenity sums what the guest already asked for of an item during the stay
+func (r *hkRepo) CountGuestAmenity(guestID, itemID int) (int, error) {
+	var total int
+	err := r.db.Get(&total, "SELECT COALESCE(SUM(quantity), 0) FROM amenity_requests WHERE guest_id = $1 AND item_id = $2", guestID, itemID)
+	return total, err
+}
+
+func (r *hkRepo) SaveAmenityRequest(req *domain.AmenityRequest) error {
+	query := `INSERT INTO amenity_requests (guest_id, room_number, item_id, item_name, quantity, unit_price, total_price, status, created_at) 
+	          VALUES (:guest_id, :room_number, :item_id, :item_name, :quantity, :unit_price, :total_price, :status, :created_at)
+	          RETURNING id`
+	rows, err := r.db.NamedQuery(query, req)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		return rows.Scan(&req.ID)
+	}
+	return nil
+}
+
+func (r *hkRepo) FetchAmenityRequests() ([]domain.AmenityRequest, error) {
+	var requests []domain.AmenityRequest
+	err := r.db.Select(&requests, "SELECT "+amenityRequestColumns+" FROM amenity_requests WHERE status = 'PENDING' ORDER BY created_at DESC")
+	return requests, err
+}
+
+func (r *hkRepo) FindAmenityRequest(id int) (*domain.AmenityRequest, error) {
+	var req domain.AmenityRequest
+	err := r.db.Get(&req, "SELECT "+amenityRequestColumns+" FROM amenity_requests WHERE id = $1", id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &req, nil
+}
+
+// FetchGuestAmenityCharges returns delivered, chargeable amenities not yet invoiced
+func (r *hkRepo) FetchGuestAmenityCharges(guestID int) ([]domain.AmenityRequest, error) {
+	requests := []domain.AmenityRequest{}
+	query := "SELECT " + amenityRequestColumns + ` FROM amenity_requests
+	WHERE guest_id = $1 AND status = 'DELIVERED' AND total_price > 0
+	ORDER BY delivered_at ASC`
+	err := r.db.Select(&requests, query, guestID)
+	return requests, err
+}
+
+// DeliverAmenityTx marks the request delivered and takes the items out of stock.
+// It returns the catalog item after the movement (nil for legacy free-text requests).
+func (r *hkRepo) DeliverAmenityTx(req *domain.AmenityRequest, staffID int) (*domain.AmenityItem, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(
+		"UPDATE amenity_requests SET status = $1, delivered_at = $2 WHERE id = $3 AND status = 'PENDING'",
+		req.Status, req.DeliveredAt, req.ID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, domain.ErrAmenityAlreadyHandled
+	}
+
+	var item *domain.AmenityItem
+	if req.ItemID != nil {
+		item, err = moveStockTx(tx, *req.ItemID, -req.Quantity, domain.StockReasonDelivery, &req.ID, staffID, "")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return item, tx.Commit()
+}
+
+// AdjustStockTx records a restock or a manual correction
+func (r *hkRepo) AdjustStockTx(itemID, change int, reason, note string, staffID int) (*domain.AmenityItem, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	item, err := moveStockTx(tx, itemID, change, reason, nil, staffID, note)
+	if err != nil {
+		return nil, err
+	}
+	return item, tx.Commit()
+}
+
+// moveStockTx changes stock_on_hand (never below zero) and writes the ledger row
+func moveStockTx(tx *sqlx.Tx, itemID, change int, reason string, requestID *int, staffID int, note string) (*domain.AmenityItem, error) {
+	var item domain.AmenityItem
+	err := tx.Get(&item, `
+		UPDATE amenity_items SET stock_on_hand = stock_on_hand + $1
+		WHERE id = $2 AND stock_on_hand + $1 >= 0
+		RETURNING `+amenityItemColumns, change, itemID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrAmenityOutOfStock
+		}
+		return nil, err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO amenity_stock_movements (item_id, change, reason, amenity_request_id, staff_id, note, balance_after)
+		VALUES ($1, $2, $3, $4, NULLIF($5, 0), NULLIF($6, ''), $7)`,
+		itemID, change, reason, requestID, staffID, note, item.StockOnHand)
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *hkRepo) FetchStockMovements(itemID int) ([]domain.StockMovement, error) {
+	movements := []domain.StockMovement{}
+	query := `
+	SELECT id, item_id, change, reason, amenity_request_id, staff_id, COALESCE(note, '') AS note, balance_after, created_at
+	FROM amenity_stock_movements
+	WHERE item_id = $1
+	ORDER BY created_at DESC`
+	err := r.db.Select(&movements, query, itemID)
+	return movements, err
+}
+
+func (r *hkRepo) FetchLowStockItems() ([]domain.AmenityItem, error) {
+	items := []domain.AmenityItem{}
+	query := `SELECT ` + amenityItemColumns + ` FROM amenity_items WHERE active = TRUE AND stock_on_hand <= par_level ORDER BY stock_on_hand ASC`
+	err := r.db.Select(&items, query)
+	return items, err
+}
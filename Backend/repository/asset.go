@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"oasis/backend/domain"
+)
+
+const assetColumns = `
+	id, name, category, room_number, COALESCE(location, '') AS location,
+	COALESCE(serial_number, '') AS serial_number, installed_at, retired_at, created_at`
+
+const scheduleColumns = `
+	s.id, s.asset_id, s.room_number, s.title, s.issue_type, s.interval_count, s.interval_unit,
+	s.lead_days, s.next_due_date, s.blocks_room, s.block_days, s.active, s.last_generated_at, s.created_at`
+
+func (r *hkRepo) CreateAsset(asset *domain.Asset) error {
+	query := `
+	INSERT INTO assets (name, category, room_number, location, serial_number, installed_at, created_at)
+	VALUES (:name, :category, :room_number, NULLIF(:location, ''), NULLIF(:serial_number, ''), :installed_at, :created_at)
+	RETURNING id`
+	rows, err := r.db.NamedQuery(query, asset)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		return rows.Scan(&asset.ID)
+	}
+	return nil
+}
+
+func (r *hkRepo) UpdateAsset(asset *domain.Asset) error {
+	query := `
+	UPDATE assets
+	SET name = :name, category = :category, room_number = :room_number, location = NULLIF(:location, ''),
+	    serial_number = NULLIF(:serial_number, ''), installed_at = :installed_at, retired_at = :retired_at
+	WHERE id = :id`
+	_, err := r.db.NamedExec(query, asset)
+	return err
+}
+
+func (r *hkRepo) FindAsset(id int) (*domain.Asset, error) {
+	var asset domain.Asset
+	err := r.db.Get(&asset, `SELECT `+assetColumns+` FROM assets WHERE id = $1`, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &asset, nil
+}
+
+func (r *hkRepo) FetchAssets() ([]domain.Asset, error) {
+	assets := []domain.Asset{}
+	err := r.db.Select(&assets, `SELECT `+assetColumns+` FROM assets WHERE retired_at IS NULL ORDER BY category, name`)
+	return assets, err
+}
+
+func (r *hkRepo) FetchAssetTickets(assetID int) ([]domain.MaintenanceTicket, error) {
+	tickets := []domain.MaintenanceTicket{}
+	query := `SELECT ` + ticketColumns + ` FROM maintenance_tickets WHERE asset_id = $1 ORDER BY created_at DESC`
+	err := r.db.Select(&tickets, query, assetID)
+	return tickets, err
+}
+
+func (r *hkRepo) CreateSchedule(schedule *domain.MaintenanceSchedule) error {
+	query := `
+	INSERT INTO maintenance_schedules (
+		asset_id, room_number, title, issue_type, interval_count, interval_unit,
+		lead_days, next_due_date, blocks_room, block_days, active, created_at
+	) VALUES (
+		:asset_id, :room_number, :title, :issue_type, :interval_count, :interval_unit,
+		:lead_days, :next_due_date, :blocks_room, :block_days, :active, :created_at
+	) RETURNING id`
+	rows, err := r.db.NamedQuery(query, schedule)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		return rows.Scan(&schedule.ID)
+	}
+	return nil
+}
+
+func (r *hkRepo) UpdateSchedule(schedule *domain.MaintenanceSchedule) error {
+	query := `
+	UPDATE maintenance_schedules
+	SET asset_id = :asset_id, room_number = :room_number, title = :title, issue_type = :issue_type,
+	    interval_count = :interval_count, interval_unit = :interval_unit, lead_days = :lead_days,
+	    next_due_date = :next_due_date, blocks_room = :blocks_room, block_days = :block_days, active = :active
+	WHERE id = :id`
+	_, err := r.db.NamedExec(query, schedule)
+	return err
+}
+
+func (r *hkRepo) FindSchedule(id int) (*domain.MaintenanceSchedule, error) {
+	var schedule domain.MaintenanceSchedule
+	err := r.db.Get(&schedule, `SELECT `+scheduleColumns+` FROM maintenance_schedules s WHERE s.id = $1`, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// FetchSchedules lists active schedules; assetID 0 means all of them
+func (r *hkRepo) FetchSchedules(assetID int) ([]domain.MaintenanceSchedule, error) {
+	schedules := []domain.MaintenanceSchedule{}
+	query := `SELECT ` + scheduleColumns + ` FROM maintenance_schedules s
+	WHERE s.active = TRUE AND ($1 = 0 OR s.asset_id = $1)
+	ORDER BY s.next_due_date ASC`
+	err := r.db.Select(&schedules, query, assetID)
+	return schedules, err
+}
+
+// FetchDueSchedules returns active schedules whose lead window has opened by today
+func (r *hkRepo) FetchDueSchedules(today time.Time) ([]domain.DueSchedule, error) {
+	var due []domain.DueSchedule
+	query := `
+	SELECT ` + scheduleColumns + `,
+		COALESCE(s.room_number, a.room_number, '') AS target_room,
+		COALESCE(a.name, '') AS asset_name,
+		COALESCE(a.location, '') AS location
+	FROM maintenance_schedules s
+	LEFT JOIN assets a ON a.id = s.asset_id
+	WHERE s.active = TRUE
+	  AND a.retired_at IS NULL
+	  AND s.next_due_date - s.lead_days <= $1
+	ORDER BY s.next_due_date ASC`
+	err := r.db.Select(&due, query, today)
+	return due, err
+}
+
+// SaveScheduledTicket stores the ticket generated for a schedule and moves the schedule
+// to its next due date in one transaction, so a cycle is never ticketed twice or skipped
+func (r *hkRepo) SaveScheduledTicket(ticket *domain.MaintenanceTicket, nextDue, generatedAt time.Time) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertTicket(tx, ticket); err != nil {
+		return err
+	}
+	_, err = tx.Exec(
+		"UPDATE maintenance_schedules SET next_due_date = $1, last_generated_at = $2 WHERE id = $3",
+		nextDue, generatedAt, *ticket.ScheduleID,
+	)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
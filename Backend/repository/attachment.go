@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"database/sql"
+
+	"oasis/backend/domain"
+	"oasis/backend/storage"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// AttachmentRepo implements the storage.Repository interface
+type AttachmentRepo interface {
+	storage.Repository
+}
+
+type attachmentRepo struct {
+	db *sqlx.DB
+}
+
+func NewAttachmentRepo(db *sqlx.DB) AttachmentRepo {
+	return &attachmentRepo{
+		db: db,
+	}
+}
+
+const attachmentColumns = `
+	id, owner_type, owner_id, file_name, content_type, size_bytes, storage_key, thumbnail_key,
+	uploaded_by, uploader_role, created_at`
+
+func (r *attachmentRepo) SaveAttachment(att *domain.Attachment) error {
+	query := `
+	INSERT INTO attachments (owner_type, owner_id, file_name, content_type, size_bytes, storage_key, thumbnail_key, uploaded_by, uploader_role, created_at)
+	VALUES (:owner_type, :owner_id, :file_name, :content_type, :size_bytes, :storage_key, :thumbnail_key, :uploaded_by, :uploader_role, :created_at)
+	RETURNING id`
+	rows, err := r.db.NamedQuery(query, att)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		return rows.Scan(&att.ID)
+	}
+	return nil
+}
+
+func (r *attachmentRepo) FindAttachment(id int) (*domain.Attachment, error) {
+	var att domain.Attachment
+	err := r.db.Get(&att, `SELECT `+attachmentColumns+` FROM attachments WHERE id = $1`, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &att, nil
+}
+
+func (r *attachmentRepo) FetchAttachments(ownerType string, ownerID int) ([]domain.Attachment, error) {
+	atts := []domain.Attachment{}
+	query := `SELECT ` + attachmentColumns + ` FROM attachments WHERE owner_type = $1 AND owner_id = $2 ORDER BY created_at ASC`
+	err := r.db.Select(&atts, query, ownerType, ownerID)
+	return atts, err
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"oasis/backend/domain"
+	"oasis/backend/group"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// GroupRepo implements the group.Repository interface
+type GroupRepo interface {
+	group.Repository
+}
+
+type groupRepo struct {
+	db *sqlx.DB
+}
+
+func NewGroupRepo(db *sqlx.DB) GroupRepo {
+	return &groupRepo{
+		db: db,
+	}
+}
+
+const groupColumns = `
+	id, name, COALESCE(contact_name, '') AS contact_name, COALESCE(contact_phone, '') AS contact_phone,
+	check_in_date, check_out_date, cutoff_date,
+	bill_room_to_master, bill_restaurant_to_master, bill_laundry_to_master,
+	status, created_at`
+
+// CreateGroup inserts the group and holds its rooms in one transaction
+func (r *groupRepo) CreateGroup(g *domain.BookingGroup, rooms []string) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+	INSERT INTO booking_groups (
+		name, contact_name, contact_phone, check_in_date, check_out_date, cutoff_date,
+		bill_room_to_master, bill_restaurant_to_master, bill_laundry_to_master, status, created_at
+	) VALUES (
+		:name, :contact_name, :contact_phone, :check_in_date, :check_out_date, :cutoff_date,
+		:bill_room_to_master, :bill_restaurant_to_master, :bill_laundry_to_master, :status, :created_at
+	) RETURNING id`
+
+	rows, err := tx.NamedQuery(query, g)
+	if err != nil {
+		return err
+	}
+	if rows.Next() {
+		if err := rows.Scan(&g.ID); err != nil {
+			rows.Close()
+			return err
+		}
+	}
+	rows.Close()
+
+	for _, roomNumber := range rooms {
+		_, err := tx.Exec(
+			"INSERT INTO group_room_blocks (group_id, room_number, status) VALUES ($1, $2, $3)",
+			g.ID, roomNumber, domain.BlockStatusHeld,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *groupRepo) FetchGroups() ([]domain.BookingGroup, error) {
+	var groups []domain.BookingGroup
+	query := `SELECT ` + groupColumns + ` FROM booking_groups ORDER BY check_in_date DESC`
+	err := r.db.Select(&groups, query)
+	return groups, err
+}
+
+func (r *groupRepo) FindGroup(id int) (*domain.BookingGroup, error) {
+	var g domain.BookingGroup
+	query := `SELECT ` + groupColumns + ` FROM booking_groups WHERE id = $1`
+	err := r.db.Get(&g, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &g, nil
+}
+
+func (r *groupRepo) FetchBlocks(groupID int) ([]domain.GroupRoomBlock, error) {
+	var blocks []domain.GroupRoomBlock
+	query := `
+	SELECT id, group_id, room_number, guest_id, status, released_at
+	FROM group_room_blocks
+	WHERE group_id = $1
+	ORDER BY room_number ASC`
+	err := r.db.Select(&blocks, query, groupID)
+	return blocks, err
+}
+
+func (r *groupRepo) ReleaseHeldBlocks(groupID int) (int, error) {
+	res, err := r.db.Exec(
+		"UPDATE group_room_blocks SET status = $1, released_at = NOW() WHERE group_id = $2 AND status = $3",
+		domain.BlockStatusReleased, groupID, domain.BlockStatusHeld,
+	)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+// ReleaseBlocksPastCutoff frees held rooms of every group whose cutoff date is before today
+func (r *groupRepo) ReleaseBlocksPastCutoff(today time.Time) (int, error) {
+	query := `
+	UPDATE group_room_blocks b
+	SET status = $1, released_at = NOW()
+	FROM booking_groups bg
+	WHERE bg.id = b.group_id
+	  AND b.status = $2
+	  AND bg.cutoff_date < $3`
+	res, err := r.db.Exec(query, domain.BlockStatusReleased, domain.BlockStatusHeld, today)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+func (r *groupRepo) FetchFolioEntries(groupID int) ([]domain.GroupFolioEntry, error) {
+	var entries []domain.GroupFolioEntry
+	query := `
+	SELECT id, group_id, guest_id, charge_type, COALESCE(description, '') AS description, amount, created_at
+	FROM group_folio_entries
+	WHERE group_id = $1
+	ORDER BY created_at ASC`
+	err := r.db.Select(&entries, query, groupID)
+	return entries, err
+}
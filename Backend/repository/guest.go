@@ -3,11 +3,13 @@ package repository
 import (
 	"database/sql"
 	"fmt"
+	"time"
 
 	"oasis/backend/domain"
 	"oasis/backend/guest"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 // GuestRepo implements the guest.GuestRepo interface
@@ -25,7 +27,9 @@ func NewGuestRepo(db *sqlx.DB) GuestRepo {
 	}
 }
 
-func (r guestRepo) Create(g domain.Guest) (*domain.Guest, error) {
+// Create checks a guest in: the guest row, the first stay segment, the
+// room occupancy and, for group members, the room block are written in one transaction.
+func (r guestRepo) Create(g domain.Guest, nightlyRate float64, actor domain.Actor) (*domain.Guest, error) {
 	query := `
 	INSERT INTO guests (
 		name, 
@@ -33,6 +37,9 @@ func (r guestRepo) Create(g domain.Guest) (*domain.Guest, error) {
 		room_number,
 		check_in_date,
 		check_out_date,
+		status,
+		group_id,
+		allergies,
 		created_at
 	) VALUES (
 		:name, 
@@ -40,24 +47,65 @@ func (r guestRepo) Create(g domain.Guest) (*domain.Guest, error) {
 		:room_number,
 		:check_in_date,
 		:check_out_date,
+		:status,
+		:group_id,
+		:allergies,
 		:created_at
 	) RETURNING id
 	`
 
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
 	// Execute named query
 	var guestID int
-	rows, err := r.db.NamedQuery(query, g)
+	rows, err := tx.NamedQuery(query, g)
 	if err != nil {
 		fmt.Println("Error creating guest:", err)
 		return nil, err
 	}
-	defer rows.Close()
-
 	if rows.Next() {
 		if err := rows.Scan(&guestID); err != nil {
+			rows.Close()
 			return nil, err
 		}
 	}
+	rows.Close()
+
+	_, err = tx.Exec(`
+		INSERT INTO stay_segments (guest_id, room_number, nightly_rate, start_date, end_date, reason)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		guestID, g.RoomNumber, nightlyRate, g.CheckInDate, g.CheckOutDate, domain.StaySegmentCheckIn)
+	if err != nil {
+		return nil, err
+	}
+
+	err = setRoomStateTx(tx, g.RoomNumber, occupyRoom, actor, "Guest checked in")
+	if err != nil {
+		return nil, err
+	}
+
+	// Group members take over the room their group is holding
+	// Note: We are touching the group module's table here, like the checkout transaction does.
+	if g.GroupID != nil {
+		res, err := tx.Exec(
+			"UPDATE group_room_blocks SET guest_id = $1, status = $2 WHERE group_id = $3 AND room_number = $4 AND status = $5",
+			guestID, domain.BlockStatusAssigned, *g.GroupID, g.RoomNumber, domain.BlockStatusHeld,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			return nil, domain.ErrRoomNotInBlock
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
 
 	g.ID = guestID
 	return &g, nil
@@ -67,7 +115,7 @@ func (r guestRepo) Create(g domain.Guest) (*domain.Guest, error) {
 func (r *guestRepo) Find(roomNumber, phoneNumber string) (*domain.Guest, error) {
 	var g domain.Guest
 	query := `
-	SELECT id, name, phone_number, room_number, check_in_date, check_out_date, created_at
+	SELECT id, name, phone_number, room_number, check_in_date, check_out_date, COALESCE(status, 'CHECKED_IN') AS status, group_id, allergies, created_at
 	FROM guests 
 	WHERE room_number = $1 AND phone_number = $2 
 	LIMIT 1
@@ -88,7 +136,7 @@ func (r *guestRepo) Find(roomNumber, phoneNumber string) (*domain.Guest, error)
 func (r *guestRepo) FindByID(id int) (*domain.Guest, error) {
 	var g domain.Guest
 	query := `
-	SELECT id, name, phone_number, room_number, check_in_date, check_out_date, created_at
+	SELECT id, name, phone_number, room_number, check_in_date, check_out_date, COALESCE(status, 'CHECKED_IN') AS status, group_id, allergies, created_at
 	FROM guests 
 	WHERE id = $1
 	LIMIT 1
@@ -109,7 +157,7 @@ func (r *guestRepo) FindByID(id int) (*domain.Guest, error) {
 func (r *guestRepo) FindByRoomNumber(roomNumber string) (*domain.Guest, error) {
 	var g domain.Guest
 	query := `
-	SELECT id, name, phone_number, room_number, check_in_date, check_out_date, created_at
+	SELECT id, name, phone_number, room_number, check_in_date, check_out_date, COALESCE(status, 'CHECKED_IN') AS status, group_id, allergies, created_at
 	FROM guests 
 	WHERE room_number = $1
 	ORDER BY created_at DESC
@@ -127,3 +175,130 @@ func (r *guestRepo) FindByRoomNumber(roomNumber string) (*domain.Guest, error) {
 	return &g, nil
 }
 
+// SetAllergies replaces the allergies on the guest profile
+func (r *guestRepo) SetAllergies(guestID int, allergies []string) error {
+	_, err := r.db.Exec("UPDATE guests SET allergies = $1 WHERE id = $2", pq.Array(allergies), guestID)
+	return err
+}
+
+func (r *guestRepo) FetchStaySegments(guestID int) ([]domain.StaySegment, error) {
+	segments := []domain.StaySegment{}
+	query := `
+	SELECT id, guest_id, room_number, nightly_rate, start_date, end_date, reason
+	FROM stay_segments
+	WHERE guest_id = $1
+	ORDER BY start_date ASC, id ASC
+	`
+	err := r.db.Select(&segments, query, guestID)
+	return segments, err
+}
+
+// FindLastInRoom returns the guest who most recently left the room by day.
+// Only when nobody had left yet does it fall back to the guest staying there on day.
+func (r *guestRepo) FindLastInRoom(roomNumber string, day time.Time) (*domain.Guest, error) {
+	var g domain.Guest
+	query := `
+	SELECT g.id, g.name, g.phone_number, g.room_number, g.check_in_date, g.check_out_date,
+	       COALESCE(g.status, 'CHECKED_IN') AS status, g.group_id, g.allergies, g.created_at
+	FROM stay_segments s
+	JOIN guests g ON g.id = s.guest_id
+	WHERE s.room_number = $1 AND s.start_date <= $2
+	ORDER BY (s.end_date <= $2) DESC, s.end_date DESC, s.id DESC
+	LIMIT 1
+	`
+	err := r.db.Get(&g, query, roomNumber, day)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &g, nil
+}
+
+// MoveRoom transfers the remaining nights, the open orders and the occupancy
+// from one room to another in a single transaction.
+func (r *guestRepo) MoveRoom(move domain.RoomMove) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// 1. Cut the segment that spans the move date, drop anything planned after it
+	_, err = tx.Exec(`
+		UPDATE stay_segments SET end_date = $2
+		WHERE guest_id = $1 AND start_date < $2 AND end_date > $2`, move.GuestID, move.MoveDate)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec("DELETE FROM stay_segments WHERE guest_id = $1 AND start_date >= $2", move.GuestID, move.MoveDate)
+	if err != nil {
+		return err
+	}
+
+	// 2. The rest of the stay happens in the new room
+	_, err = tx.Exec(`
+		INSERT INTO stay_segments (guest_id, room_number, nightly_rate, start_date, end_date, reason)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		move.GuestID, move.ToRoom, move.NightlyRate, move.MoveDate, move.CheckOutDate, domain.StaySegmentRoomMove)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("UPDATE guests SET room_number = $1 WHERE id = $2", move.ToRoom, move.GuestID)
+	if err != nil {
+		return err
+	}
+
+	// 3. Open orders are delivered to the new room (charges already follow the guest ID)
+	// Note: We are touching other module's tables here, like the checkout transaction does.
+	openOrders := []string{
+		"UPDATE restaurant_orders SET room_number = $1 WHERE guest_id = $2 AND status NOT IN ('DELIVERED', 'PAID', 'CANCELLED')",
+		"UPDATE laundry_requests SET room_number = $1 WHERE guest_id = $2 AND status NOT IN ('DELIVERED', 'PAID', 'CANCELLED')",
+		"UPDATE amenity_requests SET room_number = $1 WHERE guest_id = $2 AND status = 'PENDING'",
+	}
+	for _, q := range openOrders {
+		if _, err := tx.Exec(q, move.ToRoom, move.GuestID); err != nil {
+			return err
+		}
+	}
+
+	// 4. Old room needs a turnover clean, new room is occupied
+	reason := "Room move to " + move.ToRoom
+	if move.Reason != "" {
+		reason += ": " + move.Reason
+	}
+	if err := setRoomStateTx(tx, move.FromRoom, vacateRoom, move.Actor, reason); err != nil {
+		return err
+	}
+	if err := setRoomStateTx(tx, move.ToRoom, occupyRoom, move.Actor, "Room move from "+move.FromRoom); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ExtendStay adds the extra nights as their own segment and pushes the check-out date
+func (r *guestRepo) ExtendStay(guestID int, roomNumber string, from, to time.Time, nightlyRate float64) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		INSERT INTO stay_segments (guest_id, room_number, nightly_rate, start_date, end_date, reason)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		guestID, roomNumber, nightlyRate, from, to, domain.StaySegmentExtension)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("UPDATE guests SET check_out_date = $1 WHERE id = $2", to, guestID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -1,6 +1,9 @@
 package repository
 
 import (
+	"database/sql"
+	"fmt"
+
 	"oasis/backend/domain"
 	"oasis/backend/housekeeping"
 
@@ -19,50 +22,83 @@ func NewHousekeepingRepo(db *sqlx.DB) HousekeepingRepo {
 	return &hkRepo{db: db}
 }
 
-func (r *hkRepo) UpdateRoomStatus(roomNumber, status string) error {
-	_, err := r.db.Exec("UPDATE rooms SET housekeeping_status = $1 WHERE room_number = $2", status, roomNumber)
-	return err
-}
-
-func (r *hkRepo) FetchAllRoomStatuses() ([]domain.RoomsStatus, error) {
-	var rooms []domain.RoomsStatus
-	err := r.db.Select(&rooms, `SELECT room_number, COALESCE(housekeeping_status, 'CLEAN') as housekeeping_status FROM rooms ORDER BY room_number ASC`)
-	return rooms, err
+func (r *hkRepo) FetchRoomState(roomNumber string) (*domain.RoomState, error) {
+	var state domain.RoomState
+	err := r.db.Get(&state, "SELECT status, housekeeping_status FROM rooms WHERE room_number = $1", roomNumber)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &state, nil
 }
 
-func (r *hkRepo) SaveAmenityRequest(req *domain.AmenityRequest) error {
-	query := `INSERT INTO amenity_requests (guest_id, room_number, item_name, quantity, status, created_at) 
-	          VALUES (:guest_id, :room_number, :item_name, :quantity, :status, :created_at)`
-	_, err := r.db.NamedExec(query, req)
-	return err
-}
+// SaveRoomTransition updates the room and writes the audit row atomically.
+// The UPDATE only matches if the room is still in the "from" state, so two
+// concurrent changes cannot both succeed.
+func (r *hkRepo) SaveRoomTransition(change *domain.RoomStatusChange) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
 
-func (r *hkRepo) SaveTicket(ticket *domain.MaintenanceTicket) error {
-	query := `INSERT INTO maintenance_tickets (room_number, issue_type, description, priority, status, created_at) 
-	          VALUES (:room_number, :issue_type, :description, :priority, :status, :created_at)`
-	_, err := r.db.NamedExec(query, ticket)
-	return err
+	if err := saveRoomTransitionTx(tx, change); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
-func (r *hkRepo) FetchAmenityRequests() ([]domain.AmenityRequest, error) {
-	var requests []domain.AmenityRequest
-	err := r.db.Select(&requests, "SELECT * FROM amenity_requests WHERE status = 'PENDING' ORDER BY created_at DESC")
-	return requests, err
-}
+// saveRoomTransitionTx is SaveRoomTransition inside an existing transaction
+func saveRoomTransitionTx(tx *sqlx.Tx, change *domain.RoomStatusChange) error {
+	res, err := tx.Exec(`
+		UPDATE rooms SET status = $1, housekeeping_status = $2, `+dndColumns(6)+`
+		WHERE room_number = $3 AND status = $4 AND housekeeping_status = $5`,
+		change.To.Status, change.To.Housekeeping, change.RoomNumber, change.From.Status, change.From.Housekeeping,
+		change.To.Housekeeping == domain.HousekeepingDND)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("%w: room %s changed concurrently", domain.ErrIllegalTransition, change.RoomNumber)
+	}
 
-func (r *hkRepo) FetchMaintenanceTickets() ([]domain.MaintenanceTicket, error) {
-	var tickets []domain.MaintenanceTicket
-	err := r.db.Select(&tickets, "SELECT id, room_number, issue_type, description, priority, status, created_at FROM maintenance_tickets WHERE status != 'RESOLVED' ORDER BY priority DESC, created_at DESC")
-	return tickets, err
+	return tx.QueryRow(`
+		INSERT INTO room_status_history (
+			room_number, from_status, from_housekeeping_status, to_status, to_housekeeping_status,
+			changed_by_id, changed_by_role, reason, changed_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`,
+		change.RoomNumber, change.From.Status, change.From.Housekeeping, change.To.Status, change.To.Housekeeping,
+		change.ChangedBy.ID, change.ChangedBy.Role, change.Reason, change.ChangedAt,
+	).Scan(&change.ID)
 }
 
-func (r *hkRepo) UpdateAmenityStatus(id int, status string) error {
-	_, err := r.db.Exec("UPDATE amenity_requests SET status = $1 WHERE id = $2", status, id)
-	return err
+func (r *hkRepo) FetchRoomHistory(roomNumber string) ([]domain.RoomStatusChange, error) {
+	history := []domain.RoomStatusChange{}
+	query := `
+	SELECT id, room_number,
+		from_status AS "from.status", from_housekeeping_status AS "from.housekeeping_status",
+		to_status AS "to.status", to_housekeeping_status AS "to.housekeeping_status",
+		changed_by_id AS "changed_by.id", changed_by_role AS "changed_by.role",
+		COALESCE(reason, '') AS reason, changed_at
+	FROM room_status_history
+	WHERE room_number = $1
+	ORDER BY changed_at DESC`
+	err := r.db.Select(&history, query, roomNumber)
+	return history, err
 }
 
-func (r *hkRepo) UpdateTicketStatus(id int, status string) error {
-	_, err := r.db.Exec("UPDATE maintenance_tickets SET status = $1 WHERE id = $2", status, id)
-	return err
+func (r *hkRepo) FetchAllRoomStatuses() ([]domain.RoomsStatus, error) {
+	var rooms []domain.RoomsStatus
+	query := `
+	SELECT r.room_number, r.status, r.housekeeping_status, r.dnd_since,
+		COALESCE(TO_CHAR(w.window_start, 'HH24:MI'), '') AS window_start,
+		COALESCE(TO_CHAR(w.window_end, 'HH24:MI'), '') AS window_end
+	FROM rooms r
+	LEFT JOIN (` + activeWindows + `) w ON w.room_number = r.room_number
+	WHERE r.retired_at IS NULL
+	ORDER BY r.room_number ASC`
+	err := r.db.Select(&rooms, query)
+	return rooms, err
 }
-
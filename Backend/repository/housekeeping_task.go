@@ -0,0 +1,241 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"oasis/backend/domain"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const taskColumns = `
+	id, room_number, task_type, task_date, credits, status, assigned_to,
+	created_at, started_at, finished_at,
+	COALESCE(TO_CHAR(window_start, 'HH24:MI'), '') AS window_start,
+	COALESCE(TO_CHAR(window_end, 'HH24:MI'), '') AS window_end`
+
+// activeWindows are the cleaning windows of guests who are still in their room
+const activeWindows = `
+	SELECT w.room_number, w.guest_id, w.window_start, w.window_end, w.created_at
+	FROM cleaning_windows w
+	JOIN guests g ON g.id = w.guest_id AND g.room_number = w.room_number AND g.status = 'CHECKED_IN'`
+
+// FetchTaskCandidates lists every sellable room with its state and cleaning credits
+func (r *hkRepo) FetchTaskCandidates() ([]domain.TaskCandidate, error) {
+	var rooms []domain.TaskCandidate
+	query := `
+	SELECT r.room_number, r.status, r.housekeeping_status,
+		COALESCE(t.cleaning_credits, 1.0) AS cleaning_credits,
+		EXISTS (
+			SELECT 1 FROM guests g
+			WHERE g.room_number = r.room_number
+			  AND g.status = 'CHECKED_IN'
+			  AND g.check_in_date < CURRENT_DATE
+		) AS stayover,
+		COALESCE(TO_CHAR(w.window_start, 'HH24:MI'), '') AS window_start,
+		COALESCE(TO_CHAR(w.window_end, 'HH24:MI'), '') AS window_end
+	FROM rooms r
+	LEFT JOIN room_types t ON t.id = r.room_type_id
+	LEFT JOIN (` + activeWindows + `) w ON w.room_number = r.room_number
+	WHERE r.retired_at IS NULL
+	  AND NOT (r.out_of_order_from IS NOT NULL AND r.out_of_order_from <= CURRENT_DATE AND r.out_of_order_to >= CURRENT_DATE)
+	ORDER BY r.room_number ASC`
+	err := r.db.Select(&rooms, query)
+	return rooms, err
+}
+
+// CreateTask inserts the task unless the room already has a live task of that type for the day
+func (r *hkRepo) CreateTask(task *domain.HousekeepingTask) (bool, error) {
+	query := `
+	INSERT INTO housekeeping_tasks (room_number, task_type, task_date, credits, status, created_at, window_start, window_end)
+	VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, '')::TIME, NULLIF($8, '')::TIME)
+	ON CONFLICT (room_number, task_type, task_date) WHERE status != 'CANCELLED' DO NOTHING
+	RETURNING id`
+	err := r.db.QueryRow(query,
+		task.RoomNumber, task.Type, task.TaskDate, task.Credits, task.Status, task.CreatedAt,
+		task.WindowStart, task.WindowEnd,
+	).Scan(&task.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *hkRepo) UpdateTask(task *domain.HousekeepingTask) error {
+	return updateTask(r.db, task)
+}
+
+// updateTask stores a task's progress with either the pool or a transaction
+func updateTask(db sqlx.Execer, task *domain.HousekeepingTask) error {
+	_, err := db.Exec(`
+		UPDATE housekeeping_tasks
+		SET status = $1, assigned_to = $2, started_at = $3, finished_at = $4
+		WHERE id = $5`,
+		task.Status, task.AssignedTo, task.StartedAt, task.FinishedAt, task.ID)
+	return err
+}
+
+func (r *hkRepo) FindTask(id int) (*domain.HousekeepingTask, error) {
+	var task domain.HousekeepingTask
+	err := r.db.Get(&task, `SELECT `+taskColumns+` FROM housekeeping_tasks WHERE id = $1`, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &task, nil
+}
+
+// FetchOpenTasks returns the day's tasks nobody has started yet
+func (r *hkRepo) FetchOpenTasks(date time.Time) ([]domain.HousekeepingTask, error) {
+	var tasks []domain.HousekeepingTask
+	query := `SELECT ` + taskColumns + ` FROM housekeeping_tasks
+	WHERE task_date = $1 AND status IN ('PENDING', 'ASSIGNED')
+	ORDER BY id ASC`
+	err := r.db.Select(&tasks, query, date)
+	return tasks, err
+}
+
+// FetchTasks returns the day's board; staffID 0 means every attendant
+func (r *hkRepo) FetchTasks(date time.Time, staffID int) ([]domain.HousekeepingTask, error) {
+	tasks := []domain.HousekeepingTask{}
+	query := `SELECT ` + taskColumns + ` FROM housekeeping_tasks
+	WHERE task_date = $1 AND status != 'CANCELLED' AND ($2 = 0 OR assigned_to = $2)
+	ORDER BY status = 'DONE', window_start ASC NULLS FIRST, room_number ASC`
+	err := r.db.Select(&tasks, query, date, staffID)
+	return tasks, err
+}
+
+// FetchOnShiftStaff returns on-shift staff of a role with the credits already on their board
+func (r *hkRepo) FetchOnShiftStaff(date time.Time, role string) ([]domain.AttendantLoad, error) {
+	var staff []domain.AttendantLoad
+	query := `
+	SELECT s.id, s.name, COALESCE(SUM(t.credits), 0) AS credits
+	FROM staff s
+	LEFT JOIN housekeeping_tasks t
+		ON t.assigned_to = s.id AND t.task_date = $1 AND t.status != 'CANCELLED'
+	WHERE s.on_shift = TRUE AND s.role = $2
+	GROUP BY s.id
+	ORDER BY s.id ASC`
+	err := r.db.Select(&staff, query, date, role)
+	return staff, err
+}
+
+func (r *hkRepo) SetOnShift(staffID int, onShift bool) error {
+	res, err := r.db.Exec(`
+		UPDATE staff
+		SET on_shift = $1, shift_started_at = CASE WHEN $1 THEN NOW() ELSE NULL END
+		WHERE id = $2`, onShift, staffID)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domain.ErrStaffNotFound
+	}
+	return nil
+}
+
+// UnassignOpenTasks puts the attendant's not-yet-started tasks back in the pool
+func (r *hkRepo) UnassignOpenTasks(staffID int) error {
+	_, err := r.db.Exec(`
+		UPDATE housekeeping_tasks SET status = 'PENDING', assigned_to = NULL
+		WHERE assigned_to = $1 AND status = 'ASSIGNED'`, staffID)
+	return err
+}
+
+func (r *hkRepo) FetchWorkload(date time.Time) ([]domain.AttendantWorkload, error) {
+	board := []domain.AttendantWorkload{}
+	query := `
+	SELECT s.id AS staff_id, s.name, s.role, s.on_shift,
+		COALESCE(SUM(t.credits), 0) AS assigned_credits,
+		COUNT(t.id) AS total_tasks,
+		COUNT(t.id) FILTER (WHERE t.status = 'DONE') AS done_tasks,
+		COALESCE(AVG(EXTRACT(EPOCH FROM (t.finished_at - t.started_at)) / 60)
+			FILTER (WHERE t.status = 'DONE' AND t.task_type IN ('CHECKOUT_CLEAN', 'STAYOVER_CLEAN')), 0) AS avg_clean_minutes
+	FROM staff s
+	LEFT JOIN housekeeping_tasks t
+		ON t.assigned_to = s.id AND t.task_date = $1 AND t.status != 'CANCELLED'
+	WHERE s.role IN ('HOUSEKEEPING', 'SUPERVISOR')
+	GROUP BY s.id
+	ORDER BY s.on_shift DESC, assigned_credits DESC`
+	err := r.db.Select(&board, query, date)
+	return board, err
+}
+
+// FindCheckedInGuestID returns the guest currently staying in the room (0 if none)
+func (r *hkRepo) FindCheckedInGuestID(roomNumber string) (int, error) {
+	var guestID int
+	err := r.db.Get(&guestID, "SELECT id FROM guests WHERE room_number = $1 AND status = 'CHECKED_IN' ORDER BY id DESC LIMIT 1", roomNumber)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return guestID, err
+}
+
+func (r *hkRepo) FindCleaningWindow(roomNumber string) (*domain.CleaningWindow, error) {
+	var window domain.CleaningWindow
+	query := `
+	SELECT room_number, guest_id, TO_CHAR(window_start, 'HH24:MI') AS window_start,
+		TO_CHAR(window_end, 'HH24:MI') AS window_end, created_at
+	FROM (` + activeWindows + `) w
+	WHERE room_number = $1`
+	err := r.db.Get(&window, query, roomNumber)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &window, nil
+}
+
+// SaveCleaningWindow replaces the room's window (one per room, owned by the current guest)
+func (r *hkRepo) SaveCleaningWindow(window *domain.CleaningWindow) error {
+	_, err := r.db.Exec(`
+		INSERT INTO cleaning_windows (room_number, guest_id, window_start, window_end, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (room_number) DO UPDATE
+		SET guest_id = EXCLUDED.guest_id, window_start = EXCLUDED.window_start,
+		    window_end = EXCLUDED.window_end, created_at = EXCLUDED.created_at`,
+		window.RoomNumber, window.GuestID, window.Start, window.End, window.CreatedAt)
+	return err
+}
+
+func (r *hkRepo) DeleteCleaningWindow(roomNumber string) error {
+	_, err := r.db.Exec("DELETE FROM cleaning_windows WHERE room_number = $1", roomNumber)
+	return err
+}
+
+// UpdateOpenTaskWindows moves today's not-yet-started stayover clean to the new window
+func (r *hkRepo) UpdateOpenTaskWindows(roomNumber string, date time.Time, start, end string) error {
+	_, err := r.db.Exec(`
+		UPDATE housekeeping_tasks
+		SET window_start = NULLIF($1, '')::TIME, window_end = NULLIF($2, '')::TIME
+		WHERE room_number = $3 AND task_date = $4 AND task_type = $5 AND status IN ('PENDING', 'ASSIGNED')`,
+		start, end, roomNumber, date, domain.TaskStayoverClean)
+	return err
+}
+
+// FetchDNDRooms lists rooms on Do Not Disturb, longest first
+func (r *hkRepo) FetchDNDRooms() ([]domain.DNDRoom, error) {
+	rooms := []domain.DNDRoom{}
+	query := `
+	SELECT r.room_number, COALESCE(g.name, '') AS guest_name, r.dnd_since, r.dnd_alerted_at
+	FROM rooms r
+	LEFT JOIN guests g ON g.room_number = r.room_number AND g.status = 'CHECKED_IN'
+	WHERE r.housekeeping_status = 'DND' AND r.dnd_since IS NOT NULL
+	ORDER BY r.dnd_since ASC`
+	err := r.db.Select(&rooms, query)
+	return rooms, err
+}
+
+func (r *hkRepo) MarkDNDAlerted(roomNumbers []string, at time.Time) error {
+	_, err := r.db.Exec("UPDATE rooms SET dnd_alerted_at = $1 WHERE room_number = ANY($2)", at, pq.Array(roomNumbers))
+	return err
+}
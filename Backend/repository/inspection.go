@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"oasis/backend/domain"
+)
+
+const checklistColumns = `id, room_type_id, area, description, sort_order`
+
+func (r *hkRepo) FetchChecklist(roomTypeID int) ([]domain.ChecklistItem, error) {
+	items := []domain.ChecklistItem{}
+	query := `SELECT ` + checklistColumns + ` FROM inspection_checklist_items
+	WHERE room_type_id = $1 AND active = TRUE
+	ORDER BY sort_order ASC, id ASC`
+	err := r.db.Select(&items, query, roomTypeID)
+	return items, err
+}
+
+// FetchRoomChecklist returns the checklist of the room's type
+func (r *hkRepo) FetchRoomChecklist(roomNumber string) ([]domain.ChecklistItem, error) {
+	items := []domain.ChecklistItem{}
+	query := `SELECT c.id, c.room_type_id, c.area, c.description, c.sort_order
+	FROM inspection_checklist_items c
+	JOIN rooms r ON r.room_type_id = c.room_type_id
+	WHERE r.room_number = $1 AND c.active = TRUE
+	ORDER BY c.sort_order ASC, c.id ASC`
+	err := r.db.Select(&items, query, roomNumber)
+	return items, err
+}
+
+// ReplaceChecklist retires the current items and inserts the new list.
+// Old items are kept inactive so past inspection results still resolve.
+func (r *hkRepo) ReplaceChecklist(roomTypeID int, items []domain.ChecklistItem) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("UPDATE inspection_checklist_items SET active = FALSE WHERE room_type_id = $1", roomTypeID); err != nil {
+		return err
+	}
+
+	for i := range items {
+		err := tx.QueryRow(`
+			INSERT INTO inspection_checklist_items (room_type_id, area, description, sort_order)
+			VALUES ($1, $2, $3, $4) RETURNING id`,
+			roomTypeID, items[i].Area, items[i].Description, items[i].SortOrder,
+		).Scan(&items[i].ID)
+		if err != nil {
+			return err
+		}
+		items[i].RoomTypeID = roomTypeID
+	}
+
+	return tx.Commit()
+}
+
+// FindLastCleaningTask returns the most recently finished clean of the room
+func (r *hkRepo) FindLastCleaningTask(roomNumber string) (*domain.HousekeepingTask, error) {
+	var task domain.HousekeepingTask
+	query := `SELECT ` + taskColumns + ` FROM housekeeping_tasks
+	WHERE room_number = $1 AND status = 'DONE' AND task_type IN ('CHECKOUT_CLEAN', 'STAYOVER_CLEAN')
+	ORDER BY finished_at DESC
+	LIMIT 1`
+	err := r.db.Get(&task, query, roomNumber)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &task, nil
+}
+
+// FindOpenTask returns the room's unfinished task of the given type for the day
+func (r *hkRepo) FindOpenTask(roomNumber string, taskType domain.TaskType, date time.Time) (*domain.HousekeepingTask, error) {
+	var task domain.HousekeepingTask
+	query := `SELECT ` + taskColumns + ` FROM housekeeping_tasks
+	WHERE room_number = $1 AND task_type = $2 AND task_date = $3
+	  AND status IN ('PENDING', 'ASSIGNED', 'IN_PROGRESS')`
+	err := r.db.Get(&task, query, roomNumber, taskType, date)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &task, nil
+}
+
+// SaveInspection stores the inspection together with the room move it causes and the
+// tasks it settles, so a failure leaves neither the room nor the board half updated
+func (r *hkRepo) SaveInspection(inspection *domain.RoomInspection, change *domain.RoomStatusChange, tasks []*domain.HousekeepingTask) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Move the room first: the optimistic update protects against a concurrent change
+	if err := saveRoomTransitionTx(tx, change); err != nil {
+		return err
+	}
+
+	err = tx.QueryRow(`
+		INSERT INTO room_inspections (room_number, cleaning_task_id, attendant_id, inspector_id, passed, notes, inspected_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`,
+		inspection.RoomNumber, inspection.CleaningTaskID, inspection.AttendantID, inspection.InspectorID,
+		inspection.Passed, inspection.Notes, inspection.InspectedAt,
+	).Scan(&inspection.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, res := range inspection.Results {
+		_, err := tx.Exec(`
+			INSERT INTO room_inspection_results (inspection_id, checklist_item_id, passed, note)
+			VALUES ($1, $2, $3, $4)`,
+			inspection.ID, res.ChecklistItemID, res.Passed, res.Note)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, task := range tasks {
+		if err := updateTask(tx, task); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *hkRepo) FetchInspections(roomNumber string) ([]domain.RoomInspection, error) {
+	inspections := []domain.RoomInspection{}
+	query := `
+	SELECT id, room_number, cleaning_task_id, attendant_id, inspector_id, passed,
+		COALESCE(notes, '') AS notes, inspected_at
+	FROM room_inspections
+	WHERE room_number = $1
+	ORDER BY inspected_at DESC`
+	if err := r.db.Select(&inspections, query, roomNumber); err != nil {
+		return nil, err
+	}
+
+	for i := range inspections {
+		err := r.db.Select(&inspections[i].Results, `
+			SELECT res.checklist_item_id, c.area, c.description, res.passed, COALESCE(res.note, '') AS note
+			FROM room_inspection_results res
+			JOIN inspection_checklist_items c ON c.id = res.checklist_item_id
+			WHERE res.inspection_id = $1
+			ORDER BY c.sort_order ASC`, inspections[i].ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return inspections, nil
+}
+
+// FetchPassRates groups inspections in [from, to) by the attendant who cleaned the room
+func (r *hkRepo) FetchPassRates(from, to time.Time) ([]domain.AttendantPassRate, error) {
+	report := []domain.AttendantPassRate{}
+	query := `
+	SELECT s.id AS staff_id, s.name,
+		COUNT(i.id) AS inspections,
+		COUNT(i.id) FILTER (WHERE i.passed) AS passed,
+		ROUND(100.0 * COUNT(i.id) FILTER (WHERE i.passed) / COUNT(i.id), 1) AS pass_rate
+	FROM room_inspections i
+	JOIN staff s ON s.id = i.attendant_id
+	WHERE i.inspected_at >= $1 AND i.inspected_at < $2
+	GROUP BY s.id
+	ORDER BY pass_rate DESC, inspections DESC`
+	err := r.db.Select(&report, query, from, to)
+	return report, err
+}
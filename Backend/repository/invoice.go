@@ -28,20 +28,44 @@ func (r *invoiceRepo) CreateInvoiceTx(inv *domain.Invoice) error {
 	defer tx.Rollback() 
 
 	// 2. Insert Invoice Record
-	queryInv := `INSERT INTO invoices (guest_id, room_number, room_charge, laundry_charge, restaurant_charge, total_amount) 
-	             VALUES (:guest_id, :room_number, :room_charge, :laundry_charge, :restaurant_charge, :total_amount)`
+	queryInv := `INSERT INTO invoices (guest_id, room_number, room_charge, laundry_charge, restaurant_charge, amenity_charge, total_amount, group_id, group_charge) 
+	             VALUES (:guest_id, :room_number, :room_charge, :laundry_charge, :restaurant_charge, :amenity_charge, :total_amount, :group_id, :group_charge)`
 	_, err = tx.NamedExec(queryInv, inv)
 	if err != nil { return err }
 
+	// 2b. Post master-billed charges to the group folio
+	for _, entry := range inv.GroupEntries {
+		_, err = tx.Exec(
+			"INSERT INTO group_folio_entries (group_id, guest_id, charge_type, description, amount) VALUES ($1, $2, $3, $4, $5)",
+			entry.GroupID, entry.GuestID, entry.ChargeType, entry.Description, entry.Amount,
+		)
+		if err != nil { return err }
+	}
+
 	// 3. Mark Laundry as PAID
 	// Note: We are touching other module's tables here. 
 	// In strict Microservices, this is forbidden (you'd use API calls). 
 	// In Modular Monolith with shared DB, this is acceptable for transactions.
-	_, err = tx.Exec("UPDATE laundry_requests SET status = 'PAID' WHERE guest_id = $1 AND status != 'PAID'", inv.GuestID)
+	// The lifecycle history gets a PAID step too, so every request ends with an event.
+	// Only DELIVERED requests are settled (the lifecycle allows nothing else); checkout refuses while bags are out.
+	_, err = tx.Exec(`INSERT INTO laundry_request_events (request_id, from_status, to_status, actor_id, actor_role)
+	                  SELECT id, status, 'PAID', 0, 'SYSTEM' FROM laundry_requests
+	                  WHERE guest_id = $1 AND status = 'DELIVERED' AND awaiting_confirmation = false`, inv.GuestID)
+	if err != nil { return err }
+	_, err = tx.Exec("UPDATE laundry_requests SET status = 'PAID', updated_at = NOW() WHERE guest_id = $1 AND status = 'DELIVERED' AND awaiting_confirmation = false", inv.GuestID)
 	if err != nil { return err }
 
 	// 4. Mark Restaurant as PAID
-	_, err = tx.Exec("UPDATE restaurant_orders SET status = 'PAID' WHERE guest_id = $1 AND status != 'PAID'", inv.GuestID)
+	// Like laundry: only DELIVERED orders are settled, with their PAID event; checkout refuses while orders are open.
+	_, err = tx.Exec(`INSERT INTO restaurant_order_events (order_id, from_status, to_status, reason, actor_id, actor_role)
+	                  SELECT id, status, 'PAID', 'Guest checked out', 0, 'SYSTEM' FROM restaurant_orders
+	                  WHERE guest_id = $1 AND status = 'DELIVERED'`, inv.GuestID)
+	if err != nil { return err }
+	_, err = tx.Exec("UPDATE restaurant_orders SET status = 'PAID', updated_at = NOW() WHERE guest_id = $1 AND status = 'DELIVERED'", inv.GuestID)
+	if err != nil { return err }
+
+	// 4b. Mark delivered chargeable amenities as PAID
+	_, err = tx.Exec("UPDATE amenity_requests SET status = 'PAID' WHERE guest_id = $1 AND status = 'DELIVERED' AND total_price > 0", inv.GuestID)
 	if err != nil { return err }
 
 	// 5. Checkout Guest
@@ -49,10 +73,10 @@ func (r *invoiceRepo) CreateInvoiceTx(inv *domain.Invoice) error {
 	if err != nil { return err }
 
 	// 6. Mark Room as DIRTY (Trigger Housekeeping!)
-	// Assuming inv has RoomNumber populated
-	// We might need to fetch RoomNumber from guest table inside TX if not present
-	// For MVP, assuming we passed it in.
-	
+	// The room state machine allows checkout from every occupied state (OCCUPIED+* -> VACANT+DIRTY)
+	err = setRoomStateTx(tx, inv.RoomNumber, vacateRoom, domain.SystemActor, "Guest checked out")
+	if err != nil { return err }
+
 	// 7. COMMIT (Save everything permanently)
 	return tx.Commit()
 }
@@ -3,6 +3,7 @@ package repository
 import (
 	"database/sql"
 	"fmt"
+	"time"
 
 	"oasis/backend/domain"
 	"oasis/backend/laundry"
@@ -24,9 +25,16 @@ func NewLaundryRepo(db *sqlx.DB) LaundryRepo {
 	}
 }
 
+const laundryItemColumns = `id, name, price, is_dry_clean, category, turnaround_hours, is_available, is_deleted`
+
+// FetchMenu is the guest-facing menu: available items only
 func (r *laundryRepo) FetchMenu() ([]domain.MenuItem, error) {
 	var items []domain.MenuItem
-	query := `SELECT id, name, price, is_dry_clean FROM laundry_items ORDER BY name ASC`
+	query := `
+	SELECT ` + laundryItemColumns + `
+	FROM laundry_items
+	WHERE is_deleted = false AND is_available = true
+	ORDER BY category ASC, name ASC`
 
 	// Removed Context
 	err := r.db.Select(&items, query)
@@ -36,38 +44,146 @@ func (r *laundryRepo) FetchMenu() ([]domain.MenuItem, error) {
 	return items, nil
 }
 
+// FetchAllItems is the staff view, including items switched off for now
+func (r *laundryRepo) FetchAllItems() ([]domain.MenuItem, error) {
+	items := []domain.MenuItem{}
+	query := `SELECT ` + laundryItemColumns + ` FROM laundry_items WHERE is_deleted = false ORDER BY category ASC, name ASC`
+	err := r.db.Select(&items, query)
+	return items, err
+}
+
+func (r *laundryRepo) FindItem(id int) (*domain.MenuItem, error) {
+	var item domain.MenuItem
+	query := `SELECT ` + laundryItemColumns + ` FROM laundry_items WHERE id = $1 AND is_deleted = false`
+	err := r.db.Get(&item, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *laundryRepo) CreateItem(item *domain.MenuItem) error {
+	query := `
+	INSERT INTO laundry_items (name, price, is_dry_clean, category, turnaround_hours, is_available)
+	VALUES (:name, :price, :is_dry_clean, :category, :turnaround_hours, :is_available)
+	RETURNING id`
+
+	rows, err := r.db.NamedQuery(query, item)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		return rows.Scan(&item.ID)
+	}
+	return nil
+}
+
+// UpdateItem reprices or recategorizes an item; requests keep their snapshot prices
+func (r *laundryRepo) UpdateItem(item *domain.MenuItem) error {
+	query := `
+	UPDATE laundry_items
+	SET name = :name, price = :price, is_dry_clean = :is_dry_clean, category = :category,
+	    turnaround_hours = :turnaround_hours, is_available = :is_available
+	WHERE id = :id AND is_deleted = false`
+	res, err := r.db.NamedExec(query, item)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domain.ErrLaundryItemNotFound
+	}
+	return nil
+}
+
+// DeleteItem hides the item; old request lines still reference it
+func (r *laundryRepo) DeleteItem(id int) error {
+	res, err := r.db.Exec("UPDATE laundry_items SET is_deleted = true WHERE id = $1 AND is_deleted = false", id)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domain.ErrLaundryItemNotFound
+	}
+	return nil
+}
+
 func (r *laundryRepo) SaveRequest(req *domain.ServiceRequest) error {
 	query := `
 	INSERT INTO laundry_requests (
-		guest_id, room_number, notes, status, total_price, created_at
+		guest_id, room_number, notes, status, total_price, created_at,
+		service_level, surcharge_pct, pickup_from, pickup_to, promised_at
 	) VALUES (
-		:guest_id, :room_number, :notes, :status, :total_price, :created_at
+		:guest_id, :room_number, :notes, :status, :total_price, :created_at,
+		:service_level, :surcharge_pct, :pickup_from, :pickup_to, :promised_at
 	) RETURNING id
 	`
 
-	// Removed Context
-	rows, err := r.db.NamedQuery(query, req)
+	tx, err := r.db.Beginx()
 	if err != nil {
-		return fmt.Errorf("failed to insert laundry request: %w", err)
+		return err
 	}
-	defer rows.Close()
+	defer tx.Rollback()
 
+	rows, err := tx.NamedQuery(query, req)
+	if err != nil {
+		return fmt.Errorf("failed to insert laundry request: %w", err)
+	}
 	if rows.Next() {
-		var id int
-		if err := rows.Scan(&id); err != nil {
+		if err := rows.Scan(&req.ID); err != nil {
+			rows.Close()
 			return err
 		}
-		req.ID = id
 	}
-	return nil
+	rows.Close()
+
+	// The guest's declaration of what is in the bag
+	for i := range req.Declared {
+		req.Declared[i].RequestID = req.ID
+		_, err := tx.Exec(`INSERT INTO laundry_declared_items (request_id, item_id, quantity) VALUES ($1, $2, $3)`,
+			req.ID, req.Declared[i].ItemID, req.Declared[i].Quantity)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *laundryRepo) FetchDeclaredItems(reqID int) ([]domain.DeclaredItem, error) {
+	var items []domain.DeclaredItem
+	query := `
+	SELECT d.request_id, d.item_id, i.name, d.quantity
+	FROM laundry_declared_items d
+	JOIN laundry_items i ON i.id = d.item_id
+	WHERE d.request_id = $1
+	ORDER BY d.id ASC`
+	err := r.db.Select(&items, query, reqID)
+	return items, err
+}
+
+func (r *laundryRepo) FetchRequestItems(reqID int) ([]domain.RequestItem, error) {
+	var items []domain.RequestItem
+	query := `
+	SELECT ri.id, ri.request_id, ri.item_id, i.name, ri.quantity, ri.snap_price, COALESCE(ri.notes, '') AS notes
+	FROM laundry_request_items ri
+	JOIN laundry_items i ON i.id = ri.item_id
+	WHERE ri.request_id = $1
+	ORDER BY ri.id ASC`
+	err := r.db.Select(&items, query, reqID)
+	return items, err
 }
 
 func (r *laundryRepo) FetchRequestsByGuest(guestID int) ([]domain.ServiceRequest, error) {
 	var requests []domain.ServiceRequest
 	query := `
-	SELECT id, guest_id, room_number, notes, status, total_price, created_at 
-	FROM laundry_requests 
-	WHERE guest_id = $1 
+	SELECT ` + laundryRequestColumns + `
+	FROM laundry_requests
+	WHERE guest_id = $1
 	ORDER BY created_at DESC
 	`
 
@@ -82,34 +198,140 @@ func (r *laundryRepo) FetchRequestsByGuest(guestID int) ([]domain.ServiceRequest
 	return requests, nil
 }
 
-// SaveItemsAndUpdateTotal does two things
-func (r *laundryRepo) SaveItemsAndUpdateTotal(reqID int, items []domain.RequestItem, total float64) error {
-	// 1. Insert the items one by one
-	queryItems := `INSERT INTO laundry_request_items (request_id, item_id, quantity, snap_price) VALUES ($1, $2, $3, $4)`
+// laundryRequestColumns lists the columns read into domain.ServiceRequest
+// The tag code must match domain.LaundryTagCode
+const laundryRequestColumns = `id, 'LND-' || LPAD(id::text, GREATEST(6, LENGTH(id::text)), '0') AS tag_code, guest_id, room_number, COALESCE(notes, '') AS notes, status, total_price, created_at, updated_at,
+	service_level, surcharge_pct, surcharge_amount, pickup_from, pickup_to, promised_at,
+	awaiting_confirmation, count_confirmed_at, COALESCE(count_dispute, '') AS count_dispute`
+
+func (r *laundryRepo) FindRequest(reqID int) (*domain.ServiceRequest, error) {
+	var req domain.ServiceRequest
+	query := `SELECT ` + laundryRequestColumns + ` FROM laundry_requests WHERE id = $1`
+	err := r.db.Get(&req, query, reqID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &req, nil
+}
+
+// SaveItemsAndUpdateTotal records the staff count in one transaction, replacing any earlier count.
+// Total, surcharge, promised time and the confirmation flag come from req.
+// It fails with ErrLaundryStatusChanged if the request left the expected status meanwhile.
+func (r *laundryRepo) SaveItemsAndUpdateTotal(req *domain.ServiceRequest, items []domain.RequestItem, expected domain.LaundryStatus) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// 1. Update the parent request bill (this also locks the row)
+	res, err := tx.Exec(`
+	UPDATE laundry_requests
+	SET total_price = $1, surcharge_amount = $2, promised_at = $3, awaiting_confirmation = $4,
+	    count_confirmed_at = NULL, count_dispute = NULL, updated_at = NOW()
+	WHERE id = $5 AND status = $6`,
+		req.TotalPrice, req.SurchargeAmount, req.PromisedAt, req.AwaitingConfirmation, req.ID, expected)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domain.ErrLaundryStatusChanged
+	}
+
+	// 2. A recount replaces the previous lines
+	if _, err := tx.Exec(`DELETE FROM laundry_request_items WHERE request_id = $1`, req.ID); err != nil {
+		return err
+	}
+
+	// 3. Insert the items one by one
+	queryItems := `INSERT INTO laundry_request_items (request_id, item_id, quantity, snap_price, notes) VALUES ($1, $2, $3, $4, NULLIF($5, ''))`
 	for _, item := range items {
-		_, err := r.db.Exec(queryItems, item.RequestID, item.ItemID, item.Quantity, item.SnapPrice)
+		_, err := tx.Exec(queryItems, item.RequestID, item.ItemID, item.Quantity, item.SnapPrice, item.Notes)
 		if err != nil {
 			return err
 		}
 	}
 
-	// 2. Update the parent request bill
-	queryUpdate := `UPDATE laundry_requests SET total_price = $1, status = 'WASHING' WHERE id = $2`
-	_, err := r.db.Exec(queryUpdate, total, reqID)
-	return err
+	return tx.Commit()
 }
 
-func (r *laundryRepo) UpdateStatus(reqID int, status string) error {
-	query := `UPDATE laundry_requests SET status = $1 WHERE id = $2`
-	_, err := r.db.Exec(query, status, reqID)
-	return err
+// UpdateStatus moves the request only if it is still in `from`, and logs the change
+func (r *laundryRepo) UpdateStatus(reqID int, from, to domain.LaundryStatus, actor domain.Actor) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`UPDATE laundry_requests SET status = $1, updated_at = NOW() WHERE id = $2 AND status = $3`, to, reqID, from)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domain.ErrLaundryStatusChanged
+	}
+
+	_, err = tx.Exec(`
+	INSERT INTO laundry_request_events (request_id, from_status, to_status, actor_id, actor_role)
+	VALUES ($1, $2, $3, $4, $5)`, reqID, from, to, actor.ID, actor.Role)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ResolveCount records the guest's answer to a flagged count.
+// Accepting clears the flag so the request can be billed; a dispute keeps it flagged for staff to recount.
+func (r *laundryRepo) ResolveCount(reqID int, accepted bool, dispute string) error {
+	query := `
+	UPDATE laundry_requests
+	SET awaiting_confirmation = NOT $2,
+	    count_confirmed_at = CASE WHEN $2 THEN NOW() ELSE NULL END,
+	    count_dispute = NULLIF($3, ''),
+	    updated_at = NOW()
+	WHERE id = $1 AND awaiting_confirmation = true`
+	res, err := r.db.Exec(query, reqID, accepted, dispute)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domain.ErrNoCountToConfirm
+	}
+	return nil
+}
+
+func (r *laundryRepo) FetchRequestEvents(reqID int) ([]domain.LaundryEvent, error) {
+	var events []domain.LaundryEvent
+	query := `
+	SELECT id, request_id, from_status, to_status, actor_id, actor_role, created_at
+	FROM laundry_request_events
+	WHERE request_id = $1
+	ORDER BY created_at ASC, id ASC`
+	err := r.db.Select(&events, query, reqID)
+	return events, err
 }
 
 func (r *laundryRepo) GetAllRequests() ([]domain.ServiceRequest, error) {
 	// Staff needs to see EVERYONE'S laundry
 	var requests []domain.ServiceRequest
-	query := `SELECT * FROM laundry_requests ORDER BY created_at DESC`
+	query := `SELECT ` + laundryRequestColumns + ` FROM laundry_requests ORDER BY created_at DESC`
 	err := r.db.Select(&requests, query)
 	return requests, err
 }
 
+// FetchOverdueRequests lists open requests whose promised return time has passed, most late first
+func (r *laundryRepo) FetchOverdueRequests(now time.Time) ([]domain.ServiceRequest, error) {
+	requests := []domain.ServiceRequest{}
+	query := `
+	SELECT ` + laundryRequestColumns + `
+	FROM laundry_requests
+	WHERE promised_at < $1
+	  AND status IN ('PENDING', 'COLLECTED', 'WASHING', 'READY')
+	ORDER BY promised_at ASC`
+	err := r.db.Select(&requests, query, now)
+	return requests, err
+}
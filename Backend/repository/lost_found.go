@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"oasis/backend/domain"
+	"oasis/backend/lostfound"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// LostFoundRepo implements the lostfound.Repository interface
+type LostFoundRepo interface {
+	lostfound.Repository
+}
+
+type lostFoundRepo struct {
+	db *sqlx.DB
+}
+
+func NewLostFoundRepo(db *sqlx.DB) LostFoundRepo {
+	return &lostFoundRepo{
+		db: db,
+	}
+}
+
+// lostItemSelect joins the matched guest so staff can call them
+const lostItemSelect = `
+	SELECT
+		l.id, l.room_number, l.found_on, l.found_by, l.description, l.category, l.storage_location,
+		l.guest_id, COALESCE(g.name, '') AS guest_name, COALESCE(g.phone_number, '') AS guest_phone,
+		l.status, l.retain_until,
+		COALESCE(l.returned_to, '') AS returned_to, l.returned_at,
+		COALESCE(l.disposal_method, '') AS disposal_method, l.disposed_at,
+		l.handled_by, COALESCE(l.notes, '') AS notes, l.reminder_sent_at, l.created_at
+	FROM lost_items l
+	LEFT JOIN guests g ON g.id = l.guest_id`
+
+func (r *lostFoundRepo) SaveLostItem(item *domain.LostItem) error {
+	query := `
+	INSERT INTO lost_items (room_number, found_on, found_by, description, category, storage_location, guest_id, status, retain_until, notes, created_at)
+	VALUES (:room_number, :found_on, :found_by, :description, :category, :storage_location, :guest_id, :status, :retain_until, NULLIF(:notes, ''), :created_at)
+	RETURNING id`
+	rows, err := r.db.NamedQuery(query, item)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		return rows.Scan(&item.ID)
+	}
+	return nil
+}
+
+func (r *lostFoundRepo) FindLostItem(id int) (*domain.LostItem, error) {
+	var item domain.LostItem
+	err := r.db.Get(&item, lostItemSelect+` WHERE l.id = $1`, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+// FetchLostItems lists the log, newest first. An empty status returns everything.
+func (r *lostFoundRepo) FetchLostItems(status domain.LostItemStatus) ([]domain.LostItem, error) {
+	items := []domain.LostItem{}
+	query := lostItemSelect + ` WHERE ($1 = '' OR l.status = $1) ORDER BY l.found_on DESC, l.id DESC`
+	err := r.db.Select(&items, query, status)
+	return items, err
+}
+
+// FetchItemsPastRetention lists stored items whose retention period has ended
+func (r *lostFoundRepo) FetchItemsPastRetention(today time.Time) ([]domain.LostItem, error) {
+	items := []domain.LostItem{}
+	query := lostItemSelect + ` WHERE l.status = 'STORED' AND l.retain_until <= $1 ORDER BY l.retain_until ASC`
+	err := r.db.Select(&items, query, today)
+	return items, err
+}
+
+// CloseLostItem stores a return or a disposal
+func (r *lostFoundRepo) CloseLostItem(item *domain.LostItem) error {
+	query := `
+	UPDATE lost_items
+	SET status = :status, returned_to = NULLIF(:returned_to, ''), returned_at = :returned_at,
+	    disposal_method = NULLIF(:disposal_method, ''), disposed_at = :disposed_at,
+	    handled_by = :handled_by, notes = NULLIF(:notes, '')
+	WHERE id = :id AND status = 'STORED'`
+	res, err := r.db.NamedExec(query, item)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domain.ErrLostItemClosed
+	}
+	return nil
+}
+
+func (r *lostFoundRepo) MarkReminderSent(ids []int, at time.Time) error {
+	_, err := r.db.Exec("UPDATE lost_items SET reminder_sent_at = $1 WHERE id = ANY($2)", at, pq.Array(ids))
+	return err
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"oasis/backend/domain"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const ticketColumns = `
+	id, COALESCE(room_number, '') AS room_number, issue_type, COALESCE(description, '') AS description, priority, status, created_at,
+	assigned_to, assigned_at, started_at, COALESCE(on_hold_reason, '') AS on_hold_reason,
+	sla_due_at, escalated_at, COALESCE(resolution_notes, '') AS resolution_notes, resolved_by, resolved_at,
+	schedule_id, asset_id, blocks_room`
+
+func (r *hkRepo) SaveTicket(ticket *domain.MaintenanceTicket) error {
+	return insertTicket(r.db, ticket)
+}
+
+// insertTicket stores a new ticket and fills in its ID
+func insertTicket(db sqlx.Ext, ticket *domain.MaintenanceTicket) error {
+	query := `INSERT INTO maintenance_tickets (room_number, issue_type, description, priority, status, created_at, sla_due_at, assigned_to, assigned_at, schedule_id, asset_id, blocks_room) 
+	          VALUES (NULLIF(:room_number, ''), :issue_type, :description, :priority, :status, :created_at, :sla_due_at, :assigned_to, :assigned_at, :schedule_id, :asset_id, :blocks_room)
+	          RETURNING id`
+	rows, err := sqlx.NamedQuery(db, query, ticket)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		return rows.Scan(&ticket.ID)
+	}
+	return nil
+}
+
+// FetchMaintenanceTickets lists unresolved tickets, most urgent deadline first.
+// engineerID 0 means every engineer.
+func (r *hkRepo) FetchMaintenanceTickets(engineerID int) ([]domain.MaintenanceTicket, error) {
+	tickets := []domain.MaintenanceTicket{}
+	query := `SELECT ` + ticketColumns + ` FROM maintenance_tickets
+	WHERE status != 'RESOLVED' AND ($1 = 0 OR assigned_to = $1)
+	ORDER BY sla_due_at ASC`
+	err := r.db.Select(&tickets, query, engineerID)
+	return tickets, err
+}
+
+func (r *hkRepo) FindTicket(id int) (*domain.MaintenanceTicket, error) {
+	var ticket domain.MaintenanceTicket
+	err := r.db.Get(&ticket, `SELECT `+ticketColumns+` FROM maintenance_tickets WHERE id = $1`, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &ticket, nil
+}
+
+func (r *hkRepo) FetchTicketParts(ticketID int) ([]domain.TicketPart, error) {
+	parts := []domain.TicketPart{}
+	err := r.db.Select(&parts, "SELECT id, ticket_id, part_name, quantity, unit_cost FROM maintenance_ticket_parts WHERE ticket_id = $1 ORDER BY id ASC", ticketID)
+	return parts, err
+}
+
+func (r *hkRepo) UpdateTicket(ticket *domain.MaintenanceTicket) error {
+	query := `
+	UPDATE maintenance_tickets
+	SET status = :status, assigned_to = :assigned_to, assigned_at = :assigned_at,
+	    started_at = :started_at, on_hold_reason = :on_hold_reason, blocks_room = :blocks_room
+	WHERE id = :id`
+	_, err := r.db.NamedExec(query, ticket)
+	return err
+}
+
+// ResolveTicketTx closes the ticket and records the parts used in one transaction
+func (r *hkRepo) ResolveTicketTx(ticket *domain.MaintenanceTicket) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+	UPDATE maintenance_tickets
+	SET status = :status, on_hold_reason = :on_hold_reason, resolution_notes = :resolution_notes,
+	    resolved_by = :resolved_by, resolved_at = :resolved_at
+	WHERE id = :id`
+	if _, err := tx.NamedExec(query, ticket); err != nil {
+		return err
+	}
+
+	for i := range ticket.PartsUsed {
+		part := &ticket.PartsUsed[i]
+		part.TicketID = ticket.ID
+		err := tx.QueryRow(
+			"INSERT INTO maintenance_ticket_parts (ticket_id, part_name, quantity, unit_cost) VALUES ($1, $2, $3, $4) RETURNING id",
+			part.TicketID, part.PartName, part.Quantity, part.UnitCost,
+		).Scan(&part.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// FetchOverdueTickets returns unresolved tickets past their SLA that were not escalated yet
+func (r *hkRepo) FetchOverdueTickets(now time.Time) ([]domain.MaintenanceTicket, error) {
+	var tickets []domain.MaintenanceTicket
+	query := `SELECT ` + ticketColumns + ` FROM maintenance_tickets
+	WHERE status != 'RESOLVED' AND escalated_at IS NULL AND sla_due_at < $1
+	ORDER BY sla_due_at ASC`
+	err := r.db.Select(&tickets, query, now)
+	return tickets, err
+}
+
+func (r *hkRepo) MarkTicketEscalated(id int, at time.Time) error {
+	_, err := r.db.Exec("UPDATE maintenance_tickets SET escalated_at = $1 WHERE id = $2", at, id)
+	return err
+}
+
+// FetchStaffRole returns the staff member's role, or "" if they do not exist
+func (r *hkRepo) FetchStaffRole(staffID int) (string, error) {
+	var role string
+	err := r.db.Get(&role, "SELECT role FROM staff WHERE id = $1", staffID)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return role, err
+}
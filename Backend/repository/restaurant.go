@@ -4,8 +4,10 @@ import (
 	"database/sql"
 	"oasis/backend/domain" // Replace with your path
 	"oasis/backend/restaurant"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type RestaurantRepo interface {
@@ -32,48 +34,174 @@ func (r *restaurantRepo) FetchAllItems() ([]domain.RestaurantMenuItem, error) {
 	return items, err
 }
 
-func (r *restaurantRepo) SaveOrder(order *domain.Order, items []domain.RestaurantOrderItemInput) error {
-	// 1. Insert Order
-	queryOrder := `
-		INSERT INTO restaurant_orders (guest_id, room_number, notes, status, total_price, created_at)
-		VALUES (:guest_id, :room_number, :notes, :status, :total_price, :created_at)
-		RETURNING id`
+// FetchItems loads the given menu items, keyed by ID (removed items included)
+func (r *restaurantRepo) FetchItems(ids []int) (map[int]domain.RestaurantMenuItem, error) {
+	var items []domain.RestaurantMenuItem
+	if err := r.db.Select(&items, "SELECT * FROM menu_items WHERE id = ANY($1)", pq.Array(ids)); err != nil {
+		return nil, err
+	}
+	result := make(map[int]domain.RestaurantMenuItem, len(items))
+	for _, item := range items {
+		result[item.ID] = item
+	}
+	return result, nil
+}
 
-	// Create map for price lookup
-	allMenu, _ := r.FetchAllItems()
-	priceMap := make(map[int]float64)
-	for _, m := range allMenu {
-		priceMap[m.ID] = m.Price
+// SaveOrder prices and stores the order in one transaction.
+// The menu rows are locked while the order is written, so an item removed meanwhile cannot be billed.
+func (r *restaurantRepo) SaveOrder(order *domain.Order, items []domain.RestaurantOrderItemInput) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
 	}
+	defer tx.Rollback()
 
-	var total float64
+	// 1. Price the cart against the menu
+	menu, lines, total, err := priceOrder(tx, items, order.CreatedAt)
+	if err != nil {
+		return err
+	}
+	order.TotalPrice = total
 
-	// Prepare items and calc total
-	// Note: This logic assumes we have a transaction, simplifying for MVP
-	rows, err := r.db.NamedQuery(queryOrder, order)
+	// 2. Insert Order
+	queryOrder := `
+		INSERT INTO restaurant_orders (guest_id, room_number, notes, status, total_price, created_at, allergy_alert, allergens)
+		VALUES (:guest_id, :room_number, :notes, :status, :total_price, :created_at, :allergy_alert, :allergens)
+		RETURNING id`
+	rows, err := tx.NamedQuery(queryOrder, order)
 	if err != nil {
 		return err
 	}
 	if rows.Next() {
-		rows.Scan(&order.ID)
+		if err := rows.Scan(&order.ID); err != nil {
+			rows.Close()
+			return err
+		}
 	}
 	rows.Close()
 
-	// 2. Insert Items & Calc Total
-	queryItem := `INSERT INTO restaurant_order_items (order_id, item_id, quantity, snap_price) VALUES ($1, $2, $3, $4)`
+	// 3. Insert Items at the snapshot price, with the chosen modifiers
+	if err := insertOrderLines(tx, order.ID, items, menu, lines); err != nil {
+		return err
+	}
 
-	for _, item := range items {
-		price := priceMap[item.ItemID]
-		lineTotal := price * float64(item.Quantity)
-		total += lineTotal
+	return tx.Commit()
+}
 
-		r.db.Exec(queryItem, order.ID, item.ItemID, item.Quantity, price)
+// ReplaceOrderItems swaps the lines of an order the kitchen has not started, re-priced like a new order.
+// The order row is locked so the kitchen cannot pick it up half-way through the edit.
+func (r *restaurantRepo) ReplaceOrderItems(order *domain.Order, items []domain.RestaurantOrderItemInput, at time.Time) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var status domain.OrderStatus
+	if err := tx.Get(&status, "SELECT status FROM restaurant_orders WHERE id = $1 FOR UPDATE", order.ID); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.ErrOrderNotFound
+		}
+		return err
+	}
+	if status != domain.OrderStatusReceived {
+		return domain.ErrOrderNotEditable
 	}
 
-	// 3. Update Order Total
-	r.db.Exec("UPDATE restaurant_orders SET total_price = $1 WHERE id = $2", total, order.ID)
+	menu, lines, total, err := priceOrder(tx, items, at)
+	if err != nil {
+		return err
+	}
 	order.TotalPrice = total
+	order.AdjustedTotal = 0
+
+	_, err = tx.Exec(`
+		DELETE FROM restaurant_order_item_modifiers
+		WHERE order_item_id IN (SELECT id FROM restaurant_order_items WHERE order_id = $1)`, order.ID)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM restaurant_order_items WHERE order_id = $1", order.ID); err != nil {
+		return err
+	}
+	if err := insertOrderLines(tx, order.ID, items, menu, lines); err != nil {
+		return err
+	}
+
+	queryOrder := `
+		UPDATE restaurant_orders
+		SET notes = $2, total_price = $3, adjusted_total = 0, allergy_alert = $4, allergens = $5, updated_at = $6
+		WHERE id = $1`
+	_, err = tx.Exec(queryOrder, order.ID, order.Notes, total, order.AllergyAlert, order.Allergens, at)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
 
+// priceOrder loads every referenced item, including removed ones to explain rejections, and prices the cart at `at`
+func priceOrder(tx *sqlx.Tx, items []domain.RestaurantOrderItemInput, at time.Time) (map[int]domain.RestaurantMenuItem, []domain.PricedOrderLine, float64, error) {
+	ids := make([]int, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ItemID)
+	}
+	var menuRows []domain.RestaurantMenuItem
+	queryMenu := `
+		SELECT m.id, m.category_id, m.name, m.price, COALESCE(m.is_available, true) AS is_available,
+		       COALESCE(m.is_deleted, false) AS is_deleted, m.sold_out_until,
+		       COALESCE(m.station, c.station) AS station
+		FROM menu_items m
+		JOIN menu_categories c ON c.id = m.category_id
+		WHERE m.id = ANY($1)
+		FOR SHARE OF m`
+	if err := tx.Select(&menuRows, queryMenu, pq.Array(ids)); err != nil {
+		return nil, nil, 0, err
+	}
+	groups, err := fetchModifierGroups(tx, ids)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	periods, err := fetchServicePeriods(tx)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	schedule := domain.NewMenuSchedule(periods)
+	menu := make(map[int]domain.RestaurantMenuItem, len(menuRows))
+	for _, m := range menuRows {
+		m.ModifierGroups = groups[m.ID]
+		schedule.Apply(&m, at)
+		menu[m.ID] = m
+	}
+
+	lines, total, err := domain.PriceOrderLines(items, menu, at)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	return menu, lines, total, nil
+}
+
+// insertOrderLines stores the priced lines of an order, with the chosen modifiers
+func insertOrderLines(tx *sqlx.Tx, orderID int, items []domain.RestaurantOrderItemInput, menu map[int]domain.RestaurantMenuItem, lines []domain.PricedOrderLine) error {
+	queryItem := `
+		INSERT INTO restaurant_order_items (order_id, item_id, quantity, snap_price, modifiers_price, station)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`
+	queryModifier := `
+		INSERT INTO restaurant_order_item_modifiers (order_item_id, option_id, group_name, option_name, price_delta)
+		VALUES ($1, $2, $3, $4, $5)`
+	for i, item := range items {
+		var lineID int
+		err := tx.Get(&lineID, queryItem, orderID, item.ItemID, item.Quantity, lines[i].UnitPrice, lines[i].ModifiersPrice, menu[item.ItemID].Station)
+		if err != nil {
+			return err
+		}
+		for _, mod := range lines[i].Modifiers {
+			if _, err := tx.Exec(queryModifier, lineID, mod.OptionID, mod.GroupName, mod.OptionName, mod.PriceDelta); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
@@ -86,16 +214,192 @@ func (r *restaurantRepo) FetchOrdersByGuest(guestID int) ([]domain.Order, error)
 	return orders, err
 }
 
-func (r *restaurantRepo) UpdateStatus(orderID int, status string) error {
-	_, err := r.db.Exec("UPDATE restaurant_orders SET status = $1 WHERE id = $2", status, orderID)
-	return err
+func (r *restaurantRepo) FindOrder(orderID int) (*domain.Order, error) {
+	var order domain.Order
+	err := r.db.Get(&order, "SELECT * FROM restaurant_orders WHERE id = $1", orderID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &order, nil
+}
+
+// UpdateStatus moves the order only if it is still in `from`, and logs the change.
+// A cancellation keeps its reason on the order.
+func (r *restaurantRepo) UpdateStatus(orderID int, from, to domain.OrderStatus, reason string, actor domain.Actor) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+	UPDATE restaurant_orders
+	SET status = $1, updated_at = NOW(),
+	    cancel_reason = CASE WHEN $1 = 'CANCELLED' THEN NULLIF($4, '') ELSE cancel_reason END,
+	    cancel_requested_at = NULL, cancel_request_reason = NULL
+	WHERE id = $2 AND status = $3`
+	res, err := tx.Exec(query, to, orderID, from, reason)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domain.ErrOrderStatusChanged
+	}
+
+	_, err = tx.Exec(`
+	INSERT INTO restaurant_order_events (order_id, from_status, to_status, reason, actor_id, actor_role)
+	VALUES ($1, $2, $3, $4, $5, $6)`, orderID, from, to, reason, actor.ID, actor.Role)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *restaurantRepo) FetchOrderEvents(orderID int) ([]domain.OrderEvent, error) {
+	events := []domain.OrderEvent{}
+	query := `
+	SELECT id, order_id, from_status, to_status, reason, actor_id, actor_role, created_at
+	FROM restaurant_order_events
+	WHERE order_id = $1
+	ORDER BY created_at ASC, id ASC`
+	err := r.db.Select(&events, query, orderID)
+	return events, err
+}
+
+// RequestCancel records the guest's wish to cancel an order the kitchen is already making
+func (r *restaurantRepo) RequestCancel(orderID int, reason string, at time.Time) error {
+	query := `
+	UPDATE restaurant_orders SET cancel_requested_at = $2, cancel_request_reason = $3, updated_at = $2
+	WHERE id = $1 AND status = 'PREPARING'`
+	res, err := r.db.Exec(query, orderID, at, reason)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domain.ErrOrderStatusChanged
+	}
+	return nil
+}
+
+// ClearCancelRequest drops a cancellation request the kitchen turned down
+func (r *restaurantRepo) ClearCancelRequest(orderID int) error {
+	query := `
+	UPDATE restaurant_orders SET cancel_requested_at = NULL, cancel_request_reason = NULL, updated_at = NOW()
+	WHERE id = $1 AND cancel_requested_at IS NOT NULL`
+	res, err := r.db.Exec(query, orderID)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return domain.ErrNoCancelRequest
+	}
+	return nil
+}
+
+// SetLineAdjustment comps or voids one line (nil reinstates it) and re-totals the order.
+// The order row is locked so the invoice cannot settle it in between.
+func (r *restaurantRepo) SetLineAdjustment(orderID, lineID int, adj *domain.LineAdjustment, actor domain.Actor) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var status domain.OrderStatus
+	if err := tx.Get(&status, "SELECT status FROM restaurant_orders WHERE id = $1 FOR UPDATE", orderID); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.ErrOrderNotFound
+		}
+		return err
+	}
+	if status.Closed() {
+		return domain.ErrOrderClosed
+	}
+
+	var current *string
+	err = tx.Get(&current, "SELECT adjustment FROM restaurant_order_items WHERE id = $1 AND order_id = $2", lineID, orderID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.ErrOrderItemNotFound
+		}
+		return err
+	}
+
+	if adj != nil {
+		if current != nil {
+			return domain.ErrLineAlreadyAdjusted
+		}
+		_, err = tx.Exec(`
+		UPDATE restaurant_order_items
+		SET adjustment = $1, adjust_reason = $2, adjust_note = NULLIF($3, ''), adjusted_by = $4, adjusted_at = NOW()
+		WHERE id = $5`, adj.Type, adj.ReasonCode, adj.Note, actor.ID, lineID)
+	} else {
+		if current == nil {
+			return domain.ErrLineNotAdjusted
+		}
+		_, err = tx.Exec(`
+		UPDATE restaurant_order_items
+		SET adjustment = NULL, adjust_reason = NULL, adjust_note = NULL, adjusted_by = NULL, adjusted_at = NULL
+		WHERE id = $1`, lineID)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Only billed lines count towards what the invoice charges
+	_, err = tx.Exec(`
+	UPDATE restaurant_orders o
+	SET total_price = COALESCE((SELECT SUM((i.snap_price + i.modifiers_price) * i.quantity)
+	                            FROM restaurant_order_items i WHERE i.order_id = o.id AND i.adjustment IS NULL), 0),
+	    adjusted_total = COALESCE((SELECT SUM((i.snap_price + i.modifiers_price) * i.quantity)
+	                               FROM restaurant_order_items i WHERE i.order_id = o.id AND i.adjustment IS NOT NULL), 0),
+	    updated_at = NOW()
+	WHERE o.id = $1`, orderID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// CountOrdersAhead counts the kitchen orders placed before this one that are still being made
+func (r *restaurantRepo) CountOrdersAhead(orderID int) (int, error) {
+	var n int
+	query := `
+	SELECT COUNT(*) FROM restaurant_orders
+	WHERE status IN ('RECEIVED', 'PREPARING')
+	  AND created_at < (SELECT created_at FROM restaurant_orders WHERE id = $1)`
+	err := r.db.Get(&n, query, orderID)
+	return n, err
+}
+
+func (r *restaurantRepo) FindItem(id int) (*domain.RestaurantMenuItem, error) {
+	var item domain.RestaurantMenuItem
+	query := `
+		SELECT id, category_id, name, COALESCE(description, '') AS description, price,
+		       COALESCE(is_available, true) AS is_available, COALESCE(image_url, '') AS image_url,
+		       COALESCE(is_deleted, false) AS is_deleted, allergens, dietary, sold_out_until, station
+		FROM menu_items
+		WHERE id = $1 AND COALESCE(is_deleted, false) = false`
+	err := r.db.Get(&item, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &item, nil
 }
 
 // Add New Item
 func (r *restaurantRepo) CreateItem(item *domain.RestaurantMenuItem) error {
 	query := `
-		INSERT INTO menu_items (category_id, name, description, price, image_url, is_available)
-		VALUES (:category_id, :name, :description, :price, :image_url, :is_available)
+		INSERT INTO menu_items (category_id, name, description, price, image_url, is_available, allergens, dietary, station)
+		VALUES (:category_id, :name, :description, :price, :image_url, :is_available, :allergens, :dietary, :station)
 		RETURNING id`
 
 	rows, err := r.db.NamedQuery(query, item)
@@ -113,7 +417,8 @@ func (r *restaurantRepo) UpdateItem(item *domain.RestaurantMenuItem) error {
 	query := `
 		UPDATE menu_items 
 		SET category_id=:category_id, name=:name, description=:description, 
-		    price=:price, image_url=:image_url, is_available=:is_available
+		    price=:price, image_url=:image_url, is_available=:is_available,
+		    allergens=:allergens, dietary=:dietary, station=:station
 		WHERE id=:id`
 	_, err := r.db.NamedExec(query, item)
 	return err
@@ -127,36 +432,268 @@ func (r *restaurantRepo) DeleteItem(id int) error {
 
 // Fetch All Active Orders (For Kitchen Display)
 func (r *restaurantRepo) FetchActiveOrders() ([]domain.OrderWithItems, error) {
-	var orders []domain.Order
-	// Get everything NOT delivered
-	query := `SELECT * FROM restaurant_orders WHERE status != 'DELIVERED' ORDER BY created_at ASC`
-	err := r.db.Select(&orders, query)
-	if err != nil {
+	// Get everything still on its way to the room
+	return r.fetchKitchenOrders(`WHERE status IN ('RECEIVED', 'PREPARING', 'READY', 'OUT_FOR_DELIVERY') ORDER BY created_at ASC`)
+}
+
+// FindKitchenOrder loads one order with its lines, as the kitchen display shows it
+func (r *restaurantRepo) FindKitchenOrder(orderID int) (*domain.OrderWithItems, error) {
+	orders, err := r.fetchKitchenOrders(`WHERE id = $1`, orderID)
+	if err != nil || len(orders) == 0 {
 		return nil, err
 	}
+	return &orders[0], nil
+}
 
+// fetchKitchenOrders loads the matching orders, then all their lines and modifiers in one query each
+func (r *restaurantRepo) fetchKitchenOrders(where string, args ...interface{}) ([]domain.OrderWithItems, error) {
+	var orders []domain.Order
+	if err := r.db.Select(&orders, `SELECT * FROM restaurant_orders `+where, args...); err != nil {
+		return nil, err
+	}
 	result := []domain.OrderWithItems{}
+	if len(orders) == 0 {
+		return result, nil
+	}
 
+	orderIDs := make([]int, 0, len(orders))
 	for _, o := range orders {
-		// Fetch Items for this order
-		var items []domain.OrderItemDetail
-		qItems := `
-			SELECT m.name, i.quantity, i.snap_price 
-			FROM restaurant_order_items i
-			JOIN menu_items m ON i.item_id = m.id
-			WHERE i.order_id = $1`
-
-		err = r.db.Select(&items, qItems, o.ID)
-		if err != nil {
-			return nil, err
-		}
+		orderIDs = append(orderIDs, o.ID)
+	}
+	var items []domain.OrderItemDetail
+	qItems := `
+		SELECT i.id, i.order_id, m.name, i.quantity, i.snap_price, i.modifiers_price, m.allergens,
+		       i.station, i.status, i.ready_at, i.adjustment, i.adjust_reason, i.adjust_note
+		FROM restaurant_order_items i
+		JOIN menu_items m ON i.item_id = m.id
+		WHERE i.order_id = ANY($1)
+		ORDER BY i.id ASC`
+	if err := r.db.Select(&items, qItems, pq.Array(orderIDs)); err != nil {
+		return nil, err
+	}
+	if err := r.attachLineModifiers(items); err != nil {
+		return nil, err
+	}
 
+	itemsByOrder := make(map[int][]domain.OrderItemDetail)
+	for _, item := range items {
+		itemsByOrder[item.OrderID] = append(itemsByOrder[item.OrderID], item)
+	}
+	for _, o := range orders {
 		result = append(result, domain.OrderWithItems{
 			Order: o,
-			Items: items,
+			Items: itemsByOrder[o.ID],
 		})
 	}
+	return result, nil
+}
+
+// SetItemStatus bumps (READY) or recalls (PENDING) one line of an order
+func (r *restaurantRepo) SetItemStatus(orderID, lineID int, status string, at time.Time) (bool, error) {
+	var readyAt *time.Time
+	if status == domain.OrderItemReady {
+		readyAt = &at
+	}
+	res, err := r.db.Exec(
+		"UPDATE restaurant_order_items SET status = $1, ready_at = $2 WHERE id = $3 AND order_id = $4",
+		status, readyAt, lineID, orderID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// FetchLateOrders returns kitchen orders placed before the cut-off that have not been flagged yet
+func (r *restaurantRepo) FetchLateOrders(placedBefore time.Time) ([]domain.Order, error) {
+	orders := []domain.Order{}
+	query := `
+		SELECT * FROM restaurant_orders
+		WHERE status IN ('RECEIVED', 'PREPARING') AND created_at <= $1 AND late_alerted_at IS NULL
+		ORDER BY created_at ASC`
+	err := r.db.Select(&orders, query, placedBefore)
+	return orders, err
+}
 
+func (r *restaurantRepo) MarkLateAlerted(orderID int, at time.Time) error {
+	_, err := r.db.Exec("UPDATE restaurant_orders SET late_alerted_at = $1 WHERE id = $2", at, orderID)
+	return err
+}
+
+func (r *restaurantRepo) SetCategoryStation(categoryID int, station string) (bool, error) {
+	res, err := r.db.Exec("UPDATE menu_categories SET station = $1 WHERE id = $2", station, categoryID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// fetchModifierGroups loads the groups (with options) of the given menu items, keyed by item ID
+func fetchModifierGroups(q sqlx.Queryer, itemIDs []int) (map[int][]domain.ModifierGroup, error) {
+	var groups []domain.ModifierGroup
+	queryGroups := `
+		SELECT id, menu_item_id, name, min_select, max_select, position
+		FROM modifier_groups
+		WHERE menu_item_id = ANY($1)
+		ORDER BY position ASC, id ASC`
+	if err := sqlx.Select(q, &groups, queryGroups, pq.Array(itemIDs)); err != nil {
+		return nil, err
+	}
+	if len(groups) == 0 {
+		return map[int][]domain.ModifierGroup{}, nil
+	}
+
+	groupIDs := make([]int, 0, len(groups))
+	for _, g := range groups {
+		groupIDs = append(groupIDs, g.ID)
+	}
+	var options []domain.ModifierOption
+	queryOptions := `
+		SELECT id, group_id, name, price_delta, is_available, position
+		FROM modifier_options
+		WHERE group_id = ANY($1)
+		ORDER BY position ASC, id ASC`
+	if err := sqlx.Select(q, &options, queryOptions, pq.Array(groupIDs)); err != nil {
+		return nil, err
+	}
+	optionsByGroup := make(map[int][]domain.ModifierOption)
+	for _, o := range options {
+		optionsByGroup[o.GroupID] = append(optionsByGroup[o.GroupID], o)
+	}
+
+	result := make(map[int][]domain.ModifierGroup)
+	for _, g := range groups {
+		g.Options = optionsByGroup[g.ID]
+		g.Required = g.MinSelect > 0
+		result[g.MenuItemID] = append(result[g.MenuItemID], g)
+	}
 	return result, nil
 }
 
+func (r *restaurantRepo) FetchModifierGroups(itemIDs []int) (map[int][]domain.ModifierGroup, error) {
+	return fetchModifierGroups(r.db, itemIDs)
+}
+
+// ReplaceModifierGroups swaps the item's groups for new ones. Past order lines keep their copied names and prices.
+func (r *restaurantRepo) ReplaceModifierGroups(itemID int, groups []domain.ModifierGroup) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM modifier_groups WHERE menu_item_id = $1", itemID); err != nil {
+		return err
+	}
+
+	for gi := range groups {
+		g := &groups[gi]
+		g.MenuItemID = itemID
+		err := tx.Get(&g.ID, `
+			INSERT INTO modifier_groups (menu_item_id, name, min_select, max_select, position)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id`, itemID, g.Name, g.MinSelect, g.MaxSelect, gi)
+		if err != nil {
+			return err
+		}
+		for oi := range g.Options {
+			o := &g.Options[oi]
+			o.GroupID = g.ID
+			err := tx.Get(&o.ID, `
+				INSERT INTO modifier_options (group_id, name, price_delta, is_available, position)
+				VALUES ($1, $2, $3, $4, $5)
+				RETURNING id`, g.ID, o.Name, o.PriceDelta, o.IsAvailable, oi)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+// servicePeriodColumns reads the TIME columns as "HH:MM"
+const servicePeriodColumns = `
+	id, category_id, menu_item_id, name,
+	TO_CHAR(starts_at, 'HH24:MI') AS starts_at, TO_CHAR(ends_at, 'HH24:MI') AS ends_at, days`
+
+// fetchServicePeriods loads every service period; the table holds a handful of rows
+func fetchServicePeriods(q sqlx.Queryer) ([]domain.ServicePeriod, error) {
+	periods := []domain.ServicePeriod{}
+	query := `SELECT ` + servicePeriodColumns + ` FROM service_periods ORDER BY starts_at ASC, id ASC`
+	err := sqlx.Select(q, &periods, query)
+	return periods, err
+}
+
+func (r *restaurantRepo) FetchServicePeriods() ([]domain.ServicePeriod, error) {
+	return fetchServicePeriods(r.db)
+}
+
+// ReplaceServicePeriods swaps the periods of a category (categoryID set) or of an item (itemID set)
+func (r *restaurantRepo) ReplaceServicePeriods(categoryID, itemID *int, periods []domain.ServicePeriod) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if categoryID != nil {
+		_, err = tx.Exec("DELETE FROM service_periods WHERE category_id = $1", *categoryID)
+	} else {
+		_, err = tx.Exec("DELETE FROM service_periods WHERE menu_item_id = $1", *itemID)
+	}
+	if err != nil {
+		return err
+	}
+
+	for i := range periods {
+		p := &periods[i]
+		p.CategoryID, p.MenuItemID = categoryID, itemID
+		err := tx.Get(&p.ID, `
+			INSERT INTO service_periods (category_id, menu_item_id, name, starts_at, ends_at, days)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id`, p.CategoryID, p.MenuItemID, p.Name, p.Start, p.End, p.Days)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// SetSoldOut 86es the item until the given time; nil puts it back on sale
+func (r *restaurantRepo) SetSoldOut(itemID int, until *time.Time) error {
+	_, err := r.db.Exec("UPDATE menu_items SET sold_out_until = $1 WHERE id = $2", until, itemID)
+	return err
+}
+
+// attachLineModifiers fills in the modifiers chosen on each order line
+func (r *restaurantRepo) attachLineModifiers(items []domain.OrderItemDetail) error {
+	if len(items) == 0 {
+		return nil
+	}
+	lineIDs := make([]int, 0, len(items))
+	for _, item := range items {
+		lineIDs = append(lineIDs, item.ID)
+	}
+
+	var mods []domain.OrderItemModifier
+	query := `
+		SELECT order_item_id, option_id, group_name, option_name, price_delta
+		FROM restaurant_order_item_modifiers
+		WHERE order_item_id = ANY($1)
+		ORDER BY id ASC`
+	if err := r.db.Select(&mods, query, pq.Array(lineIDs)); err != nil {
+		return err
+	}
+
+	byLine := make(map[int][]domain.OrderItemModifier)
+	for _, m := range mods {
+		byLine[m.OrderItemID] = append(byLine[m.OrderItemID], m)
+	}
+	for i := range items {
+		items[i].Modifiers = byLine[items[i].ID]
+	}
+	return nil
+}
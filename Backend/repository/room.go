@@ -3,7 +3,8 @@ package repository
 import (
 	"database/sql"
 	"fmt"
-	
+	"time"
+
 	"oasis/backend/domain"
 	"oasis/backend/room"
 
@@ -25,18 +26,52 @@ func NewRoomRepo(db *sqlx.DB) RoomRepo {
 	}
 }
 
+// roomSelect is shared by every room read so the type join and nullable columns are handled once
+const roomSelect = `
+	SELECT
+		r.id, r.room_number, r.status,
+		COALESCE(r.room_type_id, 0) AS room_type_id,
+		COALESCE(t.name, r.type) AS type,
+		COALESCE(r.price, t.base_rate, 0) AS price,
+		COALESCE(r.floor, 0) AS floor,
+		COALESCE(r.features, '{}') AS features,
+		COALESCE(r.max_occupancy, t.max_occupancy, 2) AS max_occupancy,
+		r.out_of_order_from, r.out_of_order_to,
+		COALESCE(r.out_of_order_reason, '') AS out_of_order_reason,
+		r.retired_at
+	FROM rooms r
+	LEFT JOIN room_types t ON t.id = r.room_type_id`
+
+// roomTypeColumns coalesces the optional text columns of room_types
+const roomTypeColumns = `
+	t.id, t.name,
+	COALESCE(t.description, '') AS description,
+	t.base_occupancy, t.max_occupancy,
+	COALESCE(t.bed_configuration, '') AS bed_configuration,
+	COALESCE(t.amenities, '{}') AS amenities,
+	COALESCE(t.photos, '{}') AS photos,
+	t.base_rate, t.cleaning_credits`
+
 func (r *roomRepo) Create(rm domain.Room) (*domain.Room, error) {
 	query := `
 	INSERT INTO rooms (
 		room_number, 
+		room_type_id,
 		type, 
 		status, 
-		price
+		price,
+		floor,
+		features,
+		max_occupancy
 	) VALUES (
 		:room_number, 
+		:room_type_id,
 		:type, 
 		:status, 
-		:price
+		NULLIF(:price, 0),
+		:floor,
+		:features,
+		NULLIF(:max_occupancy, 0)
 	) RETURNING id
 	`
 	
@@ -63,9 +98,8 @@ func (r *roomRepo) Create(rm domain.Room) (*domain.Room, error) {
 func (r *roomRepo) Find(roomNumber string) (*domain.Room, error) {
 	var rm domain.Room
 	query := `
-	SELECT id, room_number, type, status, price
-	FROM rooms 
-	WHERE room_number = $1
+	` + roomSelect + `
+	WHERE r.room_number = $1
 	LIMIT 1
 	`
 
@@ -85,9 +119,8 @@ func (r *roomRepo) FindByID(id string) (*domain.Room, error) {
 	var rm domain.Room
 	// Note: If you switched to Int IDs, change the query argument type here
 	query := `
-	SELECT id, room_number, type, status, price
-	FROM rooms 
-	WHERE id = $1
+	` + roomSelect + `
+	WHERE r.id = $1
 	LIMIT 1
 	`
 
@@ -102,16 +135,17 @@ func (r *roomRepo) FindByID(id string) (*domain.Room, error) {
 	return &rm, nil
 }
 
+// GetAll lists the active inventory (retired rooms are excluded)
 func (r *roomRepo) GetAll(status string) ([]domain.Room, error) {
 	var rooms []domain.Room
 	var err error
 
 	// Dynamic Query: Check if we are filtering or fetching all
 	if status == "" {
-		query := `SELECT id, room_number, type, status, price FROM rooms`
+		query := roomSelect + ` WHERE r.retired_at IS NULL ORDER BY r.room_number ASC`
 		err = r.db.Select(&rooms, query)
 	} else {
-		query := `SELECT id, room_number, type, status, price FROM rooms WHERE status = $1`
+		query := roomSelect + ` WHERE r.retired_at IS NULL AND r.status = $1 ORDER BY r.room_number ASC`
 		err = r.db.Select(&rooms, query, status)
 	}
 
@@ -121,3 +155,180 @@ func (r *roomRepo) GetAll(status string) ([]domain.Room, error) {
 
 	return rooms, nil
 }
+
+func (r *roomRepo) Update(rm domain.Room) error {
+	query := `
+	UPDATE rooms
+	SET room_type_id = :room_type_id, type = :type, price = NULLIF(:price, 0),
+	    floor = :floor, features = :features, max_occupancy = NULLIF(:max_occupancy, 0)
+	WHERE room_number = :room_number
+	`
+	_, err := r.db.NamedExec(query, rm)
+	return err
+}
+
+// SetOutOfOrder stores the blocking window. Passing nil dates clears it.
+func (r *roomRepo) SetOutOfOrder(roomNumber string, from, to *time.Time, reason string) error {
+	query := `
+	UPDATE rooms
+	SET out_of_order_from = $1, out_of_order_to = $2, out_of_order_reason = NULLIF($3, '')
+	WHERE room_number = $4
+	`
+	_, err := r.db.Exec(query, from, to, reason, roomNumber)
+	return err
+}
+
+func (r *roomRepo) Retire(roomNumber string) error {
+	_, err := r.db.Exec("UPDATE rooms SET retired_at = NOW() WHERE room_number = $1", roomNumber)
+	return err
+}
+
+// HasActiveGuest checks the guests table for a stay that has not been checked out yet
+func (r *roomRepo) HasActiveGuest(roomNumber string) (bool, error) {
+	var exists bool
+	query := `SELECT EXISTS(SELECT 1 FROM guests WHERE room_number = $1 AND status = 'CHECKED_IN')`
+	err := r.db.Get(&exists, query, roomNumber)
+	return exists, err
+}
+
+func (r *roomRepo) FetchRoomTypes() ([]domain.RoomType, error) {
+	var types []domain.RoomType
+	query := `SELECT ` + roomTypeColumns + ` FROM room_types t ORDER BY t.base_rate ASC`
+	err := r.db.Select(&types, query)
+	return types, err
+}
+
+func (r *roomRepo) FindRoomType(id int) (*domain.RoomType, error) {
+	var rt domain.RoomType
+	query := `SELECT ` + roomTypeColumns + ` FROM room_types t WHERE t.id = $1`
+	err := r.db.Get(&rt, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &rt, nil
+}
+
+func (r *roomRepo) FindRoomTypeByName(name string) (*domain.RoomType, error) {
+	var rt domain.RoomType
+	query := `SELECT ` + roomTypeColumns + ` FROM room_types t WHERE t.name = $1`
+	err := r.db.Get(&rt, query, name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &rt, nil
+}
+
+func (r *roomRepo) CreateRoomType(rt *domain.RoomType) error {
+	query := `
+	INSERT INTO room_types (name, description, base_occupancy, max_occupancy, bed_configuration, amenities, photos, base_rate, cleaning_credits)
+	VALUES (:name, :description, :base_occupancy, :max_occupancy, :bed_configuration, :amenities, :photos, :base_rate, :cleaning_credits)
+	RETURNING id`
+
+	rows, err := r.db.NamedQuery(query, rt)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		return rows.Scan(&rt.ID)
+	}
+	return nil
+}
+
+// UpdateRoomType also refreshes the legacy rooms.type name so old readers stay correct
+func (r *roomRepo) UpdateRoomType(rt *domain.RoomType) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+	UPDATE room_types
+	SET name = :name, description = :description, base_occupancy = :base_occupancy,
+	    max_occupancy = :max_occupancy, bed_configuration = :bed_configuration,
+	    amenities = :amenities, photos = :photos, base_rate = :base_rate,
+	    cleaning_credits = :cleaning_credits
+	WHERE id = :id`
+	if _, err := tx.NamedExec(query, rt); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec("UPDATE rooms SET type = $1 WHERE room_type_id = $2", rt.Name, rt.ID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SearchAvailability counts, per room type, the rooms that are free for the whole [checkIn, checkOut) stay
+func (r *roomRepo) SearchAvailability(checkIn, checkOut time.Time, guests int) ([]domain.RoomTypeAvailability, error) {
+	var result []domain.RoomTypeAvailability
+	query := `
+	SELECT ` + roomTypeColumns + `,
+		COUNT(r.id) AS available_rooms,
+		MIN(COALESCE(r.price, t.base_rate)) AS from_rate
+	FROM room_types t
+	JOIN rooms r ON r.room_type_id = t.id
+	WHERE r.retired_at IS NULL
+	  AND COALESCE(r.max_occupancy, t.max_occupancy) >= $3
+	  AND NOT (r.out_of_order_from IS NOT NULL AND r.out_of_order_from < $2 AND r.out_of_order_to >= $1)
+	  AND NOT EXISTS (
+		SELECT 1 FROM guests g
+		WHERE g.room_number = r.room_number
+		  AND g.status = 'CHECKED_IN'
+		  AND g.check_in_date < $2
+		  AND g.check_out_date > $1
+	  )
+	  AND NOT EXISTS (
+		SELECT 1 FROM group_room_blocks b
+		JOIN booking_groups bg ON bg.id = b.group_id
+		WHERE b.room_number = r.room_number
+		  AND b.status = 'HELD'
+		  AND bg.check_in_date < $2
+		  AND bg.check_out_date > $1
+	  )
+	GROUP BY t.id
+	ORDER BY from_rate ASC
+	`
+	err := r.db.Select(&result, query, checkIn, checkOut, guests)
+	return result, err
+}
+
+// IsRoomFree checks that no other checked-in stay, out-of-order window or group block overlaps [from, to).
+// Blocks held by groupID itself do not count, so group members can be assigned into them.
+func (r *roomRepo) IsRoomFree(roomNumber string, from, to time.Time, excludeGuestID, groupID int) (bool, error) {
+	var free bool
+	query := `
+	SELECT NOT EXISTS (
+		SELECT 1 FROM guests g
+		WHERE g.room_number = $1
+		  AND g.status = 'CHECKED_IN'
+		  AND g.id != $4
+		  AND g.check_in_date < $3
+		  AND g.check_out_date > $2
+	) AND NOT EXISTS (
+		SELECT 1 FROM rooms r
+		WHERE r.room_number = $1
+		  AND r.out_of_order_from IS NOT NULL
+		  AND r.out_of_order_from < $3
+		  AND r.out_of_order_to >= $2
+	) AND NOT EXISTS (
+		SELECT 1 FROM group_room_blocks b
+		JOIN booking_groups bg ON bg.id = b.group_id
+		WHERE b.room_number = $1
+		  AND b.status = 'HELD'
+		  AND b.group_id != $5
+		  AND bg.check_in_date < $3
+		  AND bg.check_out_date > $2
+	)`
+	err := r.db.Get(&free, query, roomNumber, from, to, excludeGuestID, groupID)
+	return free, err
+}
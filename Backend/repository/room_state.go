@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"fmt"
+
+	"oasis/backend/domain"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// setRoomStateTx moves a room inside an existing transaction and records the audit row.
+// It is used by front desk flows (check-in, checkout, room moves) that must commit together
+// with other tables. The move is checked against the state machine like any other: a guest
+// cannot be put into a room housekeeping has not turned over yet.
+func setRoomStateTx(tx *sqlx.Tx, roomNumber string, next func(domain.RoomState) domain.RoomState, actor domain.Actor, reason string) error {
+	var from domain.RoomState
+	err := tx.Get(&from, "SELECT status, housekeeping_status FROM rooms WHERE room_number = $1 FOR UPDATE", roomNumber)
+	if err != nil {
+		return err
+	}
+	to := next(from)
+	if !from.CanTransitionTo(to) {
+		return fmt.Errorf("%w: room %s is %s", domain.ErrIllegalTransition, roomNumber, from)
+	}
+
+	_, err = tx.Exec("UPDATE rooms SET status = $1, housekeeping_status = $2, "+dndColumns(4)+" WHERE room_number = $3",
+		to.Status, to.Housekeeping, roomNumber, to.Housekeeping == domain.HousekeepingDND)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO room_status_history (
+			room_number, from_status, from_housekeeping_status, to_status, to_housekeeping_status,
+			changed_by_id, changed_by_role, reason
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		roomNumber, from.Status, from.Housekeeping, to.Status, to.Housekeeping, actor.ID, actor.Role, reason)
+	return err
+}
+
+// dndColumns keeps the DND clock in step with housekeeping_status; param is the
+// placeholder of a boolean "the new state is DND". The clock starts when the room
+// enters DND and is cleared, together with any welfare alert, when it leaves.
+func dndColumns(param int) string {
+	return fmt.Sprintf(`
+	dnd_since = CASE WHEN $%[1]d THEN COALESCE(dnd_since, NOW()) ELSE NULL END,
+	dnd_alerted_at = CASE WHEN $%[1]d THEN dnd_alerted_at ELSE NULL END`, param)
+}
+
+// occupyRoom is the check-in transition: a clean or inspected room becomes a clean occupied room.
+// From any other state the result is not a legal move, so setRoomStateTx refuses it.
+func occupyRoom(from domain.RoomState) domain.RoomState {
+	hk := from.Housekeeping
+	if hk == domain.HousekeepingInspected {
+		hk = domain.HousekeepingClean
+	}
+	return domain.RoomState{Status: domain.RoomStatusOccupied, Housekeeping: hk}
+}
+
+// vacateRoom is the checkout transition: the room always needs a full clean
+func vacateRoom(domain.RoomState) domain.RoomState {
+	return domain.RoomState{Status: domain.RoomStatusVacant, Housekeeping: domain.HousekeepingDirty}
+}
@@ -0,0 +1,55 @@
+package attachment
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"oasis/backend/domain"
+	"oasis/backend/util"
+)
+
+// GET /attachments/{id}?variant=thumb&expires=1735689600&sig=...
+func (h *Handler) Download(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid attachment ID")
+		return
+	}
+
+	query := r.URL.Query()
+	expires, err := strconv.ParseInt(query.Get("expires"), 10, 64)
+	if err != nil {
+		util.SendError(w, http.StatusForbidden, domain.ErrInvalidSignature.Error())
+		return
+	}
+	variant := query.Get("variant")
+	if variant == "" {
+		variant = domain.AttachmentOriginal
+	}
+
+	att, file, err := h.svc.Open(id, variant, expires, query.Get("sig"))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidSignature):
+			util.SendError(w, http.StatusForbidden, err.Error())
+		case errors.Is(err, domain.ErrAttachmentNotFound):
+			util.SendError(w, http.StatusNotFound, err.Error())
+		default:
+			util.SendError(w, http.StatusInternalServerError, "Failed to open attachment")
+		}
+		return
+	}
+	defer file.Close()
+
+	contentType := att.ContentType
+	if variant == domain.AttachmentThumbnail {
+		contentType = "image/jpeg"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "private, max-age=600")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	io.Copy(w, file)
+}
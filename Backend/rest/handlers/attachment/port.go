@@ -0,0 +1,13 @@
+package attachment
+
+import (
+	"io"
+
+	"oasis/backend/domain"
+)
+
+// Service defines the methods the Handler needs from the Business Logic layer.
+type Service interface {
+	// Open checks the signed link and streams the requested variant of the file
+	Open(id int, variant string, expires int64, signature string) (*domain.Attachment, io.ReadCloser, error)
+}
@@ -0,0 +1,12 @@
+package attachment
+
+import (
+	"net/http"
+
+	middleware "oasis/backend/rest/middlewares"
+)
+
+func (h *Handler) RegisterRoutes(mux *http.ServeMux, manager *middleware.Manager) {
+	// Links are signed and short-lived, so <img src> tags work without a JWT
+	mux.Handle("GET /attachments/{id}", manager.With(http.HandlerFunc(h.Download)))
+}
@@ -0,0 +1,78 @@
+package group
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"oasis/backend/domain"
+	"oasis/backend/util"
+)
+
+// ReqAssignGuest checks a group member into one of the held rooms
+type ReqAssignGuest struct {
+	Name        string `json:"name"`
+	PhoneNumber string `json:"phone_number"`
+	RoomNumber  string `json:"room_number"`
+}
+
+// POST /groups/{id}/assign
+func (h *Handler) AssignGuest(w http.ResponseWriter, r *http.Request) {
+	groupID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid group id")
+		return
+	}
+
+	var req ReqAssignGuest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Name == "" || req.RoomNumber == "" {
+		util.SendError(w, http.StatusBadRequest, "Name and room number are required")
+		return
+	}
+
+	gst, err := h.svc.AssignGuest(groupID, req.RoomNumber, domain.Guest{
+		Name:        req.Name,
+		PhoneNumber: req.PhoneNumber,
+	}, util.ActorFromRequest(r))
+	if err != nil {
+		sendGroupError(w, err)
+		return
+	}
+	util.SendData(w, http.StatusCreated, gst)
+}
+
+// POST /groups/{id}/release
+func (h *Handler) ReleaseUnassigned(w http.ResponseWriter, r *http.Request) {
+	groupID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid group id")
+		return
+	}
+
+	released, err := h.svc.ReleaseUnassigned(groupID)
+	if err != nil {
+		sendGroupError(w, err)
+		return
+	}
+	util.SendData(w, http.StatusOK, map[string]int{"released_rooms": released})
+}
+
+// GET /groups/{id}/folio
+func (h *Handler) GetFolio(w http.ResponseWriter, r *http.Request) {
+	groupID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid group id")
+		return
+	}
+
+	folio, err := h.svc.GetFolio(groupID)
+	if err != nil {
+		sendGroupError(w, err)
+		return
+	}
+	util.SendData(w, http.StatusOK, folio)
+}
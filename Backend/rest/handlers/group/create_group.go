@@ -0,0 +1,112 @@
+package group
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"oasis/backend/domain"
+	"oasis/backend/util"
+)
+
+// ReqCreateGroup defines the JSON payload for a new group booking
+type ReqCreateGroup struct {
+	Name                   string   `json:"name"`
+	ContactName            string   `json:"contact_name"`
+	ContactPhone           string   `json:"contact_phone"`
+	CheckInDate            string   `json:"check_in_date"`  // Format: "2025-12-03"
+	CheckOutDate           string   `json:"check_out_date"` // Format: "2025-12-05"
+	CutoffDate             string   `json:"cutoff_date"`    // Unassigned rooms are released after this date
+	Rooms                  []string `json:"rooms"`
+	BillRoomToMaster       *bool    `json:"bill_room_to_master"` // Defaults to true
+	BillRestaurantToMaster bool     `json:"bill_restaurant_to_master"`
+	BillLaundryToMaster    bool     `json:"bill_laundry_to_master"`
+}
+
+// POST /groups
+func (h *Handler) CreateGroup(w http.ResponseWriter, r *http.Request) {
+	var req ReqCreateGroup
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Name == "" || len(req.Rooms) == 0 {
+		util.SendError(w, http.StatusBadRequest, "Name and at least one room are required")
+		return
+	}
+
+	checkIn, err1 := time.Parse("2006-01-02", req.CheckInDate)
+	checkOut, err2 := time.Parse("2006-01-02", req.CheckOutDate)
+	cutoff, err3 := time.Parse("2006-01-02", req.CutoffDate)
+	if err1 != nil || err2 != nil || err3 != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid date format. Use YYYY-MM-DD")
+		return
+	}
+
+	billRoom := true
+	if req.BillRoomToMaster != nil {
+		billRoom = *req.BillRoomToMaster
+	}
+
+	group, err := h.svc.CreateGroup(domain.BookingGroup{
+		Name:                   req.Name,
+		ContactName:            req.ContactName,
+		ContactPhone:           req.ContactPhone,
+		CheckInDate:            checkIn,
+		CheckOutDate:           checkOut,
+		CutoffDate:             cutoff,
+		BillRoomToMaster:       billRoom,
+		BillRestaurantToMaster: req.BillRestaurantToMaster,
+		BillLaundryToMaster:    req.BillLaundryToMaster,
+	}, req.Rooms)
+	if err != nil {
+		sendGroupError(w, err)
+		return
+	}
+
+	util.SendData(w, http.StatusCreated, group)
+}
+
+// GET /groups
+func (h *Handler) GetGroups(w http.ResponseWriter, r *http.Request) {
+	groups, err := h.svc.GetGroups()
+	if err != nil {
+		util.SendError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	util.SendData(w, http.StatusOK, groups)
+}
+
+// GET /groups/{id}
+func (h *Handler) GetGroup(w http.ResponseWriter, r *http.Request) {
+	groupID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid group id")
+		return
+	}
+
+	group, err := h.svc.GetGroup(groupID)
+	if err != nil {
+		sendGroupError(w, err)
+		return
+	}
+	util.SendData(w, http.StatusOK, group)
+}
+
+// sendGroupError maps group booking errors to HTTP status codes
+func sendGroupError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrGroupNotFound), errors.Is(err, domain.ErrRoomNotFound):
+		util.SendError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrGroupClosed), errors.Is(err, domain.ErrGroupCutoffPassed), errors.Is(err, domain.ErrRoomNotInBlock),
+		errors.Is(err, domain.ErrRoomUnavailable), errors.Is(err, domain.ErrRoomRetired),
+		errors.Is(err, domain.ErrIllegalTransition):
+		util.SendError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, domain.ErrInvalidStayDates):
+		util.SendError(w, http.StatusBadRequest, err.Error())
+	default:
+		util.SendError(w, http.StatusInternalServerError, "Internal server error: "+err.Error())
+	}
+}
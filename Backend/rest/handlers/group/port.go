@@ -0,0 +1,17 @@
+package group
+
+import "oasis/backend/domain"
+
+// Service defines the methods the Handler needs from the Business Logic layer.
+type Service interface {
+	CreateGroup(group domain.BookingGroup, rooms []string) (*domain.GroupWithBlocks, error)
+	GetGroups() ([]domain.BookingGroup, error)
+	GetGroup(id int) (*domain.GroupWithBlocks, error)
+
+	// AssignGuest checks a member into one of the rooms held by the group
+	AssignGuest(groupID int, roomNumber string, guest domain.Guest, actor domain.Actor) (*domain.Guest, error)
+
+	// ReleaseUnassigned gives the group's held rooms back to general inventory
+	ReleaseUnassigned(groupID int) (int, error)
+	GetFolio(groupID int) (*domain.GroupFolio, error)
+}
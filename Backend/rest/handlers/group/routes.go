@@ -0,0 +1,62 @@
+package group
+
+import (
+	"net/http"
+
+	"oasis/backend/domain"
+	middleware "oasis/backend/rest/middlewares"
+)
+
+func (h *Handler) RegisterRoutes(mux *http.ServeMux, manager *middleware.Manager) {
+	// Group bookings are handled by the Front Desk
+	frontDesk := h.middlewares.AuthorizeRoles(domain.StaffRoleReceptionist, domain.StaffRoleManager, domain.StaffRoleAdmin)
+
+	mux.Handle(
+		"POST /groups",
+		manager.With(
+			http.HandlerFunc(h.CreateGroup),
+			frontDesk,
+			h.middlewares.AuthinticateJWT,
+		),
+	)
+	mux.Handle(
+		"GET /groups",
+		manager.With(
+			http.HandlerFunc(h.GetGroups),
+			frontDesk,
+			h.middlewares.AuthinticateJWT,
+		),
+	)
+	mux.Handle(
+		"GET /groups/{id}",
+		manager.With(
+			http.HandlerFunc(h.GetGroup),
+			frontDesk,
+			h.middlewares.AuthinticateJWT,
+		),
+	)
+	mux.Handle(
+		"POST /groups/{id}/assign",
+		manager.With(
+			http.HandlerFunc(h.AssignGuest),
+			frontDesk,
+			h.middlewares.AuthinticateJWT,
+		),
+	)
+	mux.Handle(
+		"POST /groups/{id}/release",
+		manager.With(
+			http.HandlerFunc(h.ReleaseUnassigned),
+			frontDesk,
+			h.middlewares.AuthinticateJWT,
+		),
+	)
+	mux.Handle(
+		"GET /groups/{id}/folio",
+		manager.With(
+			http.HandlerFunc(h.GetFolio),
+			frontDesk,
+			h.middlewares.AuthinticateJWT,
+		),
+	)
+}
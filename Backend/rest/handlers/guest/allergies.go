@@ -0,0 +1,43 @@
+package guest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"oasis/backend/domain"
+	"oasis/backend/util"
+)
+
+type ReqSetAllergies struct {
+	Allergies []string `json:"allergies"` // e.g. ["NUTS", "SHELLFISH"]; empty clears the list
+}
+
+// PUT /guests/{id}/allergies
+func (h *Handler) SetAllergies(w http.ResponseWriter, r *http.Request) {
+	guestID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid guest id")
+		return
+	}
+
+	// Guests may only edit their own profile
+	actor := util.ActorFromRequest(r)
+	if actor.Role == domain.ActorRoleGuest && actor.ID != guestID {
+		util.SendError(w, http.StatusForbidden, "Forbidden")
+		return
+	}
+
+	var req ReqSetAllergies
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	gst, err := h.svc.SetAllergies(guestID, req.Allergies)
+	if err != nil {
+		sendStayError(w, err)
+		return
+	}
+	util.SendData(w, http.StatusOK, gst)
+}
@@ -0,0 +1,105 @@
+package guest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"oasis/backend/domain"
+	"oasis/backend/util"
+)
+
+type ReqMoveRoom struct {
+	RoomNumber string `json:"room_number"`
+	Reason     string `json:"reason"` // e.g. "Broken AC"
+}
+
+type ReqExtendStay struct {
+	CheckOutDate string `json:"check_out_date"` // Format: "2025-12-07"
+}
+
+// POST /guests/{id}/move
+func (h *Handler) MoveRoom(w http.ResponseWriter, r *http.Request) {
+	guestID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid guest id")
+		return
+	}
+
+	var req ReqMoveRoom
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.RoomNumber == "" {
+		util.SendError(w, http.StatusBadRequest, "Room number is required")
+		return
+	}
+
+	gst, err := h.svc.MoveRoom(guestID, req.RoomNumber, req.Reason, util.ActorFromRequest(r))
+	if err != nil {
+		sendStayError(w, err)
+		return
+	}
+	util.SendData(w, http.StatusOK, gst)
+}
+
+// POST /guests/{id}/extend
+func (h *Handler) ExtendStay(w http.ResponseWriter, r *http.Request) {
+	guestID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid guest id")
+		return
+	}
+
+	var req ReqExtendStay
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	checkOut, err := time.Parse("2006-01-02", req.CheckOutDate)
+	if err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid check-out date format. Use YYYY-MM-DD")
+		return
+	}
+
+	gst, err := h.svc.ExtendStay(guestID, checkOut)
+	if err != nil {
+		sendStayError(w, err)
+		return
+	}
+	util.SendData(w, http.StatusOK, gst)
+}
+
+// GET /guests/{id}/segments
+func (h *Handler) GetStaySegments(w http.ResponseWriter, r *http.Request) {
+	guestID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid guest id")
+		return
+	}
+
+	segments, err := h.svc.GetStaySegments(guestID)
+	if err != nil {
+		util.SendError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	util.SendData(w, http.StatusOK, segments)
+}
+
+// sendStayError maps check-in / move / extension errors to HTTP status codes
+func sendStayError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrGuestNotFound), errors.Is(err, domain.ErrRoomNotFound):
+		util.SendError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrRoomUnavailable), errors.Is(err, domain.ErrRoomRetired),
+		errors.Is(err, domain.ErrGuestNotCheckedIn), errors.Is(err, domain.ErrIllegalTransition):
+		util.SendError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, domain.ErrInvalidStayDates), errors.Is(err, domain.ErrInvalidAllergen):
+		util.SendError(w, http.StatusBadRequest, err.Error())
+	default:
+		util.SendError(w, http.StatusInternalServerError, "Internal server error: "+err.Error())
+	}
+}
@@ -12,11 +12,12 @@ import (
 
 // ReqCreateGuest defines the JSON payload sent by the Front Desk staff
 type ReqCreateGuest struct {
-	Name         string `json:"name"`
-	PhoneNumber  string `json:"phone_number"`
-	RoomNumber   string `json:"room_number"`
-	CheckInDate  string `json:"check_in_date"`  // Format: "2025-12-03"
-	CheckOutDate string `json:"check_out_date"` // Format: "2025-12-05"
+	Name         string   `json:"name"`
+	PhoneNumber  string   `json:"phone_number"`
+	RoomNumber   string   `json:"room_number"`
+	CheckInDate  string   `json:"check_in_date"`  // Format: "2025-12-03"
+	CheckOutDate string   `json:"check_out_date"` // Format: "2025-12-05"
+	Allergies    []string `json:"allergies"`      // e.g. ["NUTS", "SHELLFISH"]
 }
 
 func (h *Handler) CreateGuest(w http.ResponseWriter, r *http.Request) {
@@ -68,11 +69,12 @@ func (h *Handler) CreateGuest(w http.ResponseWriter, r *http.Request) {
 		RoomNumber:   req.RoomNumber,
 		CheckInDate:  checkInDate,
 		CheckOutDate: checkOutDate,
+		Allergies:    req.Allergies,
 		CreatedAt:    time.Now(),
-	})
+	}, util.ActorFromRequest(r))
 
 	if err != nil {
-		util.SendError(w, http.StatusInternalServerError, "Internal server error: "+err.Error())
+		sendStayError(w, err)
 		return
 	}
 
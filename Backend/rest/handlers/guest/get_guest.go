@@ -1,6 +1,7 @@
 package guest
 
 import (
+	"oasis/backend/domain"
 	"oasis/backend/util"
 	"net/http"
 	"strconv"
@@ -15,6 +16,13 @@ func (h *Handler) GetGuest(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Guests may only read their own profile
+	actor := util.ActorFromRequest(r)
+	if actor.Role == domain.ActorRoleGuest && actor.ID != gId {
+		util.SendError(w, http.StatusForbidden, "Forbidden")
+		return
+	}
+
 	gst, err := h.svc.Get(gId)
 	if err != nil {
 		util.SendError(w, http.StatusInternalServerError, "Internal server error")
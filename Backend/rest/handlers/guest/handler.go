@@ -2,19 +2,19 @@ package guest
 
 import (
 	"oasis/backend/config"
+	middleware "oasis/backend/rest/middlewares"
 )
 
 type Handler struct {
-	// Changed from *config.Config to *middleware.Middlewares
-	cnf *config.Config
-	svc Service
+	cnf         *config.Config
+	middlewares *middleware.Middlewares
+	svc         Service
 }
 
-// Update constructor to accept middlewares
-func NewHandler(cnf *config.Config, svc Service) *Handler {
+func NewHandler(cnf *config.Config, middlewares *middleware.Middlewares, svc Service) *Handler {
 	return &Handler{
-		cnf: cnf,
-		svc: svc,
+		cnf:         cnf,
+		middlewares: middlewares,
+		svc:         svc,
 	}
 }
-
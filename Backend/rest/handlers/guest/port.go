@@ -1,12 +1,23 @@
 package guest
 
-import "oasis/backend/domain"
+import (
+	"time"
+
+	"oasis/backend/domain"
+)
 
 // Service defines the methods the Handler needs from the Business Logic layer.
 type Service interface {
 	Find(roomNumber, phoneNumber string) (*domain.Guest, error)
-	Create(guest domain.Guest) (*domain.Guest, error)
+	Create(guest domain.Guest, actor domain.Actor) (*domain.Guest, error)
 	Get(id int) (*domain.Guest, error)
 	GetByRoomNumber(roomNumber string) (*domain.Guest, error)
-}
 
+	// Mid-stay changes (Front Desk)
+	MoveRoom(guestID int, newRoom, reason string, actor domain.Actor) (*domain.Guest, error)
+	ExtendStay(guestID int, newCheckOut time.Time) (*domain.Guest, error)
+	GetStaySegments(guestID int) ([]domain.StaySegment, error)
+
+	// Profile
+	SetAllergies(guestID int, allergies []string) (*domain.Guest, error)
+}
@@ -1,6 +1,7 @@
 package guest
 
 import (
+	"oasis/backend/domain"
 	middleware "oasis/backend/rest/middlewares"
 	"net/http"
 )
@@ -21,10 +22,46 @@ func (h *Handler) RegisterRoutes(mux *http.ServeMux, manager *middleware.Manager
 			http.HandlerFunc(h.Login),
 		),
 	)
+	// The guest themselves or any staff member (the profile carries allergies)
 	mux.Handle(
 		"GET /guests/{id}",
 		manager.With(
 			http.HandlerFunc(h.GetGuest),
+			h.middlewares.AuthinticateJWT,
+		),
+	)
+
+	// Mid-stay changes (Front Desk)
+	frontDesk := h.middlewares.AuthorizeRoles(domain.StaffRoleReceptionist, domain.StaffRoleManager, domain.StaffRoleAdmin)
+	mux.Handle(
+		"POST /guests/{id}/move",
+		manager.With(
+			http.HandlerFunc(h.MoveRoom),
+			frontDesk,
+			h.middlewares.AuthinticateJWT,
+		),
+	)
+	mux.Handle(
+		"POST /guests/{id}/extend",
+		manager.With(
+			http.HandlerFunc(h.ExtendStay),
+			frontDesk,
+			h.middlewares.AuthinticateJWT,
+		),
+	)
+	mux.Handle(
+		"GET /guests/{id}/segments",
+		manager.With(
+			http.HandlerFunc(h.GetStaySegments),
+		),
+	)
+
+	// Profile: the guest or any staff member
+	mux.Handle(
+		"PUT /guests/{id}/allergies",
+		manager.With(
+			http.HandlerFunc(h.SetAllergies),
+			h.middlewares.AuthinticateJWT,
 		),
 	)
 }
@@ -0,0 +1,165 @@
+package housekeeping
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"oasis/backend/domain"
+	"oasis/backend/util"
+)
+
+// ReqAmenityItem is the payload for adding or editing a catalog entry
+// e.g. { "code": "BATHROBE", "name": "Bathrobe", "category": "LINEN", "per_stay_limit": 2, "price": 0, "par_level": 10 }
+type ReqAmenityItem struct {
+	Code         string  `json:"code"`
+	Name         string  `json:"name"`
+	Category     string  `json:"category"`
+	PerStayLimit *int    `json:"per_stay_limit"` // Omit for unlimited
+	Price        float64 `json:"price"`
+	StockOnHand  int     `json:"stock_on_hand"` // Opening stock, only used on create
+	ParLevel     int     `json:"par_level"`
+	Active       *bool   `json:"active"`
+}
+
+func (req ReqAmenityItem) toDomain() (domain.AmenityItem, string) {
+	if req.Code == "" || req.Name == "" || req.Category == "" {
+		return domain.AmenityItem{}, "Code, name and category are required"
+	}
+	if req.PerStayLimit != nil && *req.PerStayLimit < 1 {
+		return domain.AmenityItem{}, "per_stay_limit must be at least 1"
+	}
+	if req.Price < 0 || req.StockOnHand < 0 || req.ParLevel < 0 {
+		return domain.AmenityItem{}, "Price, stock and par level must not be negative"
+	}
+
+	item := domain.AmenityItem{
+		Code:         req.Code,
+		Name:         req.Name,
+		Category:     req.Category,
+		PerStayLimit: req.PerStayLimit,
+		Price:        req.Price,
+		StockOnHand:  req.StockOnHand,
+		ParLevel:     req.ParLevel,
+		Active:       true,
+	}
+	if req.Active != nil {
+		item.Active = *req.Active
+	}
+	return item, ""
+}
+
+// GET /housekeeping/amenity-catalog?all=true
+func (h *Handler) GetAmenityCatalog(w http.ResponseWriter, r *http.Request) {
+	items, err := h.svc.GetAmenityCatalog(r.URL.Query().Get("all") == "true")
+	if err != nil {
+		util.SendError(w, 500, "Failed to fetch amenity catalog")
+		return
+	}
+	util.SendData(w, 200, items)
+}
+
+// POST /housekeeping/amenity-catalog
+func (h *Handler) CreateAmenityItem(w http.ResponseWriter, r *http.Request) {
+	var req ReqAmenityItem
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.SendError(w, 400, "Invalid JSON")
+		return
+	}
+	item, msg := req.toDomain()
+	if msg != "" {
+		util.SendError(w, 400, msg)
+		return
+	}
+
+	created, err := h.svc.CreateAmenityItem(item)
+	if err != nil {
+		sendAmenityError(w, err, "Failed to create amenity")
+		return
+	}
+	util.SendData(w, 201, created)
+}
+
+// PUT /housekeeping/amenity-catalog/{id}
+func (h *Handler) UpdateAmenityItem(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, 400, "Invalid amenity ID")
+		return
+	}
+
+	var req ReqAmenityItem
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.SendError(w, 400, "Invalid JSON")
+		return
+	}
+	item, msg := req.toDomain()
+	if msg != "" {
+		util.SendError(w, 400, msg)
+		return
+	}
+	item.ID = id
+
+	updated, err := h.svc.UpdateAmenityItem(item)
+	if err != nil {
+		sendAmenityError(w, err, "Failed to update amenity")
+		return
+	}
+	util.SendData(w, 200, updated)
+}
+
+// POST /housekeeping/amenity-catalog/{id}/stock
+// Payload: { "change": 50, "reason": "RESTOCK" } or { "change": -3, "reason": "ADJUSTMENT", "note": "Stained" }
+func (h *Handler) AdjustStock(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, 400, "Invalid amenity ID")
+		return
+	}
+
+	var req struct {
+		Change int    `json:"change"`
+		Reason string `json:"reason"`
+		Note   string `json:"note"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.SendError(w, 400, "Invalid JSON")
+		return
+	}
+	if req.Reason == "" {
+		req.Reason = domain.StockReasonRestock
+	}
+
+	item, err := h.svc.AdjustStock(id, req.Change, req.Reason, req.Note, util.ActorFromRequest(r))
+	if err != nil {
+		sendAmenityError(w, err, "Failed to adjust stock")
+		return
+	}
+	util.SendData(w, 200, item)
+}
+
+// GET /housekeeping/amenity-catalog/{id}/ledger
+func (h *Handler) GetStockLedger(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, 400, "Invalid amenity ID")
+		return
+	}
+
+	movements, err := h.svc.GetStockLedger(id)
+	if err != nil {
+		util.SendError(w, 500, "Failed to fetch stock ledger")
+		return
+	}
+	util.SendData(w, 200, movements)
+}
+
+// GET /housekeeping/amenity-stock/low
+func (h *Handler) GetLowStockItems(w http.ResponseWriter, r *http.Request) {
+	items, err := h.svc.GetLowStockItems()
+	if err != nil {
+		util.SendError(w, 500, "Failed to fetch low stock items")
+		return
+	}
+	util.SendData(w, 200, items)
+}
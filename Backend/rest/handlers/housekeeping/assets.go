@@ -0,0 +1,274 @@
+package housekeeping
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"oasis/backend/domain"
+	"oasis/backend/util"
+)
+
+// ReqAsset is the payload for registering or editing an asset
+type ReqAsset struct {
+	Name         string  `json:"name"`
+	Category     string  `json:"category"`
+	RoomNumber   *string `json:"room_number"`
+	Location     string  `json:"location"` // Public areas
+	SerialNumber string  `json:"serial_number"`
+	InstalledAt  string  `json:"installed_at"` // Format: "2024-05-01"
+	Retired      bool    `json:"retired"`
+}
+
+func (req ReqAsset) toDomain() (domain.Asset, string) {
+	if req.Name == "" || req.Category == "" {
+		return domain.Asset{}, "Name and category are required"
+	}
+	if (req.RoomNumber == nil || *req.RoomNumber == "") && req.Location == "" {
+		return domain.Asset{}, "A room number or a location is required"
+	}
+
+	asset := domain.Asset{
+		Name:         req.Name,
+		Category:     req.Category,
+		Location:     req.Location,
+		SerialNumber: req.SerialNumber,
+	}
+	if req.RoomNumber != nil && *req.RoomNumber != "" {
+		asset.RoomNumber = req.RoomNumber
+	}
+	if req.InstalledAt != "" {
+		installed, err := time.Parse("2006-01-02", req.InstalledAt)
+		if err != nil {
+			return domain.Asset{}, "Invalid installed_at date. Use YYYY-MM-DD"
+		}
+		asset.InstalledAt = &installed
+	}
+	if req.Retired {
+		now := time.Now()
+		asset.RetiredAt = &now
+	}
+	return asset, ""
+}
+
+// ReqSchedule is the payload for a preventive maintenance schedule
+// e.g. { "asset_id": 3, "title": "Clean AC filter", "issue_type": "AC", "interval_count": 90, "interval_unit": "DAY", "next_due_date": "2025-12-01" }
+type ReqSchedule struct {
+	AssetID     *int                  `json:"asset_id"`
+	RoomNumber  *string               `json:"room_number"`
+	Title       string                `json:"title"`
+	IssueType   string                `json:"issue_type"`
+	Every       int                   `json:"interval_count"`
+	Unit        domain.RecurrenceUnit `json:"interval_unit"`
+	LeadDays    *int                  `json:"lead_days"` // Defaults to 7
+	NextDueDate string                `json:"next_due_date"`
+	BlocksRoom  bool                  `json:"blocks_room"`
+	BlockDays   int                   `json:"block_days"` // Defaults to 1
+	Active      *bool                 `json:"active"`
+}
+
+func (req ReqSchedule) toDomain() (domain.MaintenanceSchedule, string) {
+	due, err := time.Parse("2006-01-02", req.NextDueDate)
+	if err != nil {
+		return domain.MaintenanceSchedule{}, "Invalid next_due_date. Use YYYY-MM-DD"
+	}
+
+	schedule := domain.MaintenanceSchedule{
+		AssetID:     req.AssetID,
+		Title:       req.Title,
+		IssueType:   req.IssueType,
+		Every:       req.Every,
+		Unit:        req.Unit,
+		LeadDays:    7,
+		NextDueDate: due,
+		BlocksRoom:  req.BlocksRoom,
+		BlockDays:   req.BlockDays,
+		Active:      true,
+	}
+	if req.RoomNumber != nil && *req.RoomNumber != "" {
+		schedule.RoomNumber = req.RoomNumber
+	}
+	if req.LeadDays != nil {
+		schedule.LeadDays = *req.LeadDays
+	}
+	if schedule.BlockDays == 0 {
+		schedule.BlockDays = 1
+	}
+	if req.Active != nil {
+		schedule.Active = *req.Active
+	}
+	return schedule, ""
+}
+
+// POST /maintenance/assets
+func (h *Handler) CreateAsset(w http.ResponseWriter, r *http.Request) {
+	var req ReqAsset
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.SendError(w, 400, "Invalid JSON")
+		return
+	}
+	asset, msg := req.toDomain()
+	if msg != "" {
+		util.SendError(w, 400, msg)
+		return
+	}
+
+	created, err := h.svc.CreateAsset(asset)
+	if err != nil {
+		sendAssetError(w, err)
+		return
+	}
+	util.SendData(w, 201, created)
+}
+
+// GET /maintenance/assets
+func (h *Handler) GetAssets(w http.ResponseWriter, r *http.Request) {
+	assets, err := h.svc.GetAssets()
+	if err != nil {
+		util.SendError(w, 500, "Failed to fetch assets")
+		return
+	}
+	util.SendData(w, 200, assets)
+}
+
+// GET /maintenance/assets/{id}
+func (h *Handler) GetAsset(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, 400, "Invalid asset ID")
+		return
+	}
+
+	asset, err := h.svc.GetAsset(id)
+	if err != nil {
+		sendAssetError(w, err)
+		return
+	}
+	util.SendData(w, 200, asset)
+}
+
+// PUT /maintenance/assets/{id}
+func (h *Handler) UpdateAsset(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, 400, "Invalid asset ID")
+		return
+	}
+
+	var req ReqAsset
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.SendError(w, 400, "Invalid JSON")
+		return
+	}
+	asset, msg := req.toDomain()
+	if msg != "" {
+		util.SendError(w, 400, msg)
+		return
+	}
+	asset.ID = id
+
+	updated, err := h.svc.UpdateAsset(asset)
+	if err != nil {
+		sendAssetError(w, err)
+		return
+	}
+	util.SendData(w, 200, updated)
+}
+
+// POST /maintenance/schedules
+func (h *Handler) CreateSchedule(w http.ResponseWriter, r *http.Request) {
+	var req ReqSchedule
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.SendError(w, 400, "Invalid JSON")
+		return
+	}
+	schedule, msg := req.toDomain()
+	if msg != "" {
+		util.SendError(w, 400, msg)
+		return
+	}
+
+	created, err := h.svc.CreateSchedule(schedule)
+	if err != nil {
+		sendAssetError(w, err)
+		return
+	}
+	util.SendData(w, 201, created)
+}
+
+// GET /maintenance/schedules
+func (h *Handler) GetSchedules(w http.ResponseWriter, r *http.Request) {
+	schedules, err := h.svc.GetSchedules()
+	if err != nil {
+		util.SendError(w, 500, "Failed to fetch schedules")
+		return
+	}
+	util.SendData(w, 200, schedules)
+}
+
+// PUT /maintenance/schedules/{id}
+func (h *Handler) UpdateSchedule(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, 400, "Invalid schedule ID")
+		return
+	}
+
+	var req ReqSchedule
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.SendError(w, 400, "Invalid JSON")
+		return
+	}
+	schedule, msg := req.toDomain()
+	if msg != "" {
+		util.SendError(w, 400, msg)
+		return
+	}
+	schedule.ID = id
+
+	updated, err := h.svc.UpdateSchedule(schedule)
+	if err != nil {
+		sendAssetError(w, err)
+		return
+	}
+	util.SendData(w, 200, updated)
+}
+
+// DELETE /maintenance/schedules/{id}
+func (h *Handler) DeactivateSchedule(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, 400, "Invalid schedule ID")
+		return
+	}
+
+	if err := h.svc.DeactivateSchedule(id); err != nil {
+		sendAssetError(w, err)
+		return
+	}
+	util.SendData(w, 200, map[string]string{"message": "Schedule deactivated"})
+}
+
+// POST /maintenance/schedules/run (Generate due tickets now instead of waiting for the scheduler)
+func (h *Handler) RunSchedules(w http.ResponseWriter, r *http.Request) {
+	generated, err := h.svc.GeneratePreventiveTickets(domain.Today())
+	if err != nil {
+		util.SendError(w, 500, "Failed to generate tickets: "+err.Error())
+		return
+	}
+	util.SendData(w, 200, map[string]int{"generated": generated})
+}
+
+// sendAssetError maps asset and schedule errors to HTTP status codes
+func sendAssetError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrAssetNotFound), errors.Is(err, domain.ErrScheduleNotFound), errors.Is(err, domain.ErrRoomNotFound):
+		util.SendError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrInvalidSchedule):
+		util.SendError(w, http.StatusBadRequest, err.Error())
+	default:
+		util.SendError(w, 500, "Internal server error")
+	}
+}
@@ -0,0 +1,62 @@
+package housekeeping
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"oasis/backend/util"
+)
+
+// GET /housekeeping/rooms/{room}/cleaning-window
+// Guests only see the window of the room they are staying in
+func (h *Handler) GetCleaningWindow(w http.ResponseWriter, r *http.Request) {
+	window, err := h.svc.GetCleaningWindow(r.PathValue("room"), util.ActorFromRequest(r))
+	if err != nil {
+		sendStateError(w, err, "Failed to fetch cleaning window")
+		return
+	}
+	if window == nil {
+		util.SendData(w, 200, map[string]string{"message": "No preferred cleaning time"})
+		return
+	}
+	util.SendData(w, 200, window)
+}
+
+// PUT /housekeeping/rooms/{room}/cleaning-window
+// Payload: { "start": "14:00", "end": "17:00" }
+func (h *Handler) SetCleaningWindow(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Start string `json:"start"`
+		End   string `json:"end"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.SendError(w, 400, "Invalid JSON")
+		return
+	}
+
+	window, err := h.svc.SetCleaningWindow(r.PathValue("room"), req.Start, req.End, util.ActorFromRequest(r))
+	if err != nil {
+		sendStateError(w, err, "Failed to save cleaning window")
+		return
+	}
+	util.SendData(w, 200, window)
+}
+
+// DELETE /housekeeping/rooms/{room}/cleaning-window
+func (h *Handler) ClearCleaningWindow(w http.ResponseWriter, r *http.Request) {
+	if err := h.svc.ClearCleaningWindow(r.PathValue("room"), util.ActorFromRequest(r)); err != nil {
+		sendStateError(w, err, "Failed to clear cleaning window")
+		return
+	}
+	util.SendData(w, 200, map[string]string{"message": "Room can be serviced any time"})
+}
+
+// GET /housekeeping/dnd (Supervisors: welfare checks)
+func (h *Handler) GetDNDRooms(w http.ResponseWriter, r *http.Request) {
+	rooms, err := h.svc.GetDNDRooms()
+	if err != nil {
+		util.SendError(w, 500, "Failed to fetch DND rooms")
+		return
+	}
+	util.SendData(w, 200, rooms)
+}
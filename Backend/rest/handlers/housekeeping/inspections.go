@@ -0,0 +1,127 @@
+package housekeeping
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"oasis/backend/domain"
+	"oasis/backend/util"
+)
+
+// ReqInspection is the supervisor's completed checklist
+type ReqInspection struct {
+	Results []domain.InspectionResult `json:"results"`
+	Notes   string                    `json:"notes"`
+}
+
+// GET /housekeeping/checklists/{typeID}
+func (h *Handler) GetChecklist(w http.ResponseWriter, r *http.Request) {
+	typeID, err := strconv.Atoi(r.PathValue("typeID"))
+	if err != nil {
+		util.SendError(w, 400, "Invalid room type id")
+		return
+	}
+
+	items, err := h.svc.GetChecklist(typeID)
+	if err != nil {
+		util.SendError(w, 500, "Failed to fetch checklist")
+		return
+	}
+	util.SendData(w, 200, items)
+}
+
+// PUT /housekeeping/checklists/{typeID}
+// Payload: [ { "area": "BATHROOM", "description": "Towels restocked" }, ... ]
+func (h *Handler) SetChecklist(w http.ResponseWriter, r *http.Request) {
+	typeID, err := strconv.Atoi(r.PathValue("typeID"))
+	if err != nil {
+		util.SendError(w, 400, "Invalid room type id")
+		return
+	}
+
+	var items []domain.ChecklistItem
+	if err := json.NewDecoder(r.Body).Decode(&items); err != nil {
+		util.SendError(w, 400, "Invalid JSON")
+		return
+	}
+	for _, item := range items {
+		if item.Area == "" || item.Description == "" {
+			util.SendError(w, 400, "Every item needs an area and a description")
+			return
+		}
+	}
+
+	saved, err := h.svc.SetChecklist(typeID, items)
+	if err != nil {
+		util.SendError(w, 500, "Failed to save checklist")
+		return
+	}
+	util.SendData(w, 200, saved)
+}
+
+// GET /housekeeping/rooms/{room}/checklist
+func (h *Handler) GetRoomChecklist(w http.ResponseWriter, r *http.Request) {
+	items, err := h.svc.GetRoomChecklist(r.PathValue("room"))
+	if err != nil {
+		util.SendError(w, 500, "Failed to fetch checklist")
+		return
+	}
+	util.SendData(w, 200, items)
+}
+
+// POST /housekeeping/rooms/{room}/inspect
+func (h *Handler) SubmitInspection(w http.ResponseWriter, r *http.Request) {
+	var req ReqInspection
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.SendError(w, 400, "Invalid JSON")
+		return
+	}
+
+	inspection, err := h.svc.SubmitInspection(r.PathValue("room"), req.Results, req.Notes, util.ActorFromRequest(r))
+	if err != nil {
+		sendStateError(w, err, "Error saving inspection")
+		return
+	}
+	util.SendData(w, 201, inspection)
+}
+
+// GET /housekeeping/rooms/{room}/inspections
+func (h *Handler) GetInspections(w http.ResponseWriter, r *http.Request) {
+	inspections, err := h.svc.GetInspections(r.PathValue("room"))
+	if err != nil {
+		util.SendError(w, 500, "Failed to fetch inspections")
+		return
+	}
+	util.SendData(w, 200, inspections)
+}
+
+// GET /housekeeping/reports/pass-rate?from=2025-12-01&to=2025-12-31
+// Defaults to the last 30 days
+func (h *Handler) GetPassRateReport(w http.ResponseWriter, r *http.Request) {
+	to := time.Now()
+	from := to.AddDate(0, 0, -30)
+
+	var err error
+	if v := r.URL.Query().Get("from"); v != "" {
+		if from, err = time.Parse("2006-01-02", v); err != nil {
+			util.SendError(w, 400, "Invalid from date. Use YYYY-MM-DD")
+			return
+		}
+	}
+	if v := r.URL.Query().Get("to"); v != "" {
+		if to, err = time.Parse("2006-01-02", v); err != nil {
+			util.SendError(w, 400, "Invalid to date. Use YYYY-MM-DD")
+			return
+		}
+		to = to.AddDate(0, 0, 1) // Include the whole last day
+	}
+
+	report, err := h.svc.GetPassRateReport(from, to)
+	if err != nil {
+		util.SendError(w, 500, "Failed to build pass-rate report")
+		return
+	}
+	util.SendData(w, 200, report)
+}
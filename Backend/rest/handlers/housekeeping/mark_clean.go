@@ -1,19 +1,39 @@
 package housekeeping
 
 import (
+	"errors"
 	"net/http"
 
+	"oasis/backend/domain"
 	"oasis/backend/util"
 )
 
 // PATCH /housekeeping/rooms/{room}/clean
 func (h *Handler) MarkClean(w http.ResponseWriter, r *http.Request) {
 	roomNumber := r.PathValue("room")
-	err := h.svc.UpdateRoomStatus(roomNumber, "CLEAN")
+	err := h.svc.UpdateHousekeepingStatus(roomNumber, domain.HousekeepingClean, util.ActorFromRequest(r), "Marked clean")
 	if err != nil {
-		util.SendError(w, 500, "Error updating status")
+		sendStateError(w, err, "Error updating status")
 		return
 	}
 	util.SendData(w, 200, "Room Marked Clean")
 }
 
+// sendStateError maps state machine errors to 404/409 and anything else to 500
+func sendStateError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, domain.ErrRoomNotFound):
+		util.SendError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrGuestRoomStatus):
+		util.SendError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, domain.ErrIllegalTransition), errors.Is(err, domain.ErrInspectionRequired),
+		errors.Is(err, domain.ErrRoomDND), errors.Is(err, domain.ErrBeforeCleaningWindow),
+		errors.Is(err, domain.ErrGuestNotCheckedIn):
+		util.SendError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, domain.ErrChecklistIncomplete), errors.Is(err, domain.ErrFailNoteRequired),
+		errors.Is(err, domain.ErrInvalidCleaningWindow):
+		util.SendError(w, http.StatusBadRequest, err.Error())
+	default:
+		util.SendError(w, 500, fallback)
+	}
+}
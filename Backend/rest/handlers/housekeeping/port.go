@@ -2,6 +2,7 @@ package housekeeping
 
 import (
 	"net/http"
+	"time"
 
 	"oasis/backend/domain"
 )
@@ -9,21 +10,74 @@ import (
 // Service defines the methods the Handler needs from the Business Logic layer.
 type Service interface {
 	// Guest Actions
-	RequestCleaning(roomNumber string) error
-	RequestAmenity(guestID int, roomNumber, item string, qty int) error
-	ReportIssue(roomNumber, issueType, desc string) error
+	RequestCleaning(roomNumber string, actor domain.Actor) error
+	RequestAmenity(guestID int, roomNumber, code string, qty int) (*domain.AmenityRequest, error)
+	ReportIssue(roomNumber, issueType, desc string, photos []domain.Upload, actor domain.Actor) (*domain.MaintenanceTicket, error)
+	AttachTicketPhoto(ticketID int, photo domain.Upload, actor domain.Actor) (*domain.Attachment, error)
 
 	// Staff Actions
 	GetLiveStatus() ([]domain.RoomsStatus, error)
-	UpdateRoomStatus(roomNumber, status string) error
+	UpdateHousekeepingStatus(roomNumber string, status domain.HousekeepingStatus, actor domain.Actor, reason string) error
+	TransitionRoom(roomNumber string, to domain.RoomState, actor domain.Actor, reason string) error
+	GetRoomHistory(roomNumber string) ([]domain.RoomStatusChange, error)
 	GetAmenityRequests() ([]domain.AmenityRequest, error)
 	GetMaintenanceTickets() ([]domain.MaintenanceTicket, error)
-	MarkAmenityDelivered(id int) error
-	ResolveTicket(id int) error
+	MarkAmenityDelivered(id int, actor domain.Actor) (*domain.AmenityRequest, error)
+
+	// Maintenance
+	GetMyTickets(engineerID int) ([]domain.MaintenanceTicket, error)
+	GetTicket(id int) (*domain.MaintenanceTicket, error)
+	AssignTicket(id, engineerID int) (*domain.MaintenanceTicket, error)
+	UpdateTicketStatus(id int, status domain.TicketStatus, reason string) (*domain.MaintenanceTicket, error)
+	ResolveTicket(id int, notes string, parts []domain.TicketPart, actor domain.Actor) (*domain.MaintenanceTicket, error)
+
+	// Task Board
+	GenerateTasks(date time.Time) (int, error)
+	GetTasks(date time.Time) ([]domain.HousekeepingTask, error)
+	GetMyTasks(staffID int, date time.Time) ([]domain.HousekeepingTask, error)
+	StartTask(taskID int, actor domain.Actor) (*domain.HousekeepingTask, error)
+	FinishTask(taskID int, actor domain.Actor) (*domain.HousekeepingTask, error)
+	StartShift(staffID int) error
+	EndShift(staffID int) error
+	GetWorkload(date time.Time) ([]domain.AttendantWorkload, error)
+
+	// Inspections
+	GetChecklist(roomTypeID int) ([]domain.ChecklistItem, error)
+	GetRoomChecklist(roomNumber string) ([]domain.ChecklistItem, error)
+	SetChecklist(roomTypeID int, items []domain.ChecklistItem) ([]domain.ChecklistItem, error)
+	SubmitInspection(roomNumber string, results []domain.InspectionResult, notes string, actor domain.Actor) (*domain.RoomInspection, error)
+	GetInspections(roomNumber string) ([]domain.RoomInspection, error)
+	GetPassRateReport(from, to time.Time) ([]domain.AttendantPassRate, error)
+
+	// Preventive Maintenance
+	CreateAsset(asset domain.Asset) (*domain.Asset, error)
+	UpdateAsset(asset domain.Asset) (*domain.Asset, error)
+	GetAssets() ([]domain.Asset, error)
+	GetAsset(id int) (*domain.AssetDetail, error)
+	CreateSchedule(schedule domain.MaintenanceSchedule) (*domain.MaintenanceSchedule, error)
+	UpdateSchedule(schedule domain.MaintenanceSchedule) (*domain.MaintenanceSchedule, error)
+	DeactivateSchedule(id int) error
+	GetSchedules() ([]domain.MaintenanceSchedule, error)
+	GeneratePreventiveTickets(today time.Time) (int, error)
+
+	// Do Not Disturb & Cleaning Windows
+	GetCleaningWindow(roomNumber string, actor domain.Actor) (*domain.CleaningWindow, error)
+	SetCleaningWindow(roomNumber, start, end string, actor domain.Actor) (*domain.CleaningWindow, error)
+	ClearCleaningWindow(roomNumber string, actor domain.Actor) error
+	GetDNDRooms() ([]domain.DNDRoom, error)
+
+	// Amenity Catalog & Stock
+	GetAmenityCatalog(includeInactive bool) ([]domain.AmenityItem, error)
+	CreateAmenityItem(item domain.AmenityItem) (*domain.AmenityItem, error)
+	UpdateAmenityItem(item domain.AmenityItem) (*domain.AmenityItem, error)
+	AdjustStock(itemID, change int, reason, note string, actor domain.Actor) (*domain.AmenityItem, error)
+	GetStockLedger(itemID int) ([]domain.StockMovement, error)
+	GetLowStockItems() ([]domain.AmenityItem, error)
 }
 
 // WebSocketHub defines the methods the Handler needs for WebSocket functionality.
 type WebSocketHub interface {
 	ServeWs(w http.ResponseWriter, r *http.Request)
+	ServeGuestWs(w http.ResponseWriter, r *http.Request, guestID int)
 }
 
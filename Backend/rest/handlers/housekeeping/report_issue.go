@@ -2,28 +2,86 @@ package housekeeping
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
 
+	"oasis/backend/domain"
 	"oasis/backend/util"
 )
 
+// maxTicketPhotos is how many photos can be sent with one request
+const maxTicketPhotos = 5
+
 // POST /housekeeping/ticket
+// JSON, or multipart/form-data with room_number, issue_type, description and up to 5 "photos" files
 func (h *Handler) ReportIssue(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		RoomNumber  string `json:"room_number"`
 		IssueType   string `json:"issue_type"`
 		Description string `json:"description"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	var photos []domain.Upload
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		uploads, closeUploads, err := util.ParseUploads(w, r, "photos", maxTicketPhotos)
+		if err != nil {
+			util.SendError(w, 400, err.Error())
+			return
+		}
+		defer closeUploads()
+
+		req.RoomNumber = r.FormValue("room_number")
+		req.IssueType = r.FormValue("issue_type")
+		req.Description = r.FormValue("description")
+		photos = uploads
+	} else if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		util.SendError(w, 400, "Invalid JSON")
 		return
 	}
 
-	err := h.svc.ReportIssue(req.RoomNumber, req.IssueType, req.Description)
+	// Priority and SLA deadline are derived from the issue type
+	ticket, err := h.svc.ReportIssue(req.RoomNumber, req.IssueType, req.Description, photos, util.ActorFromRequest(r))
+	if errors.Is(err, domain.ErrRoomNotFound) {
+		util.SendError(w, 404, err.Error())
+		return
+	}
 	if err != nil {
 		util.SendError(w, 500, "Failed to report issue")
 		return
 	}
-	util.SendData(w, 200, "Issue Reported")
+	util.SendData(w, 200, ticket)
 }
 
+// POST /housekeeping/tickets/{id}/photos (multipart/form-data, "photos" files)
+func (h *Handler) AttachTicketPhotos(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, 400, "Invalid ticket ID")
+		return
+	}
+
+	uploads, closeUploads, err := util.ParseUploads(w, r, "photos", maxTicketPhotos)
+	if err != nil {
+		util.SendError(w, 400, err.Error())
+		return
+	}
+	defer closeUploads()
+	if len(uploads) == 0 {
+		util.SendError(w, 400, "At least one photo is required")
+		return
+	}
+
+	actor := util.ActorFromRequest(r)
+	attachments := []domain.Attachment{}
+	for _, photo := range uploads {
+		att, err := h.svc.AttachTicketPhoto(id, photo, actor)
+		if err != nil {
+			sendTicketError(w, err)
+			return
+		}
+		attachments = append(attachments, *att)
+	}
+	util.SendData(w, 201, attachments)
+}
@@ -2,18 +2,26 @@ package housekeeping
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
+	"oasis/backend/domain"
 	"oasis/backend/util"
 )
 
-// POST /housekeeping/amenity
-// Payload: { "guest_id": 1, "room_number": "101", "amenity": "towels", "quantity": 2 }
+// POST /housekeeping/amenity (Guests)
+// Payload: { "room_number": "101", "amenity": "TOWEL", "quantity": 2 }
+// The guest comes from the token, the per-stay limit is counted against them
 func (h *Handler) RequestAmenity(w http.ResponseWriter, r *http.Request) {
+	actor := util.ActorFromRequest(r)
+	if actor.Role != domain.ActorRoleGuest {
+		util.SendError(w, http.StatusForbidden, "Guest token required")
+		return
+	}
+
 	var req struct {
-		GuestID    int    `json:"guest_id"`
 		RoomNumber string `json:"room_number"`
-		Amenity    string `json:"amenity"`
+		Amenity    string `json:"amenity"` // Catalog code or name
 		Quantity   int    `json:"quantity"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -21,11 +29,27 @@ func (h *Handler) RequestAmenity(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := h.svc.RequestAmenity(req.GuestID, req.RoomNumber, req.Amenity, req.Quantity)
+	amenity, err := h.svc.RequestAmenity(actor.ID, req.RoomNumber, req.Amenity, req.Quantity)
 	if err != nil {
-		util.SendError(w, 500, "Failed to request amenity")
+		sendAmenityError(w, err, "Failed to request amenity")
 		return
 	}
-	util.SendData(w, 200, "Amenity Requested")
+	util.SendData(w, 201, amenity)
 }
 
+// sendAmenityError maps catalog and stock errors to HTTP codes
+func sendAmenityError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, domain.ErrAmenityNotFound), errors.Is(err, domain.ErrAmenityRequestNotFound),
+		errors.Is(err, domain.ErrRoomNotFound):
+		util.SendError(w, 404, err.Error())
+	case errors.Is(err, domain.ErrAmenityLimitExceeded), errors.Is(err, domain.ErrInvalidStockChange):
+		util.SendError(w, 400, err.Error())
+	case errors.Is(err, domain.ErrAmenityOutOfStock),
+		errors.Is(err, domain.ErrAmenityAlreadyHandled),
+		errors.Is(err, domain.ErrAmenityExists):
+		util.SendError(w, 409, err.Error())
+	default:
+		util.SendError(w, 500, fallback)
+	}
+}
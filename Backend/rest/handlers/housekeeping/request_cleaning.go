@@ -4,11 +4,13 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"oasis/backend/domain"
 	"oasis/backend/util"
 )
 
 // POST /housekeeping/clean
-// Payload: { "room_number": "101", "status": "REQUESTED_CLEANING" | "DND" | "CLEAN" }
+// Payload: { "room_number": "101", "status": "REQUESTED_CLEANING" | "DND" }
+// Guests can only change the room they are staying in, and only to these two statuses
 func (h *Handler) RequestCleaning(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		RoomNumber string `json:"room_number"`
@@ -20,16 +22,15 @@ func (h *Handler) RequestCleaning(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Default to REQUESTED_CLEANING if no status provided
-	status := req.Status
+	status := domain.HousekeepingStatus(req.Status)
 	if status == "" {
-		status = "REQUESTED_CLEANING"
+		status = domain.HousekeepingRequestedCleaning
 	}
 
-	err := h.svc.UpdateRoomStatus(req.RoomNumber, status)
+	err := h.svc.UpdateHousekeepingStatus(req.RoomNumber, status, util.ActorFromRequest(r), "Guest request")
 	if err != nil {
-		util.SendError(w, 500, "Failed to update room status")
+		sendStateError(w, err, "Failed to update room status")
 		return
 	}
 	util.SendData(w, 200, "Room status updated")
 }
-
@@ -0,0 +1,40 @@
+package housekeeping
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"oasis/backend/domain"
+	"oasis/backend/util"
+)
+
+// PATCH /housekeeping/rooms/{room}/state
+// Payload: { "status": "VACANT", "housekeeping_status": "DIRTY", "reason": "Guest spilled wine" }
+// INSPECTED is rejected here, it is only set by POST /housekeeping/rooms/{room}/inspect
+func (h *Handler) TransitionRoom(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		domain.RoomState
+		Reason string `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.SendError(w, 400, "Invalid JSON")
+		return
+	}
+
+	err := h.svc.TransitionRoom(r.PathValue("room"), req.RoomState, util.ActorFromRequest(r), req.Reason)
+	if err != nil {
+		sendStateError(w, err, "Error updating status")
+		return
+	}
+	util.SendData(w, 200, req.RoomState)
+}
+
+// GET /housekeeping/rooms/{room}/history
+func (h *Handler) GetRoomHistory(w http.ResponseWriter, r *http.Request) {
+	history, err := h.svc.GetRoomHistory(r.PathValue("room"))
+	if err != nil {
+		util.SendError(w, 500, "Failed to fetch room history")
+		return
+	}
+	util.SendData(w, 200, history)
+}
@@ -2,24 +2,84 @@ package housekeeping
 
 import (
 	"net/http"
+
+	"oasis/backend/domain"
 	middleware "oasis/backend/rest/middlewares"
 )
 
 func (h *Handler) RegisterRoutes(mux *http.ServeMux, manager *middleware.Manager) {
-	// 1. The WebSocket Endpoint (Public or Token-Based logic inside)
-	mux.Handle("GET /ws", http.HandlerFunc(h.ServeWebSocket))
+	// 1. The WebSocket Endpoints (token in ?token=, browsers cannot set headers on the handshake)
+	mux.Handle("GET /ws", manager.With(http.HandlerFunc(h.ServeWebSocket), h.middlewares.AuthinticateJWT, h.middlewares.TokenFromQuery))
+	mux.Handle("GET /ws/guest", manager.With(http.HandlerFunc(h.ServeGuestWebSocket), h.middlewares.AuthinticateJWT, h.middlewares.TokenFromQuery))
 
 	// 2. Guest Actions
-	mux.Handle("POST /housekeeping/clean", manager.With(http.HandlerFunc(h.RequestCleaning)))
-	mux.Handle("POST /housekeeping/amenity", manager.With(http.HandlerFunc(h.RequestAmenity)))
-	mux.Handle("POST /housekeeping/ticket", manager.With(http.HandlerFunc(h.ReportIssue)))
+	mux.Handle("POST /housekeeping/clean", manager.With(http.HandlerFunc(h.RequestCleaning), h.middlewares.AuthinticateJWT))
+	mux.Handle("POST /housekeeping/amenity", manager.With(http.HandlerFunc(h.RequestAmenity), h.middlewares.AuthinticateJWT))
+	mux.Handle("POST /housekeeping/ticket", manager.With(http.HandlerFunc(h.ReportIssue), h.middlewares.AuthinticateJWT))
+	mux.Handle("GET /housekeeping/rooms/{room}/cleaning-window", manager.With(http.HandlerFunc(h.GetCleaningWindow), h.middlewares.AuthinticateJWT))
+	mux.Handle("PUT /housekeeping/rooms/{room}/cleaning-window", manager.With(http.HandlerFunc(h.SetCleaningWindow), h.middlewares.AuthinticateJWT))
+	mux.Handle("DELETE /housekeeping/rooms/{room}/cleaning-window", manager.With(http.HandlerFunc(h.ClearCleaningWindow), h.middlewares.AuthinticateJWT))
 
-	// 3. Staff Actions
+	// 3. Staff Actions (room state is worked by housekeeping and the front desk)
+	roomStaff := h.middlewares.AuthorizeRoles(domain.StaffRoleHousekeeping, domain.StaffRoleSupervisor, domain.StaffRoleReceptionist, domain.StaffRoleManager, domain.StaffRoleAdmin)
 	mux.Handle("GET /housekeeping/live", manager.With(http.HandlerFunc(h.GetLiveStatus)))
 	mux.Handle("GET /housekeeping/amenities", manager.With(http.HandlerFunc(h.GetAmenityRequests)))
 	mux.Handle("GET /housekeeping/tickets", manager.With(http.HandlerFunc(h.GetMaintenanceTickets)))
-	mux.Handle("PATCH /housekeeping/rooms/{room}/clean", manager.With(http.HandlerFunc(h.MarkClean)))
-	mux.Handle("PATCH /housekeeping/amenities/{id}/deliver", manager.With(http.HandlerFunc(h.MarkAmenityDelivered)))
-	mux.Handle("PATCH /housekeeping/tickets/{id}/resolve", manager.With(http.HandlerFunc(h.ResolveTicket)))
+	mux.Handle("PATCH /housekeeping/rooms/{room}/clean", manager.With(http.HandlerFunc(h.MarkClean), roomStaff, h.middlewares.AuthinticateJWT))
+	mux.Handle("PATCH /housekeeping/rooms/{room}/state", manager.With(http.HandlerFunc(h.TransitionRoom), roomStaff, h.middlewares.AuthinticateJWT))
+	mux.Handle("GET /housekeeping/rooms/{room}/history", manager.With(http.HandlerFunc(h.GetRoomHistory), roomStaff, h.middlewares.AuthinticateJWT))
+	mux.Handle("PATCH /housekeeping/amenities/{id}/deliver", manager.With(http.HandlerFunc(h.MarkAmenityDelivered), roomStaff, h.middlewares.AuthinticateJWT))
+
+	// 4. Task Board (Attendants work their own list, supervisors see everyone)
+	attendants := h.middlewares.AuthorizeRoles(domain.StaffRoleHousekeeping, domain.StaffRoleSupervisor, domain.StaffRoleManager, domain.StaffRoleAdmin)
+	supervisors := h.middlewares.AuthorizeRoles(domain.StaffRoleSupervisor, domain.StaffRoleManager, domain.StaffRoleAdmin)
+	mux.Handle("GET /housekeeping/my-tasks", manager.With(http.HandlerFunc(h.GetMyTasks), attendants, h.middlewares.AuthinticateJWT))
+	mux.Handle("PATCH /housekeeping/tasks/{id}/start", manager.With(http.HandlerFunc(h.StartTask), attendants, h.middlewares.AuthinticateJWT))
+	mux.Handle("PATCH /housekeeping/tasks/{id}/finish", manager.With(http.HandlerFunc(h.FinishTask), attendants, h.middlewares.AuthinticateJWT))
+	mux.Handle("POST /housekeeping/shift/start", manager.With(http.HandlerFunc(h.StartShift), attendants, h.middlewares.AuthinticateJWT))
+	mux.Handle("POST /housekeeping/shift/end", manager.With(http.HandlerFunc(h.EndShift), attendants, h.middlewares.AuthinticateJWT))
+	mux.Handle("GET /housekeeping/tasks", manager.With(http.HandlerFunc(h.GetTasks), supervisors, h.middlewares.AuthinticateJWT))
+	mux.Handle("POST /housekeeping/tasks/generate", manager.With(http.HandlerFunc(h.GenerateTasks), supervisors, h.middlewares.AuthinticateJWT))
+	mux.Handle("GET /housekeeping/workload", manager.With(http.HandlerFunc(h.GetWorkload), supervisors, h.middlewares.AuthinticateJWT))
+	mux.Handle("GET /housekeeping/dnd", manager.With(http.HandlerFunc(h.GetDNDRooms), supervisors, h.middlewares.AuthinticateJWT))
+
+	// 5. Inspections (Supervisors)
+	mux.Handle("GET /housekeeping/checklists/{typeID}", manager.With(http.HandlerFunc(h.GetChecklist), supervisors, h.middlewares.AuthinticateJWT))
+	mux.Handle("PUT /housekeeping/checklists/{typeID}", manager.With(http.HandlerFunc(h.SetChecklist), supervisors, h.middlewares.AuthinticateJWT))
+	mux.Handle("GET /housekeeping/rooms/{room}/checklist", manager.With(http.HandlerFunc(h.GetRoomChecklist), supervisors, h.middlewares.AuthinticateJWT))
+	mux.Handle("POST /housekeeping/rooms/{room}/inspect", manager.With(http.HandlerFunc(h.SubmitInspection), supervisors, h.middlewares.AuthinticateJWT))
+	mux.Handle("GET /housekeeping/rooms/{room}/inspections", manager.With(http.HandlerFunc(h.GetInspections), supervisors, h.middlewares.AuthinticateJWT))
+	mux.Handle("GET /housekeeping/reports/pass-rate", manager.With(http.HandlerFunc(h.GetPassRateReport), supervisors, h.middlewares.AuthinticateJWT))
+
+	// 6. Maintenance (Engineers work their tickets, managers dispatch them)
+	engineers := h.middlewares.AuthorizeRoles(domain.StaffRoleEngineer, domain.StaffRoleManager, domain.StaffRoleAdmin)
+	dispatchers := h.middlewares.AuthorizeRoles(domain.StaffRoleManager, domain.StaffRoleAdmin, domain.StaffRoleReceptionist)
+	ticketStaff := h.middlewares.AuthorizeRoles(domain.StaffRoleEngineer, domain.StaffRoleManager, domain.StaffRoleAdmin, domain.StaffRoleReceptionist) // Tickets carry signed photo links
+	mux.Handle("GET /housekeeping/my-tickets", manager.With(http.HandlerFunc(h.GetMyTickets), engineers, h.middlewares.AuthinticateJWT))
+	mux.Handle("GET /housekeeping/tickets/{id}", manager.With(http.HandlerFunc(h.GetTicket), ticketStaff, h.middlewares.AuthinticateJWT))
+	mux.Handle("POST /housekeeping/tickets/{id}/photos", manager.With(http.HandlerFunc(h.AttachTicketPhotos), h.middlewares.AuthinticateJWT))
+	mux.Handle("PATCH /housekeeping/tickets/{id}/assign", manager.With(http.HandlerFunc(h.AssignTicket), dispatchers, h.middlewares.AuthinticateJWT))
+	mux.Handle("PATCH /housekeeping/tickets/{id}/status", manager.With(http.HandlerFunc(h.UpdateTicketStatus), engineers, h.middlewares.AuthinticateJWT))
+	mux.Handle("PATCH /housekeeping/tickets/{id}/resolve", manager.With(http.HandlerFunc(h.ResolveTicket), engineers, h.middlewares.AuthinticateJWT))
+
+	// 7. Preventive Maintenance (Asset register + recurring schedules)
+	mux.Handle("POST /maintenance/assets", manager.With(http.HandlerFunc(h.CreateAsset), engineers, h.middlewares.AuthinticateJWT))
+	mux.Handle("GET /maintenance/assets", manager.With(http.HandlerFunc(h.GetAssets), engineers, h.middlewares.AuthinticateJWT))
+	mux.Handle("GET /maintenance/assets/{id}", manager.With(http.HandlerFunc(h.GetAsset), engineers, h.middlewares.AuthinticateJWT))
+	mux.Handle("PUT /maintenance/assets/{id}", manager.With(http.HandlerFunc(h.UpdateAsset), engineers, h.middlewares.AuthinticateJWT))
+	mux.Handle("POST /maintenance/schedules", manager.With(http.HandlerFunc(h.CreateSchedule), engineers, h.middlewares.AuthinticateJWT))
+	mux.Handle("GET /maintenance/schedules", manager.With(http.HandlerFunc(h.GetSchedules), engineers, h.middlewares.AuthinticateJWT))
+	mux.Handle("PUT /maintenance/schedules/{id}", manager.With(http.HandlerFunc(h.UpdateSchedule), engineers, h.middlewares.AuthinticateJWT))
+	mux.Handle("DELETE /maintenance/schedules/{id}", manager.With(http.HandlerFunc(h.DeactivateSchedule), engineers, h.middlewares.AuthinticateJWT))
+	mux.Handle("POST /maintenance/schedules/run", manager.With(http.HandlerFunc(h.RunSchedules), engineers, h.middlewares.AuthinticateJWT))
+
+	// 8. Amenity Catalog & Linen Stock
+	managers := h.middlewares.AuthorizeRoles(domain.StaffRoleManager, domain.StaffRoleAdmin)
+	mux.Handle("GET /housekeeping/amenity-catalog", manager.With(http.HandlerFunc(h.GetAmenityCatalog)))
+	mux.Handle("POST /housekeeping/amenity-catalog", manager.With(http.HandlerFunc(h.CreateAmenityItem), managers, h.middlewares.AuthinticateJWT))
+	mux.Handle("PUT /housekeeping/amenity-catalog/{id}", manager.With(http.HandlerFunc(h.UpdateAmenityItem), managers, h.middlewares.AuthinticateJWT))
+	mux.Handle("POST /housekeeping/amenity-catalog/{id}/stock", manager.With(http.HandlerFunc(h.AdjustStock), supervisors, h.middlewares.AuthinticateJWT))
+	mux.Handle("GET /housekeeping/amenity-catalog/{id}/ledger", manager.With(http.HandlerFunc(h.GetStockLedger), supervisors, h.middlewares.AuthinticateJWT))
+	mux.Handle("GET /housekeeping/amenity-stock/low", manager.With(http.HandlerFunc(h.GetLowStockItems), supervisors, h.middlewares.AuthinticateJWT))
 }
 
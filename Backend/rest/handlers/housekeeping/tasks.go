@@ -0,0 +1,147 @@
+package housekeeping
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"oasis/backend/domain"
+	"oasis/backend/util"
+)
+
+// boardDate reads ?date=YYYY-MM-DD, defaulting to today
+func boardDate(r *http.Request) (time.Time, error) {
+	if d := r.URL.Query().Get("date"); d != "" {
+		return time.Parse("2006-01-02", d)
+	}
+	return domain.Today(), nil
+}
+
+// GET /housekeeping/my-tasks
+func (h *Handler) GetMyTasks(w http.ResponseWriter, r *http.Request) {
+	date, err := boardDate(r)
+	if err != nil {
+		util.SendError(w, 400, "Invalid date format. Use YYYY-MM-DD")
+		return
+	}
+
+	tasks, err := h.svc.GetMyTasks(util.ActorFromRequest(r).ID, date)
+	if err != nil {
+		util.SendError(w, 500, "Failed to fetch tasks")
+		return
+	}
+	util.SendData(w, 200, tasks)
+}
+
+// GET /housekeeping/tasks
+func (h *Handler) GetTasks(w http.ResponseWriter, r *http.Request) {
+	date, err := boardDate(r)
+	if err != nil {
+		util.SendError(w, 400, "Invalid date format. Use YYYY-MM-DD")
+		return
+	}
+
+	tasks, err := h.svc.GetTasks(date)
+	if err != nil {
+		util.SendError(w, 500, "Failed to fetch tasks")
+		return
+	}
+	util.SendData(w, 200, tasks)
+}
+
+// POST /housekeeping/tasks/generate
+func (h *Handler) GenerateTasks(w http.ResponseWriter, r *http.Request) {
+	date, err := boardDate(r)
+	if err != nil {
+		util.SendError(w, 400, "Invalid date format. Use YYYY-MM-DD")
+		return
+	}
+
+	created, err := h.svc.GenerateTasks(date)
+	if err != nil {
+		util.SendError(w, 500, "Failed to generate tasks")
+		return
+	}
+	util.SendData(w, 200, map[string]int{"created": created})
+}
+
+// PATCH /housekeeping/tasks/{id}/start
+func (h *Handler) StartTask(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, 400, "Invalid task id")
+		return
+	}
+
+	task, err := h.svc.StartTask(id, util.ActorFromRequest(r))
+	if err != nil {
+		sendTaskError(w, err)
+		return
+	}
+	util.SendData(w, 200, task)
+}
+
+// PATCH /housekeeping/tasks/{id}/finish
+func (h *Handler) FinishTask(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, 400, "Invalid task id")
+		return
+	}
+
+	task, err := h.svc.FinishTask(id, util.ActorFromRequest(r))
+	if err != nil {
+		sendTaskError(w, err)
+		return
+	}
+	util.SendData(w, 200, task)
+}
+
+// POST /housekeeping/shift/start
+func (h *Handler) StartShift(w http.ResponseWriter, r *http.Request) {
+	if err := h.svc.StartShift(util.ActorFromRequest(r).ID); err != nil {
+		sendTaskError(w, err)
+		return
+	}
+	util.SendData(w, 200, "Shift started")
+}
+
+// POST /housekeeping/shift/end
+func (h *Handler) EndShift(w http.ResponseWriter, r *http.Request) {
+	if err := h.svc.EndShift(util.ActorFromRequest(r).ID); err != nil {
+		sendTaskError(w, err)
+		return
+	}
+	util.SendData(w, 200, "Shift ended")
+}
+
+// GET /housekeeping/workload
+func (h *Handler) GetWorkload(w http.ResponseWriter, r *http.Request) {
+	date, err := boardDate(r)
+	if err != nil {
+		util.SendError(w, 400, "Invalid date format. Use YYYY-MM-DD")
+		return
+	}
+
+	board, err := h.svc.GetWorkload(date)
+	if err != nil {
+		util.SendError(w, 500, "Failed to fetch workload")
+		return
+	}
+	util.SendData(w, 200, board)
+}
+
+// sendTaskError maps task errors to HTTP codes, falling back to the room state errors
+func sendTaskError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrTaskNotFound), errors.Is(err, domain.ErrStaffNotFound):
+		util.SendError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrTaskNotYours):
+		util.SendError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, domain.ErrTaskWrongStatus):
+		util.SendError(w, http.StatusConflict, err.Error())
+	default:
+		sendStateError(w, err, "Error updating task")
+	}
+}
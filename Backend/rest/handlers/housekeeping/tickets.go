@@ -0,0 +1,106 @@
+package housekeeping
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"oasis/backend/domain"
+	"oasis/backend/util"
+)
+
+// GET /housekeeping/tickets/{id}
+func (h *Handler) GetTicket(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, 400, "Invalid ticket ID")
+		return
+	}
+
+	ticket, err := h.svc.GetTicket(id)
+	if err != nil {
+		sendTicketError(w, err)
+		return
+	}
+	util.SendData(w, 200, ticket)
+}
+
+// GET /housekeeping/my-tickets (Engineer's own queue)
+func (h *Handler) GetMyTickets(w http.ResponseWriter, r *http.Request) {
+	tickets, err := h.svc.GetMyTickets(util.ActorFromRequest(r).ID)
+	if err != nil {
+		util.SendError(w, 500, "Failed to fetch maintenance tickets")
+		return
+	}
+	util.SendData(w, 200, tickets)
+}
+
+// PATCH /housekeeping/tickets/{id}/assign
+// Payload: { "engineer_id": 7 }
+func (h *Handler) AssignTicket(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, 400, "Invalid ticket ID")
+		return
+	}
+
+	var req struct {
+		EngineerID int `json:"engineer_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.SendError(w, 400, "Invalid JSON")
+		return
+	}
+
+	ticket, err := h.svc.AssignTicket(id, req.EngineerID)
+	if err != nil {
+		sendTicketError(w, err)
+		return
+	}
+	util.SendData(w, 200, ticket)
+}
+
+// PATCH /housekeeping/tickets/{id}/status
+// Payload: { "status": "ON_HOLD", "reason": "Waiting for compressor" }
+func (h *Handler) UpdateTicketStatus(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, 400, "Invalid ticket ID")
+		return
+	}
+
+	var req struct {
+		Status domain.TicketStatus `json:"status"`
+		Reason string              `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.SendError(w, 400, "Invalid JSON")
+		return
+	}
+
+	ticket, err := h.svc.UpdateTicketStatus(id, req.Status, req.Reason)
+	if err != nil {
+		sendTicketError(w, err)
+		return
+	}
+	util.SendData(w, 200, ticket)
+}
+
+// sendTicketError maps maintenance errors to HTTP status codes
+func sendTicketError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrTicketNotFound), errors.Is(err, domain.ErrStaffNotFound):
+		util.SendError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrIllegalTicketTransition):
+		util.SendError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, domain.ErrNotAnEngineer), errors.Is(err, domain.ErrResolutionRequired):
+		util.SendError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, domain.ErrAttachmentTooLarge):
+		util.SendError(w, http.StatusRequestEntityTooLarge, err.Error())
+	case errors.Is(err, domain.ErrUnsupportedMediaType):
+		util.SendError(w, http.StatusUnsupportedMediaType, err.Error())
+	default:
+		util.SendError(w, 500, "Failed to update ticket")
+	}
+}
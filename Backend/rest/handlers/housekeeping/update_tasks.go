@@ -1,9 +1,11 @@
 package housekeeping
 
 import (
+	"encoding/json"
 	"net/http"
 	"strconv"
 
+	"oasis/backend/domain"
 	"oasis/backend/util"
 )
 
@@ -16,16 +18,17 @@ func (h *Handler) MarkAmenityDelivered(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.svc.MarkAmenityDelivered(id)
+	amenity, err := h.svc.MarkAmenityDelivered(id, util.ActorFromRequest(r))
 	if err != nil {
-		util.SendError(w, 500, "Failed to mark amenity as delivered")
+		sendAmenityError(w, err, "Failed to mark amenity as delivered")
 		return
 	}
 
-	util.SendData(w, 200, map[string]string{"message": "Amenity marked as delivered"})
+	util.SendData(w, 200, amenity)
 }
 
 // PATCH /housekeeping/tickets/{id}/resolve
+// Payload: { "resolution_notes": "Replaced valve", "parts_used": [ { "part_name": "Valve", "quantity": 1, "unit_cost": 12.5 } ] }
 func (h *Handler) ResolveTicket(w http.ResponseWriter, r *http.Request) {
 	idStr := r.PathValue("id")
 	id, err := strconv.Atoi(idStr)
@@ -34,12 +37,27 @@ func (h *Handler) ResolveTicket(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.svc.ResolveTicket(id)
+	var req struct {
+		ResolutionNotes string              `json:"resolution_notes"`
+		PartsUsed       []domain.TicketPart `json:"parts_used"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.SendError(w, 400, "Invalid JSON")
+		return
+	}
+	for _, part := range req.PartsUsed {
+		if part.PartName == "" || part.Quantity < 1 || part.UnitCost < 0 {
+			util.SendError(w, 400, "Each part needs a name, a positive quantity and a non-negative cost")
+			return
+		}
+	}
+
+	ticket, err := h.svc.ResolveTicket(id, req.ResolutionNotes, req.PartsUsed, util.ActorFromRequest(r))
 	if err != nil {
-		util.SendError(w, 500, "Failed to resolve ticket")
+		sendTicketError(w, err)
 		return
 	}
 
-	util.SendData(w, 200, map[string]string{"message": "Ticket resolved"})
+	util.SendData(w, 200, ticket)
 }
 
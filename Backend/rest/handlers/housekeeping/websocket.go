@@ -2,11 +2,29 @@ package housekeeping
 
 import (
 	"net/http"
+
+	"oasis/backend/domain"
+	"oasis/backend/util"
 )
 
-// GET /ws
-// The Frontend calls this to connect: new WebSocket("ws://localhost:8080/ws")
+// GET /ws?token=...
+// Staff feed (kitchen tickets, room board, ...): new WebSocket("ws://localhost:8080/ws?token=...")
 func (h *Handler) ServeWebSocket(w http.ResponseWriter, r *http.Request) {
+	if util.ActorFromRequest(r).Role == domain.ActorRoleGuest {
+		util.SendError(w, 403, "Staff token required")
+		return
+	}
 	h.hub.ServeWs(w, r)
 }
 
+
+// GET /ws/guest?token=...
+// Guests only receive updates about their own requests (laundry, orders)
+func (h *Handler) ServeGuestWebSocket(w http.ResponseWriter, r *http.Request) {
+	actor := util.ActorFromRequest(r)
+	if actor.Role != domain.ActorRoleGuest || actor.ID == 0 {
+		util.SendError(w, 403, "Guest token required")
+		return
+	}
+	h.hub.ServeGuestWs(w, r, actor.ID)
+}
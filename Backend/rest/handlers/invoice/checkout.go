@@ -1,8 +1,10 @@
 package invoice
 
 import (
+	"errors"
 	"net/http"
 
+	"oasis/backend/domain"
 	"oasis/backend/util"
 )
 
@@ -18,7 +20,7 @@ func (h *Handler) Checkout(w http.ResponseWriter, r *http.Request) {
 		// Staff flow: lookup by room number
 		inv, err := h.svc.ProcessCheckoutByRoom(roomNumber)
 		if err != nil {
-			util.SendError(w, http.StatusInternalServerError, "Checkout failed: "+err.Error())
+			sendCheckoutError(w, err, "Checkout failed: ")
 			return
 		}
 		util.SendData(w, http.StatusOK, inv)
@@ -34,10 +36,20 @@ func (h *Handler) Checkout(w http.ResponseWriter, r *http.Request) {
 
 	inv, err := h.svc.ProcessCheckout(guestID)
 	if err != nil {
-		util.SendError(w, http.StatusInternalServerError, "Checkout Transaction Failed: "+err.Error())
+		sendCheckoutError(w, err, "Checkout Transaction Failed: ")
 		return
 	}
 
 	util.SendData(w, http.StatusOK, inv)
 }
 
+// sendCheckoutError answers 409 when the stay still has something to settle first
+func sendCheckoutError(w http.ResponseWriter, err error, prefix string) {
+	switch {
+	case errors.Is(err, domain.ErrLaundryUnconfirmed), errors.Is(err, domain.ErrLaundryInProcess),
+		errors.Is(err, domain.ErrRestaurantInProcess), errors.Is(err, domain.ErrIllegalTransition):
+		util.SendError(w, http.StatusConflict, err.Error())
+	default:
+		util.SendError(w, http.StatusInternalServerError, prefix+err.Error())
+	}
+}
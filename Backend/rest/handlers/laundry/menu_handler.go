@@ -1,9 +1,13 @@
 package laundry
 
-
 import (
-	"oasis/backend/util" // Replace with your actual module
+	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
+
+	"oasis/backend/domain"
+	"oasis/backend/util" // Replace with your actual module
 )
 
 func (h *Handler) GetMenu(w http.ResponseWriter, r *http.Request) {
@@ -15,3 +19,93 @@ func (h *Handler) GetMenu(w http.ResponseWriter, r *http.Request) {
 	}
 	util.SendData(w, http.StatusOK, menu)
 }
+
+// GET /laundry/service-levels
+// Surcharges and cut-off times, so guests can pick express or same-day service
+func (h *Handler) GetServiceLevels(w http.ResponseWriter, r *http.Request) {
+	util.SendData(w, http.StatusOK, h.svc.GetServiceLevels())
+}
+
+// GET /laundry/categories
+func (h *Handler) GetCategories(w http.ResponseWriter, r *http.Request) {
+	util.SendData(w, http.StatusOK, h.svc.GetCategories())
+}
+
+// GET /laundry/items (Staff: includes unavailable items)
+func (h *Handler) GetItems(w http.ResponseWriter, r *http.Request) {
+	items, err := h.svc.GetMenuItems()
+	if err != nil {
+		util.SendError(w, http.StatusInternalServerError, "Failed to fetch laundry items")
+		return
+	}
+	util.SendData(w, http.StatusOK, items)
+}
+
+// POST /laundry/items
+func (h *Handler) CreateItem(w http.ResponseWriter, r *http.Request) {
+	item := domain.MenuItem{IsAvailable: true}
+	if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+
+	if err := h.svc.AddMenuItem(&item); err != nil {
+		sendMenuError(w, err, "Failed to create item")
+		return
+	}
+	util.SendData(w, http.StatusCreated, item)
+}
+
+// PUT /laundry/items/{id}
+// Fields left out of the body keep their current value (e.g. {"is_available": false})
+func (h *Handler) UpdateItem(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid item ID")
+		return
+	}
+
+	item, err := h.svc.GetMenuItem(id)
+	if err != nil {
+		sendMenuError(w, err, "Failed to fetch item")
+		return
+	}
+	if err := json.NewDecoder(r.Body).Decode(item); err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+	item.ID = id // Ensure ID matches URL
+
+	if err := h.svc.UpdateMenuItem(item); err != nil {
+		sendMenuError(w, err, "Failed to update item")
+		return
+	}
+	util.SendData(w, http.StatusOK, item)
+}
+
+// DELETE /laundry/items/{id}
+// Soft delete: past requests still show the item
+func (h *Handler) DeleteItem(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid item ID")
+		return
+	}
+
+	if err := h.svc.RemoveMenuItem(id); err != nil {
+		sendMenuError(w, err, "Failed to delete item")
+		return
+	}
+	util.SendData(w, http.StatusOK, "Item Deleted")
+}
+
+func sendMenuError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, domain.ErrLaundryItemNotFound):
+		util.SendError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrInvalidLaundryItem), errors.Is(err, domain.ErrInvalidLaundryCategory):
+		util.SendError(w, http.StatusBadRequest, err.Error())
+	default:
+		util.SendError(w, http.StatusInternalServerError, fallback)
+	}
+}
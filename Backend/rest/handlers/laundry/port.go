@@ -6,10 +6,22 @@ import (
 
 type Service interface {
 	GetMenu() ([]domain.MenuItem, error)
-	CreateRequest(guestID int, roomNumber, notes string) (*domain.ServiceRequest, error)
+	GetCategories() []domain.LaundryCategory
+	GetMenuItems() ([]domain.MenuItem, error)
+	GetMenuItem(id int) (*domain.MenuItem, error)
+	AddMenuItem(item *domain.MenuItem) error
+	UpdateMenuItem(item *domain.MenuItem) error
+	RemoveMenuItem(id int) error
+	GetServiceLevels() []domain.LaundryServiceRule
+	CreateRequest(guestID int, input domain.LaundryRequestInput) (*domain.ServiceRequest, error)
 	GetGuestRequests(guestID int) ([]domain.ServiceRequest, error)
 	GetAllRequests() ([]domain.ServiceRequest, error)
-	UpdateStatus(reqID int, status string) error
+	GetOverdueRequests() ([]domain.ServiceRequest, error)
+	GetRequest(reqID int) (*domain.ServiceRequest, error)
+	UpdateStatus(reqID int, status string, actor domain.Actor) (*domain.ServiceRequest, error)
+	CancelRequest(reqID int, actor domain.Actor) (*domain.ServiceRequest, error)
+	GetTag(reqID int, format string) (contentType string, data []byte, err error)
+	ScanTag(tag, stage string, actor domain.Actor) (*domain.ServiceRequest, error)
+	ResolveCount(reqID int, accepted bool, comment string, actor domain.Actor) (*domain.ServiceRequest, error)
 	AddItemsToRequest(reqID int, items []domain.AddItemInput) error
 }
-
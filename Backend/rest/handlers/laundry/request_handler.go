@@ -1,18 +1,27 @@
 package laundry
 
-
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
+
+	"oasis/backend/domain"
 
 	"oasis/backend/util" // Replace with your actual module
 )
 
 type ReqCreateRequest struct {
-	RoomNumber string `json:"room_number"`
-	Notes      string `json:"notes"`
+	RoomNumber   string     `json:"room_number"`
+	Notes        string     `json:"notes"`
+	ServiceLevel string     `json:"service_level"` // STANDARD (default), SAME_DAY or EXPRESS
+	PickupFrom   *time.Time `json:"pickup_from"`   // Optional pickup slot (RFC 3339)
+	PickupTo     *time.Time `json:"pickup_to"`
+
+	Items []domain.AddItemInput `json:"items"` // Optional: what the guest says is in the bag
 }
 
 func (h *Handler) CreateRequest(w http.ResponseWriter, r *http.Request) {
@@ -26,18 +35,30 @@ func (h *Handler) CreateRequest(w http.ResponseWriter, r *http.Request) {
 	// 2. Extract Guest ID from JWT
 	authHeader := r.Header.Get("Authorization")
 	tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-	
+
 	// Assuming you implemented the ParseJwtClaims helper in util
-	claims, err := util.ParseJwtClaims(tokenString) 
+	claims, err := util.ParseJwtClaims(tokenString)
 	if err != nil {
 		util.SendError(w, http.StatusUnauthorized, "Invalid token")
 		return
 	}
-	guestID := claims.Sub 
+	guestID := claims.Sub
 
 	// 3. Call Service (Updated: No Context passed)
-	ticket, err := h.svc.CreateRequest(guestID, req.RoomNumber, req.Notes)
+	ticket, err := h.svc.CreateRequest(guestID, domain.LaundryRequestInput{
+		RoomNumber:   req.RoomNumber,
+		Notes:        req.Notes,
+		ServiceLevel: domain.LaundryServiceLevel(strings.ToUpper(req.ServiceLevel)),
+		PickupFrom:   req.PickupFrom,
+		PickupTo:     req.PickupTo,
+		Items:        req.Items,
+	})
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidServiceLevel) || errors.Is(err, domain.ErrInvalidPickupSlot) ||
+			errors.Is(err, domain.ErrLaundryItemUnavailable) || errors.Is(err, domain.ErrInvalidLaundryQuantity) {
+			util.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		fmt.Println("Error creating laundry request:", err)
 		util.SendError(w, http.StatusInternalServerError, "Failed to create request")
 		return
@@ -50,8 +71,8 @@ func (h *Handler) GetHistory(w http.ResponseWriter, r *http.Request) {
 	// Extract ID again
 	authHeader := r.Header.Get("Authorization")
 	tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-	claims, _ := util.ParseJwtClaims(tokenString) 
-	
+	claims, _ := util.ParseJwtClaims(tokenString)
+
 	// Updated: No Context passed
 	history, err := h.svc.GetGuestRequests(claims.Sub)
 	if err != nil {
@@ -60,3 +81,52 @@ func (h *Handler) GetHistory(w http.ResponseWriter, r *http.Request) {
 	}
 	util.SendData(w, http.StatusOK, history)
 }
+
+// POST /laundry/requests/{id}/cancel
+// Guests can withdraw a pickup until the bag has been collected
+func (h *Handler) CancelRequest(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid Request ID")
+		return
+	}
+
+	req, err := h.svc.CancelRequest(id, util.ActorFromRequest(r))
+	if err != nil {
+		sendLaundryError(w, err, "Failed to cancel request")
+		return
+	}
+	util.SendData(w, http.StatusOK, req)
+}
+
+type ReqResolveCount struct {
+	Accepted *bool  `json:"accepted"`
+	Comment  string `json:"comment"` // Why the guest disagrees with the count
+}
+
+// POST /laundry/requests/{id}/count
+// The guest accepts or disputes a count that differs from their declaration
+func (h *Handler) ResolveCount(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid Request ID")
+		return
+	}
+
+	var req ReqResolveCount
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Accepted == nil {
+		util.SendError(w, http.StatusBadRequest, "accepted (true or false) is required")
+		return
+	}
+	if !*req.Accepted && strings.TrimSpace(req.Comment) == "" {
+		util.SendError(w, http.StatusBadRequest, "Please tell us what is wrong with the count")
+		return
+	}
+
+	ticket, err := h.svc.ResolveCount(id, *req.Accepted, req.Comment, util.ActorFromRequest(r))
+	if err != nil {
+		sendLaundryError(w, err, "Failed to save your answer")
+		return
+	}
+	util.SendData(w, http.StatusOK, ticket)
+}
@@ -1,26 +1,46 @@
 package laundry
 
 import (
-	middleware "oasis/backend/rest/middlewares"
 	"net/http"
+	"oasis/backend/domain"
+	middleware "oasis/backend/rest/middlewares"
 )
 
 func (h *Handler) RegisterRoutes(mux *http.ServeMux, manager *middleware.Manager) {
+	// Laundry is run by housekeeping; the front desk can follow up on guest questions
+	laundryStaff := h.middlewares.AuthorizeRoles(domain.StaffRoleHousekeeping, domain.StaffRoleSupervisor, domain.StaffRoleReceptionist, domain.StaffRoleManager, domain.StaffRoleAdmin)
+	menuManagers := h.middlewares.AuthorizeRoles(domain.StaffRoleSupervisor, domain.StaffRoleManager, domain.StaffRoleAdmin)
+
 	// Public: Anyone can see prices
 	mux.Handle("GET /laundry/menu", manager.With(http.HandlerFunc(h.GetMenu)))
+	mux.Handle("GET /laundry/service-levels", manager.With(http.HandlerFunc(h.GetServiceLevels)))
+	mux.Handle("GET /laundry/categories", manager.With(http.HandlerFunc(h.GetCategories)))
+
+	// Menu management: pricing and availability are decided by supervisors and managers
+	mux.Handle("GET /laundry/items", manager.With(http.HandlerFunc(h.GetItems), laundryStaff, h.middlewares.AuthinticateJWT))
+	mux.Handle("POST /laundry/items", manager.With(http.HandlerFunc(h.CreateItem), menuManagers, h.middlewares.AuthinticateJWT))
+	mux.Handle("PUT /laundry/items/{id}", manager.With(http.HandlerFunc(h.UpdateItem), menuManagers, h.middlewares.AuthinticateJWT))
+	mux.Handle("DELETE /laundry/items/{id}", manager.With(http.HandlerFunc(h.DeleteItem), menuManagers, h.middlewares.AuthinticateJWT))
 
 	// Protected: Only logged-in guests can request pickup
 	// We assume 'manager.With' applies your Auth middleware if configured
 	mux.Handle("POST /laundry/requests", manager.With(http.HandlerFunc(h.CreateRequest), h.middlewares.AuthinticateJWT))
-	
+
 	mux.Handle("GET /laundry/requests/me", manager.With(http.HandlerFunc(h.GetHistory), h.middlewares.AuthinticateJWT))
 
 	mux.Handle("GET /laundry/requests/all", manager.With(http.HandlerFunc(h.GetAllRequests)))
 
-    // 2. Add Items (Billing)
-    mux.Handle("POST /laundry/requests/{id}/items", manager.With(http.HandlerFunc(h.AddItems)))
+	mux.Handle("GET /laundry/requests/overdue", manager.With(http.HandlerFunc(h.GetOverdueRequests), laundryStaff, h.middlewares.AuthinticateJWT))
+	mux.Handle("GET /laundry/requests/{id}/tag", manager.With(http.HandlerFunc(h.GetTag), laundryStaff, h.middlewares.AuthinticateJWT))
+	mux.Handle("POST /laundry/scan", manager.With(http.HandlerFunc(h.ScanTag), laundryStaff, h.middlewares.AuthinticateJWT))
+	mux.Handle("GET /laundry/requests/{id}", manager.With(http.HandlerFunc(h.GetRequest), h.middlewares.AuthinticateJWT))
+	mux.Handle("POST /laundry/requests/{id}/cancel", manager.With(http.HandlerFunc(h.CancelRequest), h.middlewares.AuthinticateJWT))
+	mux.Handle("POST /laundry/requests/{id}/count", manager.With(http.HandlerFunc(h.ResolveCount), h.middlewares.AuthinticateJWT))
+
+	// 2. Add Items (Billing)
+	mux.Handle("POST /laundry/requests/{id}/items", manager.With(http.HandlerFunc(h.AddItems), laundryStaff, h.middlewares.AuthinticateJWT))
 
-    // 3. Update Status
-    // Note: We use PATCH for partial updates
-    mux.Handle("PATCH /laundry/requests/{id}/status", manager.With(http.HandlerFunc(h.UpdateStatus)))
+	// 3. Update Status
+	// Note: We use PATCH for partial updates. Illegal moves (e.g. after PAID) get 409.
+	mux.Handle("PATCH /laundry/requests/{id}/status", manager.With(http.HandlerFunc(h.UpdateStatus), laundryStaff, h.middlewares.AuthinticateJWT))
 }
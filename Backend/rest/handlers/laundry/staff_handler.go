@@ -2,6 +2,7 @@ package laundry
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"oasis/backend/domain"
 	"oasis/backend/util"
@@ -9,6 +10,7 @@ import (
 )
 
 // POST /laundry/requests/{id}/items
+// Records the staff count (replacing an earlier count); lines may carry damage/stain notes
 func (h *Handler) AddItems(w http.ResponseWriter, r *http.Request) {
 	idStr := r.PathValue("id")
 	id, err := strconv.Atoi(idStr)
@@ -35,11 +37,10 @@ func (h *Handler) AddItems(w http.ResponseWriter, r *http.Request) {
 	// Call Service
 	err = h.svc.AddItemsToRequest(id, payload.Items)
 	if err != nil {
-		// You might want to log the error here: fmt.Println(err)
-		util.SendError(w, http.StatusInternalServerError, "Error processing items")
+		sendLaundryError(w, err, "Error processing items")
 		return
 	}
-	
+
 	util.SendData(w, http.StatusOK, "Items added successfully")
 }
 
@@ -53,19 +54,62 @@ func (h *Handler) UpdateStatus(w http.ResponseWriter, r *http.Request) {
 	}
 
 	status := r.URL.Query().Get("status") // e.g. ?status=READY
-	
+	if status == "" && r.ContentLength != 0 {
+		// Also accept {"status": "READY"} in the body
+		var body struct {
+			Status string `json:"status"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err == nil {
+			status = body.Status
+		}
+	}
+
 	if status == "" {
 		util.SendError(w, http.StatusBadRequest, "Status parameter is required")
 		return
 	}
 
-	err = h.svc.UpdateStatus(id, status)
+	req, err := h.svc.UpdateStatus(id, status, util.ActorFromRequest(r))
+	if err != nil {
+		sendLaundryError(w, err, "Error updating status")
+		return
+	}
+
+	util.SendData(w, http.StatusOK, req)
+}
+
+// GET /laundry/requests/overdue
+// Open requests past their promised return time, most late first
+func (h *Handler) GetOverdueRequests(w http.ResponseWriter, r *http.Request) {
+	requests, err := h.svc.GetOverdueRequests()
+	if err != nil {
+		util.SendError(w, http.StatusInternalServerError, "Failed to fetch overdue requests")
+		return
+	}
+	util.SendData(w, http.StatusOK, requests)
+}
+
+// GET /laundry/requests/{id}
+// Staff see any request; guests only their own
+func (h *Handler) GetRequest(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid Request ID")
+		return
+	}
+
+	req, err := h.svc.GetRequest(id)
+	if err == nil {
+		actor := util.ActorFromRequest(r)
+		if actor.Role == domain.ActorRoleGuest && req.GuestID != actor.ID {
+			err = domain.ErrLaundryRequestNotFound
+		}
+	}
 	if err != nil {
-		util.SendError(w, http.StatusInternalServerError, "Error updating status")
+		sendLaundryError(w, err, "Failed to fetch request")
 		return
 	}
-	
-	util.SendData(w, http.StatusOK, "Status updated successfully")
+	util.SendData(w, http.StatusOK, req)
 }
 
 // GET /laundry/requests/all (Staff Feed)
@@ -77,3 +121,22 @@ func (h *Handler) GetAllRequests(w http.ResponseWriter, r *http.Request) {
 	}
 	util.SendData(w, http.StatusOK, requests)
 }
+
+func sendLaundryError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, domain.ErrLaundryRequestNotFound):
+		util.SendError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrInvalidLaundryStatus),
+		errors.Is(err, domain.ErrLaundryItemUnavailable),
+		errors.Is(err, domain.ErrInvalidLaundryQuantity):
+		util.SendError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, domain.ErrIllegalLaundryTransition),
+		errors.Is(err, domain.ErrLaundryRequestPaid),
+		errors.Is(err, domain.ErrLaundryRequestClosed),
+		errors.Is(err, domain.ErrLaundryStatusChanged),
+		errors.Is(err, domain.ErrNoCountToConfirm):
+		util.SendError(w, http.StatusConflict, err.Error())
+	default:
+		util.SendError(w, http.StatusInternalServerError, fallback)
+	}
+}
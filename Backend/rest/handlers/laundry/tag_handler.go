@@ -0,0 +1,70 @@
+package laundry
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"oasis/backend/domain"
+	"oasis/backend/util"
+)
+
+// GET /laundry/requests/{id}/tag?format=svg|png
+// Printable Code 128 bag tag (SVG by default)
+func (h *Handler) GetTag(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid Request ID")
+		return
+	}
+
+	contentType, data, err := h.svc.GetTag(id, strings.ToLower(r.URL.Query().Get("format")))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidTagFormat) {
+			util.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		sendLaundryError(w, err, "Failed to render tag")
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", "inline; filename=\""+domain.LaundryTagCode(id)+"."+extension(contentType)+"\"")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+func extension(contentType string) string {
+	if contentType == "image/png" {
+		return "png"
+	}
+	return "svg"
+}
+
+type ReqScanTag struct {
+	Tag   string `json:"tag"`   // What the scanner read, e.g. LND-000123
+	Stage string `json:"stage"` // Optional: the station's stage (COLLECTED, WASHING, READY, DELIVERED)
+}
+
+// POST /laundry/scan
+// Advances the scanned bag instead of looking up the request by hand
+func (h *Handler) ScanTag(w http.ResponseWriter, r *http.Request) {
+	var req ReqScanTag
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || strings.TrimSpace(req.Tag) == "" {
+		util.SendError(w, http.StatusBadRequest, "tag is required")
+		return
+	}
+
+	ticket, err := h.svc.ScanTag(req.Tag, req.Stage, util.ActorFromRequest(r))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidLaundryTag) {
+			util.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		sendLaundryError(w, err, "Failed to process scan")
+		return
+	}
+	util.SendData(w, http.StatusOK, ticket)
+}
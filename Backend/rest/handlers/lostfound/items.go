@@ -0,0 +1,223 @@
+package lostfound
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"oasis/backend/domain"
+	"oasis/backend/util"
+)
+
+// maxItemPhotos is how many photos can be sent with one request
+const maxItemPhotos = 5
+
+// ReqLogItem defines the payload for a found item
+type ReqLogItem struct {
+	RoomNumber      string `json:"room_number"`
+	FoundOn         string `json:"found_on"` // Format: "2025-12-03", defaults to today
+	Description     string `json:"description"`
+	Category        string `json:"category"`
+	StorageLocation string `json:"storage_location"`
+	Notes           string `json:"notes"`
+}
+
+// POST /lost-found
+// JSON, or multipart/form-data with the same fields and up to 5 "photos" files
+func (h *Handler) LogItem(w http.ResponseWriter, r *http.Request) {
+	var req ReqLogItem
+	var photos []domain.Upload
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		uploads, closeUploads, err := util.ParseUploads(w, r, "photos", maxItemPhotos)
+		if err != nil {
+			util.SendError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		defer closeUploads()
+
+		req = ReqLogItem{
+			RoomNumber:      r.FormValue("room_number"),
+			FoundOn:         r.FormValue("found_on"),
+			Description:     r.FormValue("description"),
+			Category:        r.FormValue("category"),
+			StorageLocation: r.FormValue("storage_location"),
+			Notes:           r.FormValue("notes"),
+		}
+		photos = uploads
+	} else if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.RoomNumber == "" || req.Description == "" || req.StorageLocation == "" {
+		util.SendError(w, http.StatusBadRequest, "Room number, description and storage location are required")
+		return
+	}
+
+	item := domain.LostItem{
+		RoomNumber:      req.RoomNumber,
+		Description:     req.Description,
+		Category:        strings.ToUpper(req.Category),
+		StorageLocation: req.StorageLocation,
+		Notes:           req.Notes,
+	}
+	if req.FoundOn != "" {
+		foundOn, err := time.Parse("2006-01-02", req.FoundOn)
+		if err != nil {
+			util.SendError(w, http.StatusBadRequest, "Invalid found_on date. Use YYYY-MM-DD")
+			return
+		}
+		item.FoundOn = foundOn
+	}
+
+	logged, err := h.svc.LogItem(item, photos, util.ActorFromRequest(r))
+	if err != nil {
+		sendLostItemError(w, err)
+		return
+	}
+	util.SendData(w, http.StatusCreated, logged)
+}
+
+// GET /lost-found?status=STORED
+func (h *Handler) GetItems(w http.ResponseWriter, r *http.Request) {
+	status := domain.LostItemStatus(strings.ToUpper(r.URL.Query().Get("status")))
+	items, err := h.svc.GetItems(status)
+	if err != nil {
+		util.SendError(w, http.StatusInternalServerError, "Failed to fetch lost items")
+		return
+	}
+	util.SendData(w, http.StatusOK, items)
+}
+
+// GET /lost-found/{id}
+func (h *Handler) GetItem(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid item ID")
+		return
+	}
+
+	item, err := h.svc.GetItem(id)
+	if err != nil {
+		sendLostItemError(w, err)
+		return
+	}
+	util.SendData(w, http.StatusOK, item)
+}
+
+// POST /lost-found/{id}/photos (multipart/form-data, "photos" files)
+func (h *Handler) AttachPhotos(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid item ID")
+		return
+	}
+
+	uploads, closeUploads, err := util.ParseUploads(w, r, "photos", maxItemPhotos)
+	if err != nil {
+		util.SendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	defer closeUploads()
+	if len(uploads) == 0 {
+		util.SendError(w, http.StatusBadRequest, "At least one photo is required")
+		return
+	}
+
+	actor := util.ActorFromRequest(r)
+	attachments := []domain.Attachment{}
+	for _, photo := range uploads {
+		att, err := h.svc.AttachPhoto(id, photo, actor)
+		if err != nil {
+			sendLostItemError(w, err)
+			return
+		}
+		attachments = append(attachments, *att)
+	}
+	util.SendData(w, http.StatusCreated, attachments)
+}
+
+// PATCH /lost-found/{id}/return
+// Payload: { "returned_to": "John Smith (courier)", "notes": "Shipped by DHL" }
+func (h *Handler) ReturnItem(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid item ID")
+		return
+	}
+
+	var req struct {
+		ReturnedTo string `json:"returned_to"` // Defaults to the matched guest
+		Notes      string `json:"notes"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	item, err := h.svc.ReturnItem(id, req.ReturnedTo, req.Notes, util.ActorFromRequest(r))
+	if err != nil {
+		sendLostItemError(w, err)
+		return
+	}
+	util.SendData(w, http.StatusOK, item)
+}
+
+// PATCH /lost-found/{id}/dispose
+// Payload: { "method": "DONATED", "notes": "Red Cross" }
+func (h *Handler) DisposeItem(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid item ID")
+		return
+	}
+
+	var req struct {
+		Method string `json:"method"`
+		Notes  string `json:"notes"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	item, err := h.svc.DisposeItem(id, strings.ToUpper(req.Method), req.Notes, util.ActorFromRequest(r))
+	if err != nil {
+		sendLostItemError(w, err)
+		return
+	}
+	util.SendData(w, http.StatusOK, item)
+}
+
+// GET /lost-found/due-for-disposal
+func (h *Handler) GetDueForDisposal(w http.ResponseWriter, r *http.Request) {
+	items, err := h.svc.GetDueForDisposal()
+	if err != nil {
+		util.SendError(w, http.StatusInternalServerError, "Failed to fetch lost items")
+		return
+	}
+	util.SendData(w, http.StatusOK, items)
+}
+
+func sendLostItemError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrLostItemNotFound), errors.Is(err, domain.ErrRoomNotFound):
+		util.SendError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrLostItemClosed), errors.Is(err, domain.ErrRetentionNotOver):
+		util.SendError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, domain.ErrInvalidLostCategory),
+		errors.Is(err, domain.ErrInvalidDisposal),
+		errors.Is(err, domain.ErrReturnRecipientEmpty):
+		util.SendError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, domain.ErrAttachmentTooLarge):
+		util.SendError(w, http.StatusRequestEntityTooLarge, err.Error())
+	case errors.Is(err, domain.ErrUnsupportedMediaType):
+		util.SendError(w, http.StatusUnsupportedMediaType, err.Error())
+	default:
+		util.SendError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
@@ -0,0 +1,17 @@
+package lostfound
+
+import "oasis/backend/domain"
+
+// Service defines the methods the Handler needs from the Business Logic layer.
+type Service interface {
+	// LogItem records a found item and matches it to the last guest of the room
+	LogItem(item domain.LostItem, photos []domain.Upload, actor domain.Actor) (*domain.LostItem, error)
+	GetItems(status domain.LostItemStatus) ([]domain.LostItem, error)
+	GetItem(id int) (*domain.LostItem, error)
+	AttachPhoto(id int, photo domain.Upload, actor domain.Actor) (*domain.Attachment, error)
+
+	// Closing an item
+	ReturnItem(id int, returnedTo, notes string, actor domain.Actor) (*domain.LostItem, error)
+	DisposeItem(id int, method, notes string, actor domain.Actor) (*domain.LostItem, error)
+	GetDueForDisposal() ([]domain.LostItem, error)
+}
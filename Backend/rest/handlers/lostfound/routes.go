@@ -0,0 +1,23 @@
+package lostfound
+
+import (
+	"net/http"
+
+	"oasis/backend/domain"
+	middleware "oasis/backend/rest/middlewares"
+)
+
+func (h *Handler) RegisterRoutes(mux *http.ServeMux, manager *middleware.Manager) {
+	// Housekeeping logs what it finds, the Front Desk hands items back, supervisors dispose of them
+	finders := h.middlewares.AuthorizeRoles(domain.StaffRoleHousekeeping, domain.StaffRoleSupervisor, domain.StaffRoleReceptionist, domain.StaffRoleManager, domain.StaffRoleAdmin)
+	frontDesk := h.middlewares.AuthorizeRoles(domain.StaffRoleReceptionist, domain.StaffRoleSupervisor, domain.StaffRoleManager, domain.StaffRoleAdmin)
+	supervisors := h.middlewares.AuthorizeRoles(domain.StaffRoleSupervisor, domain.StaffRoleManager, domain.StaffRoleAdmin)
+
+	mux.Handle("POST /lost-found", manager.With(http.HandlerFunc(h.LogItem), finders, h.middlewares.AuthinticateJWT))
+	mux.Handle("GET /lost-found", manager.With(http.HandlerFunc(h.GetItems), finders, h.middlewares.AuthinticateJWT))
+	mux.Handle("GET /lost-found/due-for-disposal", manager.With(http.HandlerFunc(h.GetDueForDisposal), supervisors, h.middlewares.AuthinticateJWT))
+	mux.Handle("GET /lost-found/{id}", manager.With(http.HandlerFunc(h.GetItem), finders, h.middlewares.AuthinticateJWT))
+	mux.Handle("POST /lost-found/{id}/photos", manager.With(http.HandlerFunc(h.AttachPhotos), finders, h.middlewares.AuthinticateJWT))
+	mux.Handle("PATCH /lost-found/{id}/return", manager.With(http.HandlerFunc(h.ReturnItem), frontDesk, h.middlewares.AuthinticateJWT))
+	mux.Handle("PATCH /lost-found/{id}/dispose", manager.With(http.HandlerFunc(h.DisposeItem), supervisors, h.middlewares.AuthinticateJWT))
+}
@@ -2,6 +2,7 @@ package restaurant
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"oasis/backend/domain"
 	"oasis/backend/util"
@@ -17,9 +18,21 @@ func (h *Handler) CreateItem(w http.ResponseWriter, r *http.Request) {
 
 	err := h.svc.AddMenuItem(&item)
 	if err != nil {
-		util.SendError(w, 500, "Failed to create item")
+		sendItemError(w, err, "Failed to create item")
 		return
 	}
 	util.SendData(w, 201, item)
 }
 
+// sendItemError answers 400 for bad tags or station on a menu item and 404 for an unknown item
+func sendItemError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidAllergen), errors.Is(err, domain.ErrInvalidDietaryTag),
+		errors.Is(err, domain.ErrInvalidStation):
+		util.SendError(w, 400, err.Error())
+	case errors.Is(err, domain.ErrMenuItemNotFound):
+		util.SendError(w, 404, err.Error())
+	default:
+		util.SendError(w, 500, fallback)
+	}
+}
@@ -1,17 +1,25 @@
 package restaurant
 
 import (
+	"errors"
 	"net/http"
+	"oasis/backend/domain"
 	"oasis/backend/util"
+	"strings"
 )
 
-// GET /restaurant/orders/active (Kitchen Feed)
+// GET /restaurant/orders/active?station=GRILL (Kitchen Feed)
+// Live changes are pushed over /ws: NEW_ORDER, ORDER_STATUS, ORDER_ITEM_STATUS, ORDER_LATE
 func (h *Handler) GetActiveOrders(w http.ResponseWriter, r *http.Request) {
-	orders, err := h.svc.GetKitchenOrders()
+	station := strings.ToUpper(r.URL.Query().Get("station"))
+	orders, err := h.svc.GetKitchenOrders(station)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidStation) {
+			util.SendError(w, 400, err.Error())
+			return
+		}
 		util.SendError(w, 500, "Error")
 		return
 	}
 	util.SendData(w, 200, orders)
 }
-
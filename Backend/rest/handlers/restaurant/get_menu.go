@@ -2,12 +2,33 @@ package restaurant
 
 import (
 	"net/http"
+	"oasis/backend/domain"
 	"oasis/backend/util"
+	"strings"
 )
 
-// GET /restaurant/menu
+// GET /restaurant/menu?exclude_allergens=NUTS,DAIRY&diet=VEGAN&safe=true
+// A verified guest token (see OptionalJWT on the route) marks the guest's allergies on each dish; safe=true hides those dishes.
 func (h *Handler) GetMenu(w http.ResponseWriter, r *http.Request) {
-	menu, err := h.svc.GetFullMenu()
+	q := r.URL.Query()
+	exclude, err := domain.NormalizeAllergens(splitList(q.Get("exclude_allergens")))
+	if err != nil {
+		util.SendError(w, 400, err.Error())
+		return
+	}
+	diets, err := domain.NormalizeDietaryTags(splitList(q.Get("diet")))
+	if err != nil {
+		util.SendError(w, 400, err.Error())
+		return
+	}
+
+	filter := domain.MenuFilter{ExcludeAllergens: exclude, Diets: diets}
+	if actor := util.ActorFromRequest(r); actor.Role == domain.ActorRoleGuest && actor.ID != 0 {
+		filter.GuestID = actor.ID
+		filter.SafeForGuest = q.Get("safe") == "true"
+	}
+
+	menu, err := h.svc.GetFullMenu(filter)
 	if err != nil {
 		util.SendError(w, 500, "Error fetching menu")
 		return
@@ -15,3 +36,18 @@ func (h *Handler) GetMenu(w http.ResponseWriter, r *http.Request) {
 	util.SendData(w, 200, menu)
 }
 
+// GET /restaurant/tags lists the allergens and dietary tags items can carry
+func (h *Handler) GetTags(w http.ResponseWriter, r *http.Request) {
+	util.SendData(w, 200, map[string][]string{
+		"allergens": domain.Allergens(),
+		"dietary":   domain.DietaryTags(),
+	})
+}
+
+// splitList reads a comma separated query value
+func splitList(v string) []string {
+	if v == "" {
+		return nil
+	}
+	return strings.Split(v, ",")
+}
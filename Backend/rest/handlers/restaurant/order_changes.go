@@ -0,0 +1,142 @@
+package restaurant
+
+import (
+	"encoding/json"
+	"net/http"
+	"oasis/backend/domain"
+	"oasis/backend/util"
+	"strconv"
+)
+
+type ReqModifyOrder struct {
+	Notes                string                            `json:"notes"`
+	Items                []domain.RestaurantOrderItemInput `json:"items"`
+	AcknowledgeAllergens bool                              `json:"acknowledge_allergens"`
+}
+
+type ReqCancelOrder struct {
+	Reason string `json:"reason"`
+}
+
+// PUT /restaurant/orders/{id}
+// Replaces the cart while the order is RECEIVED; answers like POST /restaurant/orders on bad lines
+func (h *Handler) ModifyOrder(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, 400, "Invalid id")
+		return
+	}
+
+	var req ReqModifyOrder
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.SendError(w, 400, "Invalid Payload")
+		return
+	}
+
+	ticket, err := h.svc.ModifyOrder(id, req.Notes, req.Items, req.AcknowledgeAllergens, util.ActorFromRequest(r))
+	if err != nil {
+		sendKitchenError(w, err)
+		return
+	}
+	util.SendData(w, 200, ticket)
+}
+
+// POST /restaurant/orders/{id}/cancel
+// Cancels a RECEIVED order; a guest cancelling a PREPARING order gets 202 while the kitchen decides
+func (h *Handler) CancelOrder(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, 400, "Invalid id")
+		return
+	}
+
+	var req ReqCancelOrder
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.SendError(w, 400, "Invalid JSON")
+		return
+	}
+
+	order, err := h.svc.CancelOrder(id, req.Reason, util.ActorFromRequest(r))
+	if err != nil {
+		sendKitchenError(w, err)
+		return
+	}
+	if order.Status != domain.OrderStatusCancelled {
+		util.SendData(w, 202, order)
+		return
+	}
+	util.SendData(w, 200, order)
+}
+
+// POST /restaurant/orders/{id}/cancel-request/approve
+func (h *Handler) ApproveCancel(w http.ResponseWriter, r *http.Request) {
+	h.resolveCancel(w, r, true)
+}
+
+// POST /restaurant/orders/{id}/cancel-request/reject
+// The kitchen keeps cooking; the guest is told on their socket
+func (h *Handler) RejectCancel(w http.ResponseWriter, r *http.Request) {
+	h.resolveCancel(w, r, false)
+}
+
+func (h *Handler) resolveCancel(w http.ResponseWriter, r *http.Request, approve bool) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, 400, "Invalid id")
+		return
+	}
+
+	order, err := h.svc.ResolveCancelRequest(id, approve, util.ActorFromRequest(r))
+	if err != nil {
+		sendKitchenError(w, err)
+		return
+	}
+	util.SendData(w, 200, order)
+}
+
+// GET /restaurant/adjustment-reasons
+func (h *Handler) GetAdjustReasons(w http.ResponseWriter, r *http.Request) {
+	util.SendData(w, 200, domain.AdjustReasons())
+}
+
+// POST /restaurant/orders/{id}/items/{lineId}/adjustment (Managers)
+// Comps or voids one line: {"type": "COMP", "reason_code": "QUALITY", "note": "..."}
+func (h *Handler) AdjustLine(w http.ResponseWriter, r *http.Request) {
+	orderID, err1 := strconv.Atoi(r.PathValue("id"))
+	lineID, err2 := strconv.Atoi(r.PathValue("lineId"))
+	if err1 != nil || err2 != nil {
+		util.SendError(w, 400, "Invalid id")
+		return
+	}
+
+	var req domain.LineAdjustment
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.SendError(w, 400, "Invalid JSON")
+		return
+	}
+
+	ticket, err := h.svc.AdjustLine(orderID, lineID, req, util.ActorFromRequest(r))
+	if err != nil {
+		sendKitchenError(w, err)
+		return
+	}
+	util.SendData(w, 200, ticket)
+}
+
+// DELETE /restaurant/orders/{id}/items/{lineId}/adjustment (Managers)
+// Puts the line back on the bill
+func (h *Handler) RemoveLineAdjustment(w http.ResponseWriter, r *http.Request) {
+	orderID, err1 := strconv.Atoi(r.PathValue("id"))
+	lineID, err2 := strconv.Atoi(r.PathValue("lineId"))
+	if err1 != nil || err2 != nil {
+		util.SendError(w, 400, "Invalid id")
+		return
+	}
+
+	ticket, err := h.svc.RemoveLineAdjustment(orderID, lineID, util.ActorFromRequest(r))
+	if err != nil {
+		sendKitchenError(w, err)
+		return
+	}
+	util.SendData(w, 200, ticket)
+}
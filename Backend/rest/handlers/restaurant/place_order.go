@@ -2,6 +2,7 @@ package restaurant
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"oasis/backend/domain"
 	"oasis/backend/util"
@@ -9,9 +10,10 @@ import (
 )
 
 type ReqPlaceOrder struct {
-	RoomNumber string                  `json:"room_number"`
-	Notes      string                  `json:"notes"`
-	Items      []domain.RestaurantOrderItemInput `json:"items"`
+	RoomNumber           string                            `json:"room_number"`
+	Notes                string                            `json:"notes"`
+	Items                []domain.RestaurantOrderItemInput `json:"items"`
+	AcknowledgeAllergens bool                              `json:"acknowledge_allergens"` // Guest confirmed the allergy warning
 }
 
 // POST /restaurant/orders
@@ -26,14 +28,40 @@ func (h *Handler) PlaceOrder(w http.ResponseWriter, r *http.Request) {
 	// 2. Get Guest ID
 	authHeader := r.Header.Get("Authorization")
 	tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-	claims, _ := util.ParseJwtClaims(tokenString)
+	claims, err := util.ParseJwtClaims(tokenString)
+	if err != nil {
+		util.SendError(w, 401, "Invalid token")
+		return
+	}
 
 	// 3. Call Service
-	order, err := h.svc.PlaceOrder(claims.Sub, req.RoomNumber, req.Notes, req.Items)
+	order, err := h.svc.PlaceOrder(claims.Sub, req.RoomNumber, req.Notes, req.Items, req.AcknowledgeAllergens)
 	if err != nil {
-		util.SendError(w, 500, "Failed to place order")
+		sendOrderError(w, err)
 		return
 	}
 	util.SendData(w, 200, order)
 }
 
+// sendOrderError answers 422 with the offending lines when the cart itself is wrong,
+// and 409 with the conflicting dishes when the guest has not confirmed an allergy warning
+func sendOrderError(w http.ResponseWriter, err error) {
+	var invalid *domain.OrderValidationError
+	var warning *domain.AllergenWarning
+	switch {
+	case errors.As(err, &warning):
+		util.SendData(w, 409, map[string]interface{}{
+			"error":     domain.ErrAllergenConflict.Error(),
+			"conflicts": warning.Conflicts,
+		})
+	case errors.As(err, &invalid):
+		util.SendData(w, 422, map[string]interface{}{
+			"error": domain.ErrInvalidOrder.Error(),
+			"lines": invalid.Lines,
+		})
+	case errors.Is(err, domain.ErrEmptyOrder):
+		util.SendError(w, 422, err.Error())
+	default:
+		util.SendError(w, 500, "Failed to place order")
+	}
+}
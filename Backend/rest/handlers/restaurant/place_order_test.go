@@ -0,0 +1,67 @@
+package restaurant
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"oasis/backend/domain"
+)
+
+func TestSendOrderErrorInvalidLines(t *testing.T) {
+	lines := []domain.OrderLineError{
+		{Line: 0, ItemID: 7, Reason: domain.OrderLineSoldOut, Message: "Soup is sold out until 17:00"},
+		{Line: 2, ItemID: 9, Reason: domain.OrderLineModifierCount, Message: "choose Doneness for Steak"},
+	}
+	// The service may wrap the error on its way up
+	err := fmt.Errorf("place order: %w", &domain.OrderValidationError{Lines: lines})
+
+	rec := httptest.NewRecorder()
+	sendOrderError(rec, err)
+
+	if rec.Code != 422 {
+		t.Fatalf("got status %d, want 422", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("got Content-Type %q, want application/json", ct)
+	}
+
+	var body struct {
+		Error string                  `json:"error"`
+		Lines []domain.OrderLineError `json:"lines"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("body is not JSON: %v (%s)", err, rec.Body.String())
+	}
+	if body.Error != domain.ErrInvalidOrder.Error() {
+		t.Errorf("got error %q, want %q", body.Error, domain.ErrInvalidOrder.Error())
+	}
+	if !reflect.DeepEqual(body.Lines, lines) {
+		t.Errorf("got lines %+v, want %+v", body.Lines, lines)
+	}
+}
+
+func TestSendOrderErrorStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"empty cart", domain.ErrEmptyOrder, 422},
+		{"allergy warning", &domain.AllergenWarning{}, 409},
+		{"anything else", errors.New("connection refused"), 500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			sendOrderError(rec, tt.err)
+			if rec.Code != tt.code {
+				t.Errorf("got status %d, want %d", rec.Code, tt.code)
+			}
+		})
+	}
+}
@@ -1,32 +1,74 @@
 package restaurant
 
-import "oasis/backend/domain"
+import (
+	"oasis/backend/domain"
+	"time"
+)
 
 type Service interface {
 	// --- GUEST FEATURES ---
 
 	// GetFullMenu returns the tree structure (Categories -> Items)
 	// Requires: domain.CategoryWithItems struct
-	GetFullMenu() ([]domain.CategoryWithItems, error)
+	// The filter drops items by allergen / dietary tag and marks the guest's allergies
+	GetFullMenu(filter domain.MenuFilter) ([]domain.CategoryWithItems, error)
 
 	// PlaceOrder handles the guest adding food to cart
 	// Requires: domain.RestaurantOrderItemInput struct
-	PlaceOrder(guestID int, roomNumber, notes string, items []domain.RestaurantOrderItemInput) (*domain.Order, error)
+	// Returns a *domain.AllergenWarning unless the guest acknowledged the allergens in the order
+	PlaceOrder(guestID int, roomNumber, notes string, items []domain.RestaurantOrderItemInput, acknowledgeAllergens bool) (*domain.Order, error)
 
 	// GetGuestOrders shows history (My Orders)
 	GetGuestOrders(guestID int) ([]domain.Order, error)
 
+	// TrackOrder returns the order with its status history and ETA; guests only see their own
+	TrackOrder(orderID int, actor domain.Actor) (*domain.OrderTracking, error)
+
+	// ModifyOrder replaces the lines (and notes) of an order the kitchen has not started yet
+	ModifyOrder(orderID int, notes string, items []domain.RestaurantOrderItemInput, acknowledgeAllergens bool, actor domain.Actor) (*domain.OrderWithItems, error)
+
+	// CancelOrder cancels a RECEIVED order; once PREPARING a guest's cancel waits for the kitchen
+	CancelOrder(orderID int, reason string, actor domain.Actor) (*domain.Order, error)
+
 	// --- STAFF / ADMIN FEATURES ---
 
 	// GetKitchenOrders shows active tickets for the KDS (Kitchen Display System)
-	GetKitchenOrders() ([]domain.OrderWithItems, error)
+	// A station (GRILL, COLD, BAR) only gets its own lines; "" gets everything
+	GetKitchenOrders(station string) ([]domain.OrderWithItems, error)
+
+	// BumpItem marks a line ready at its station (false recalls it)
+	// The first bump starts the order (PREPARING), the last one makes it READY
+	BumpItem(orderID, lineID int, ready bool, actor domain.Actor) (*domain.OrderWithItems, error)
+
+	// ResolveCancelRequest approves or turns down a guest's request to cancel an order being made
+	ResolveCancelRequest(orderID int, approve bool, actor domain.Actor) (*domain.Order, error)
 
-	// UpdateStatus moves order from PREPARING -> READY -> DELIVERED
-	UpdateStatus(orderID int, status string) error
+	// AdjustLine comps or voids one line with a reason code (managers); RemoveLineAdjustment undoes it
+	AdjustLine(orderID, lineID int, adj domain.LineAdjustment, actor domain.Actor) (*domain.OrderWithItems, error)
+	RemoveLineAdjustment(orderID, lineID int, actor domain.Actor) (*domain.OrderWithItems, error)
+
+	// SetCategoryStation routes a category to a kitchen station
+	SetCategoryStation(categoryID int, station string) error
+
+	// UpdateStatus moves order RECEIVED -> PREPARING -> READY -> OUT_FOR_DELIVERY -> DELIVERED
+	// Cancelling (before READY) needs a reason; the guest is notified of every change
+	UpdateStatus(orderID int, status, reason string, actor domain.Actor) (*domain.Order, error)
 
 	// Menu CRUD (For Menu Manager Page)
 	AddMenuItem(item *domain.RestaurantMenuItem) error
 	UpdateMenuItem(item *domain.RestaurantMenuItem) error
 	RemoveMenuItem(id int) error
+
+	// SetModifierGroups replaces the modifier groups of an item (doneness, extras, ...)
+	SetModifierGroups(itemID int, groups []domain.ModifierGroup) ([]domain.ModifierGroup, error)
+
+	// Service periods (breakfast 06:00-11:00, ...) set on a category or a single item
+	GetServicePeriods() ([]domain.ServicePeriod, error)
+	SetCategoryPeriods(categoryID int, periods []domain.ServicePeriod) ([]domain.ServicePeriod, error)
+	SetItemPeriods(itemID int, periods []domain.ServicePeriod) ([]domain.ServicePeriod, error)
+
+	// MarkSoldOut 86es an item until the given time, or until its next service when nil
+	MarkSoldOut(itemID int, until *time.Time) (*domain.RestaurantMenuItem, error)
+	Restock(itemID int) (*domain.RestaurantMenuItem, error)
 }
 
@@ -1,21 +1,47 @@
 package restaurant
 
 import (
-	middleware "oasis/backend/rest/middlewares"
 	"net/http"
+	"oasis/backend/domain"
+	middleware "oasis/backend/rest/middlewares"
 )
 
 func (h *Handler) RegisterRoutes(mux *http.ServeMux, manager *middleware.Manager) {
 	// Guest Routes
-	mux.Handle("GET /restaurant/menu", manager.With(http.HandlerFunc(h.GetMenu)))
-	mux.Handle("POST /restaurant/orders", manager.With(http.HandlerFunc(h.PlaceOrder))) // Add Auth middleware
+	mux.Handle("GET /restaurant/menu", manager.With(http.HandlerFunc(h.GetMenu), h.middlewares.OptionalJWT))
+	mux.Handle("GET /restaurant/tags", manager.With(http.HandlerFunc(h.GetTags)))
+	mux.Handle("POST /restaurant/orders", manager.With(http.HandlerFunc(h.PlaceOrder), h.middlewares.AuthinticateJWT))
 	mux.Handle("GET /restaurant/orders/me", manager.With(http.HandlerFunc(h.GetMyOrders))) // Add Auth middleware
+	mux.Handle("GET /restaurant/orders/{id}/track", manager.With(http.HandlerFunc(h.TrackOrder), h.middlewares.AuthinticateJWT))
+	mux.Handle("PUT /restaurant/orders/{id}", manager.With(http.HandlerFunc(h.ModifyOrder), h.middlewares.AuthinticateJWT))
+	mux.Handle("POST /restaurant/orders/{id}/cancel", manager.With(http.HandlerFunc(h.CancelOrder), h.middlewares.AuthinticateJWT))
 
-	// Staff Routes
-	mux.Handle("PATCH /restaurant/orders/{id}/status", manager.With(http.HandlerFunc(h.UpdateStatus)))
+	// Staff Routes (kitchen staff work the tickets, managers own the menu)
+	kitchen := h.middlewares.AuthorizeRoles(domain.StaffRoleKitchen, domain.StaffRoleManager, domain.StaffRoleAdmin)
+	managers := h.middlewares.AuthorizeRoles(domain.StaffRoleManager, domain.StaffRoleAdmin)
+	mux.Handle("PATCH /restaurant/orders/{id}/status", manager.With(http.HandlerFunc(h.UpdateStatus), kitchen, h.middlewares.AuthinticateJWT))
 	// STAFF / ADMIN ROUTES
-    mux.Handle("GET /restaurant/orders/active", manager.With(http.HandlerFunc(h.GetActiveOrders)))
-    mux.Handle("POST /restaurant/items", manager.With(http.HandlerFunc(h.CreateItem)))
-    mux.Handle("PUT /restaurant/items/{id}", manager.With(http.HandlerFunc(h.UpdateItem)))
-    mux.Handle("DELETE /restaurant/items/{id}", manager.With(http.HandlerFunc(h.DeleteItem)))
+	mux.Handle("GET /restaurant/orders/active", manager.With(http.HandlerFunc(h.GetActiveOrders), kitchen, h.middlewares.AuthinticateJWT))
+	mux.Handle("POST /restaurant/items", manager.With(http.HandlerFunc(h.CreateItem), managers, h.middlewares.AuthinticateJWT))
+	mux.Handle("PUT /restaurant/items/{id}", manager.With(http.HandlerFunc(h.UpdateItem), managers, h.middlewares.AuthinticateJWT))
+	mux.Handle("DELETE /restaurant/items/{id}", manager.With(http.HandlerFunc(h.DeleteItem), managers, h.middlewares.AuthinticateJWT))
+	mux.Handle("PUT /restaurant/items/{id}/modifiers", manager.With(http.HandlerFunc(h.SetModifiers), managers, h.middlewares.AuthinticateJWT))
+	mux.Handle("GET /restaurant/service-periods", manager.With(http.HandlerFunc(h.GetServicePeriods)))
+	mux.Handle("PUT /restaurant/categories/{id}/service-periods", manager.With(http.HandlerFunc(h.SetCategoryPeriods), managers, h.middlewares.AuthinticateJWT))
+	mux.Handle("PUT /restaurant/items/{id}/service-periods", manager.With(http.HandlerFunc(h.SetItemPeriods), managers, h.middlewares.AuthinticateJWT))
+	mux.Handle("POST /restaurant/items/{id}/sold-out", manager.With(http.HandlerFunc(h.MarkSoldOut), kitchen, h.middlewares.AuthinticateJWT))
+	mux.Handle("DELETE /restaurant/items/{id}/sold-out", manager.With(http.HandlerFunc(h.Restock), kitchen, h.middlewares.AuthinticateJWT))
+
+	// Kitchen stations
+	mux.Handle("GET /restaurant/stations", manager.With(http.HandlerFunc(h.GetStations)))
+	mux.Handle("PUT /restaurant/categories/{id}/station", manager.With(http.HandlerFunc(h.SetCategoryStation), managers, h.middlewares.AuthinticateJWT))
+	mux.Handle("POST /restaurant/orders/{id}/items/{lineId}/bump", manager.With(http.HandlerFunc(h.BumpItem), kitchen, h.middlewares.AuthinticateJWT))
+	mux.Handle("DELETE /restaurant/orders/{id}/items/{lineId}/bump", manager.With(http.HandlerFunc(h.RecallItem), kitchen, h.middlewares.AuthinticateJWT))
+	mux.Handle("POST /restaurant/orders/{id}/cancel-request/approve", manager.With(http.HandlerFunc(h.ApproveCancel), kitchen, h.middlewares.AuthinticateJWT))
+	mux.Handle("POST /restaurant/orders/{id}/cancel-request/reject", manager.With(http.HandlerFunc(h.RejectCancel), kitchen, h.middlewares.AuthinticateJWT))
+
+	// Comps and voids (Managers)
+	mux.Handle("GET /restaurant/adjustment-reasons", manager.With(http.HandlerFunc(h.GetAdjustReasons)))
+	mux.Handle("POST /restaurant/orders/{id}/items/{lineId}/adjustment", manager.With(http.HandlerFunc(h.AdjustLine), managers, h.middlewares.AuthinticateJWT))
+	mux.Handle("DELETE /restaurant/orders/{id}/items/{lineId}/adjustment", manager.With(http.HandlerFunc(h.RemoveLineAdjustment), managers, h.middlewares.AuthinticateJWT))
 }
@@ -0,0 +1,75 @@
+package restaurant
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"oasis/backend/domain"
+	"oasis/backend/util"
+	"strconv"
+)
+
+type ReqServicePeriod struct {
+	Name  string `json:"name"`
+	Start string `json:"start"` // "06:00"
+	End   string `json:"end"`   // "11:00"; before start runs past midnight
+	Days  []int  `json:"days"`  // 0 = Sunday ... 6 = Saturday; empty = every day
+}
+
+// GET /restaurant/service-periods
+func (h *Handler) GetServicePeriods(w http.ResponseWriter, r *http.Request) {
+	periods, err := h.svc.GetServicePeriods()
+	if err != nil {
+		util.SendError(w, 500, "Failed to fetch service periods")
+		return
+	}
+	util.SendData(w, 200, periods)
+}
+
+// PUT /restaurant/categories/{id}/service-periods
+// Replaces the periods of the category; an empty list serves it all day
+func (h *Handler) SetCategoryPeriods(w http.ResponseWriter, r *http.Request) {
+	h.setPeriods(w, r, h.svc.SetCategoryPeriods)
+}
+
+// PUT /restaurant/items/{id}/service-periods
+// Replaces the item's own periods; an empty list falls back to its category
+func (h *Handler) SetItemPeriods(w http.ResponseWriter, r *http.Request) {
+	h.setPeriods(w, r, h.svc.SetItemPeriods)
+}
+
+func (h *Handler) setPeriods(w http.ResponseWriter, r *http.Request, save func(int, []domain.ServicePeriod) ([]domain.ServicePeriod, error)) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, 400, "Invalid id")
+		return
+	}
+
+	var req []ReqServicePeriod
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.SendError(w, 400, "Invalid JSON")
+		return
+	}
+	periods := make([]domain.ServicePeriod, 0, len(req))
+	for _, p := range req {
+		days := make([]int64, 0, len(p.Days))
+		for _, d := range p.Days {
+			days = append(days, int64(d))
+		}
+		periods = append(periods, domain.ServicePeriod{Name: p.Name, Start: p.Start, End: p.End, Days: days})
+	}
+
+	saved, err := save(id, periods)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrMenuItemNotFound), errors.Is(err, domain.ErrCategoryNotFound):
+			util.SendError(w, 404, err.Error())
+		case errors.Is(err, domain.ErrInvalidServicePeriod):
+			util.SendError(w, 400, err.Error())
+		default:
+			util.SendError(w, 500, "Failed to save service periods")
+		}
+		return
+	}
+	util.SendData(w, 200, saved)
+}
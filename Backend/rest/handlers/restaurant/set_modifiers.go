@@ -0,0 +1,69 @@
+package restaurant
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"oasis/backend/domain"
+	"oasis/backend/util"
+	"strconv"
+)
+
+type ReqModifierOption struct {
+	Name        string  `json:"name"`
+	PriceDelta  float64 `json:"price_delta"`
+	IsAvailable *bool   `json:"is_available"` // Defaults to true
+}
+
+type ReqModifierGroup struct {
+	Name      string              `json:"name"`
+	Required  bool                `json:"required"`
+	MinSelect int                 `json:"min_select"`
+	MaxSelect int                 `json:"max_select"`
+	Options   []ReqModifierOption `json:"options"`
+}
+
+// PUT /restaurant/items/{id}/modifiers
+// Replaces all modifier groups of the item, in the given order
+func (h *Handler) SetModifiers(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, 400, "Invalid item ID")
+		return
+	}
+
+	var req []ReqModifierGroup
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.SendError(w, 400, "Invalid JSON")
+		return
+	}
+
+	groups := make([]domain.ModifierGroup, 0, len(req))
+	for _, g := range req {
+		group := domain.ModifierGroup{
+			Name:      g.Name,
+			Required:  g.Required,
+			MinSelect: g.MinSelect,
+			MaxSelect: g.MaxSelect,
+		}
+		for _, o := range g.Options {
+			available := o.IsAvailable == nil || *o.IsAvailable
+			group.Options = append(group.Options, domain.ModifierOption{Name: o.Name, PriceDelta: o.PriceDelta, IsAvailable: available})
+		}
+		groups = append(groups, group)
+	}
+
+	saved, err := h.svc.SetModifierGroups(id, groups)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrMenuItemNotFound):
+			util.SendError(w, 404, err.Error())
+		case errors.Is(err, domain.ErrInvalidModifierGroup):
+			util.SendError(w, 400, err.Error())
+		default:
+			util.SendError(w, 500, "Failed to save modifiers")
+		}
+		return
+	}
+	util.SendData(w, 200, saved)
+}
@@ -0,0 +1,64 @@
+package restaurant
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"oasis/backend/domain"
+	"oasis/backend/util"
+	"strconv"
+	"time"
+)
+
+type ReqSoldOut struct {
+	Until *time.Time `json:"until"` // Optional; defaults to the start of the item's next service
+}
+
+// POST /restaurant/items/{id}/sold-out
+// The kitchen 86es an item; it comes back on its own at the next service
+func (h *Handler) MarkSoldOut(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, 400, "Invalid id")
+		return
+	}
+
+	var req ReqSoldOut
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			util.SendError(w, 400, "Invalid JSON")
+			return
+		}
+	}
+
+	item, err := h.svc.MarkSoldOut(id, req.Until)
+	if err != nil {
+		sendSoldOutError(w, err)
+		return
+	}
+	util.SendData(w, 200, item)
+}
+
+// DELETE /restaurant/items/{id}/sold-out
+func (h *Handler) Restock(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, 400, "Invalid id")
+		return
+	}
+
+	item, err := h.svc.Restock(id)
+	if err != nil {
+		sendSoldOutError(w, err)
+		return
+	}
+	util.SendData(w, 200, item)
+}
+
+func sendSoldOutError(w http.ResponseWriter, err error) {
+	if errors.Is(err, domain.ErrMenuItemNotFound) {
+		util.SendError(w, 404, err.Error())
+		return
+	}
+	util.SendError(w, 500, "Failed to update item")
+}
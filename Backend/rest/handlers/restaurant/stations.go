@@ -0,0 +1,93 @@
+package restaurant
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"oasis/backend/domain"
+	"oasis/backend/util"
+	"strconv"
+	"strings"
+)
+
+type ReqStation struct {
+	Station string `json:"station"` // GRILL, COLD, BAR
+}
+
+// GET /restaurant/stations
+func (h *Handler) GetStations(w http.ResponseWriter, r *http.Request) {
+	util.SendData(w, 200, domain.KitchenStations())
+}
+
+// PUT /restaurant/categories/{id}/station
+func (h *Handler) SetCategoryStation(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, 400, "Invalid id")
+		return
+	}
+
+	var req ReqStation
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.SendError(w, 400, "Invalid JSON")
+		return
+	}
+
+	err = h.svc.SetCategoryStation(id, strings.ToUpper(req.Station))
+	if err != nil {
+		sendKitchenError(w, err)
+		return
+	}
+	util.SendData(w, 200, "Station Updated")
+}
+
+// POST /restaurant/orders/{id}/items/{lineId}/bump
+// The station marks one line ready; the order turns READY with its last line
+func (h *Handler) BumpItem(w http.ResponseWriter, r *http.Request) {
+	h.bump(w, r, true)
+}
+
+// DELETE /restaurant/orders/{id}/items/{lineId}/bump
+// Recalls a line bumped by mistake
+func (h *Handler) RecallItem(w http.ResponseWriter, r *http.Request) {
+	h.bump(w, r, false)
+}
+
+func (h *Handler) bump(w http.ResponseWriter, r *http.Request, ready bool) {
+	orderID, err1 := strconv.Atoi(r.PathValue("id"))
+	lineID, err2 := strconv.Atoi(r.PathValue("lineId"))
+	if err1 != nil || err2 != nil {
+		util.SendError(w, 400, "Invalid id")
+		return
+	}
+
+	ticket, err := h.svc.BumpItem(orderID, lineID, ready, util.ActorFromRequest(r))
+	if err != nil {
+		sendKitchenError(w, err)
+		return
+	}
+	util.SendData(w, 200, ticket)
+}
+
+func sendKitchenError(w http.ResponseWriter, err error) {
+	var invalid *domain.OrderValidationError
+	var warning *domain.AllergenWarning
+	switch {
+	case errors.As(err, &invalid), errors.As(err, &warning), errors.Is(err, domain.ErrEmptyOrder):
+		sendOrderError(w, err) // Order edits answer like a new order
+	case errors.Is(err, domain.ErrInvalidStation), errors.Is(err, domain.ErrInvalidOrderStatus),
+		errors.Is(err, domain.ErrCancelReasonRequired), errors.Is(err, domain.ErrInvalidLineAdjustment):
+		util.SendError(w, 400, err.Error())
+	case errors.Is(err, domain.ErrOrderNotFound), errors.Is(err, domain.ErrOrderItemNotFound),
+		errors.Is(err, domain.ErrCategoryNotFound):
+		util.SendError(w, 404, err.Error())
+	case errors.Is(err, domain.ErrIllegalOrderTransition), errors.Is(err, domain.ErrOrderStatusChanged),
+		errors.Is(err, domain.ErrOrderLeftKitchen), errors.Is(err, domain.ErrOrderNotEditable),
+		errors.Is(err, domain.ErrCancelTooLate), errors.Is(err, domain.ErrNoCancelRequest),
+		errors.Is(err, domain.ErrOrderClosed), errors.Is(err, domain.ErrLineAlreadyAdjusted),
+		errors.Is(err, domain.ErrLineNotAdjusted):
+		util.SendError(w, 409, err.Error())
+	default:
+		util.SendError(w, 500, "Error")
+	}
+}
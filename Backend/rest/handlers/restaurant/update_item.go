@@ -22,7 +22,7 @@ func (h *Handler) UpdateItem(w http.ResponseWriter, r *http.Request) {
 
 	err := h.svc.UpdateMenuItem(&item)
 	if err != nil {
-		util.SendError(w, 500, "Failed to update")
+		sendItemError(w, err, "Failed to update")
 		return
 	}
 	util.SendData(w, 200, "Item Updated")
@@ -6,17 +6,38 @@ import (
 	"strconv"
 )
 
-// PATCH /restaurant/orders/{id}/status (Staff Only)
+// PATCH /restaurant/orders/{id}/status?status=CANCELLED&reason=... (Staff Only)
+// RECEIVED -> PREPARING -> READY -> OUT_FOR_DELIVERY -> DELIVERED; cancelling needs a reason
 func (h *Handler) UpdateStatus(w http.ResponseWriter, r *http.Request) {
-	idStr := r.PathValue("id")
-	id, _ := strconv.Atoi(idStr)
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, 400, "Invalid id")
+		return
+	}
 	status := r.URL.Query().Get("status")
+	reason := r.URL.Query().Get("reason")
 
-	err := h.svc.UpdateStatus(id, status)
+	order, err := h.svc.UpdateStatus(id, status, reason, util.ActorFromRequest(r))
 	if err != nil {
-		util.SendError(w, 500, "Error")
+		sendKitchenError(w, err)
 		return
 	}
-	util.SendData(w, 200, "Status Updated")
+	util.SendData(w, 200, order)
 }
 
+// GET /restaurant/orders/{id}/track
+// The order with its status history and ETA; live updates come as ORDER_STATUS on /ws/guest
+func (h *Handler) TrackOrder(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, 400, "Invalid id")
+		return
+	}
+
+	tracking, err := h.svc.TrackOrder(id, util.ActorFromRequest(r))
+	if err != nil {
+		sendKitchenError(w, err)
+		return
+	}
+	util.SendData(w, 200, tracking)
+}
@@ -0,0 +1,73 @@
+package room
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"oasis/backend/domain"
+	"oasis/backend/util"
+)
+
+// ReqRoom is the payload for creating or editing a room
+type ReqRoom struct {
+	RoomNumber   string   `json:"room_number"`
+	RoomTypeID   int      `json:"room_type_id"`
+	Price        float64  `json:"price"` // Optional override, 0 = type base rate
+	Floor        int      `json:"floor"`
+	Features     []string `json:"features"`
+	MaxOccupancy int      `json:"max_occupancy"`
+}
+
+func (req ReqRoom) validate() string {
+	if req.RoomTypeID <= 0 {
+		return "Room type is required"
+	}
+	if req.Price < 0 {
+		return "Price must not be negative"
+	}
+	if req.MaxOccupancy < 0 {
+		return "Max occupancy must not be negative"
+	}
+	return ""
+}
+
+func (req ReqRoom) toDomain() domain.Room {
+	features := req.Features
+	if features == nil {
+		features = []string{}
+	}
+	return domain.Room{
+		RoomNumber:   req.RoomNumber,
+		RoomTypeID:   req.RoomTypeID,
+		Price:        req.Price,
+		Floor:        req.Floor,
+		Features:     features,
+		MaxOccupancy: req.MaxOccupancy,
+	}
+}
+
+// POST /rooms
+func (h *Handler) CreateRoom(w http.ResponseWriter, r *http.Request) {
+	var req ReqRoom
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.RoomNumber == "" {
+		util.SendError(w, http.StatusBadRequest, "Room number is required")
+		return
+	}
+	if msg := req.validate(); msg != "" {
+		util.SendError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	rm, err := h.svc.Create(req.toDomain())
+	if err != nil {
+		sendRoomError(w, err)
+		return
+	}
+
+	util.SendData(w, http.StatusCreated, rm)
+}
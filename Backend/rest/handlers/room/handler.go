@@ -2,16 +2,19 @@ package room
 
 import (
 	"oasis/backend/config"
+	middleware "oasis/backend/rest/middlewares"
 )
 
 type Handler struct {
-	cnf *config.Config
-	svc Service
+	cnf         *config.Config
+	middlewares *middleware.Middlewares
+	svc         Service
 }
 
-func NewHandler(cnf *config.Config, svc Service) *Handler {
+func NewHandler(cnf *config.Config, middlewares *middleware.Middlewares, svc Service) *Handler {
 	return &Handler{
-		cnf: cnf,
-		svc: svc,
+		cnf:         cnf,
+		middlewares: middlewares,
+		svc:         svc,
 	}
 }
@@ -0,0 +1,54 @@
+package room
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"oasis/backend/util"
+)
+
+type ReqOutOfOrder struct {
+	From   string `json:"from"` // Format: "2025-12-03"
+	To     string `json:"to"`   // Format: "2025-12-05"
+	Reason string `json:"reason"`
+}
+
+// PATCH /rooms/{number}/out-of-order
+func (h *Handler) SetOutOfOrder(w http.ResponseWriter, r *http.Request) {
+	var req ReqOutOfOrder
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	from, err := time.Parse("2006-01-02", req.From)
+	if err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid from date format. Use YYYY-MM-DD")
+		return
+	}
+	to, err := time.Parse("2006-01-02", req.To)
+	if err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid to date format. Use YYYY-MM-DD")
+		return
+	}
+
+	err = h.svc.SetOutOfOrder(r.PathValue("number"), from, to, req.Reason)
+	if err != nil {
+		sendRoomError(w, err)
+		return
+	}
+
+	util.SendData(w, http.StatusOK, "Room marked out of order")
+}
+
+// DELETE /rooms/{number}/out-of-order
+func (h *Handler) ClearOutOfOrder(w http.ResponseWriter, r *http.Request) {
+	err := h.svc.ClearOutOfOrder(r.PathValue("number"))
+	if err != nil {
+		sendRoomError(w, err)
+		return
+	}
+
+	util.SendData(w, http.StatusOK, "Room back in service")
+}
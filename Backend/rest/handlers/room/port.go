@@ -1,6 +1,10 @@
 package room
 
-import "oasis/backend/domain"
+import (
+	"time"
+
+	"oasis/backend/domain"
+)
 
 type Service interface {
 	Create(room domain.Room) (*domain.Room, error)
@@ -8,4 +12,18 @@ type Service interface {
 	FindByID(id string) (*domain.Room, error)
     // Add this line:
 	GetAll(status string) ([]domain.Room, error)
+	CheckAvailability(roomNumber string, from, to time.Time, excludeGuestID, groupID int) (*domain.Room, error)
+
+	// Inventory management (Manager only)
+	Update(room domain.Room) (*domain.Room, error)
+	SetOutOfOrder(roomNumber string, from, to time.Time, reason string) error
+	ClearOutOfOrder(roomNumber string) error
+	Retire(roomNumber string) error
+
+	// Room Types (Guest-facing listing + Manager CRUD)
+	GetRoomTypes() ([]domain.RoomType, error)
+	GetRoomType(id int) (*domain.RoomType, error)
+	CreateRoomType(rt domain.RoomType) (*domain.RoomType, error)
+	UpdateRoomType(rt domain.RoomType) (*domain.RoomType, error)
+	SearchAvailability(checkIn, checkOut time.Time, guests int) ([]domain.RoomTypeAvailability, error)
 }
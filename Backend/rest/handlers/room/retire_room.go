@@ -0,0 +1,36 @@
+package room
+
+import (
+	"errors"
+	"net/http"
+
+	"oasis/backend/domain"
+	"oasis/backend/util"
+)
+
+// DELETE /rooms/{number}
+// Rooms are never hard deleted: they are retired so old invoices still resolve.
+func (h *Handler) RetireRoom(w http.ResponseWriter, r *http.Request) {
+	err := h.svc.Retire(r.PathValue("number"))
+	if err != nil {
+		sendRoomError(w, err)
+		return
+	}
+
+	util.SendData(w, http.StatusOK, "Room retired")
+}
+
+// sendRoomError maps the room domain errors to HTTP status codes
+func sendRoomError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrRoomNotFound), errors.Is(err, domain.ErrRoomTypeNotFound):
+		util.SendError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrRoomOccupied), errors.Is(err, domain.ErrRoomRetired),
+		errors.Is(err, domain.ErrRoomExists), errors.Is(err, domain.ErrRoomTypeExists):
+		util.SendError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, domain.ErrInvalidWindow), errors.Is(err, domain.ErrInvalidStayDates):
+		util.SendError(w, http.StatusBadRequest, err.Error())
+	default:
+		util.SendError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
@@ -0,0 +1,139 @@
+package room
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"oasis/backend/domain"
+	"oasis/backend/util"
+)
+
+// ReqRoomType is the payload for creating or editing a room type
+type ReqRoomType struct {
+	Name             string   `json:"name"`
+	Description      string   `json:"description"`
+	BaseOccupancy    int      `json:"base_occupancy"`
+	MaxOccupancy     int      `json:"max_occupancy"`
+	BedConfiguration string   `json:"bed_configuration"`
+	Amenities        []string `json:"amenities"`
+	Photos           []string `json:"photos"`
+	BaseRate         float64  `json:"base_rate"`
+	CleaningCredits  float64  `json:"cleaning_credits"` // Defaults to 1
+}
+
+func (req ReqRoomType) validate() string {
+	if req.Name == "" {
+		return "Name is required"
+	}
+	if req.BaseRate <= 0 {
+		return "Base rate must be greater than zero"
+	}
+	if req.BaseOccupancy < 1 || req.MaxOccupancy < req.BaseOccupancy {
+		return "Occupancy must be at least 1 and max occupancy must cover base occupancy"
+	}
+	return ""
+}
+
+func (req ReqRoomType) toDomain() domain.RoomType {
+	amenities, photos := req.Amenities, req.Photos
+	if amenities == nil {
+		amenities = []string{}
+	}
+	if photos == nil {
+		photos = []string{}
+	}
+	credits := req.CleaningCredits
+	if credits <= 0 {
+		credits = 1
+	}
+	return domain.RoomType{
+		Name:             req.Name,
+		Description:      req.Description,
+		BaseOccupancy:    req.BaseOccupancy,
+		MaxOccupancy:     req.MaxOccupancy,
+		BedConfiguration: req.BedConfiguration,
+		Amenities:        amenities,
+		Photos:           photos,
+		BaseRate:         req.BaseRate,
+		CleaningCredits:  credits,
+	}
+}
+
+// GET /room-types (Guest-facing room listing)
+func (h *Handler) GetRoomTypes(w http.ResponseWriter, r *http.Request) {
+	types, err := h.svc.GetRoomTypes()
+	if err != nil {
+		util.SendError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	if types == nil {
+		types = []domain.RoomType{}
+	}
+	util.SendData(w, http.StatusOK, types)
+}
+
+// GET /room-types/{id}
+func (h *Handler) GetRoomType(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid room type id")
+		return
+	}
+
+	rt, err := h.svc.GetRoomType(id)
+	if err != nil {
+		sendRoomError(w, err)
+		return
+	}
+	util.SendData(w, http.StatusOK, rt)
+}
+
+// POST /room-types
+func (h *Handler) CreateRoomType(w http.ResponseWriter, r *http.Request) {
+	var req ReqRoomType
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if msg := req.validate(); msg != "" {
+		util.SendError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	rt, err := h.svc.CreateRoomType(req.toDomain())
+	if err != nil {
+		sendRoomError(w, err)
+		return
+	}
+	util.SendData(w, http.StatusCreated, rt)
+}
+
+// PUT /room-types/{id}
+func (h *Handler) UpdateRoomType(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid room type id")
+		return
+	}
+
+	var req ReqRoomType
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if msg := req.validate(); msg != "" {
+		util.SendError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	rt := req.toDomain()
+	rt.ID = id // Ensure ID matches URL
+
+	updated, err := h.svc.UpdateRoomType(rt)
+	if err != nil {
+		sendRoomError(w, err)
+		return
+	}
+	util.SendData(w, http.StatusOK, updated)
+}
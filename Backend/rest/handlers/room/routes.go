@@ -1,6 +1,7 @@
 package room
 
 import (
+	"oasis/backend/domain"
 	middleware "oasis/backend/rest/middlewares"
 	"net/http"
 )
@@ -15,12 +16,88 @@ func (h *Handler) RegisterRoutes(mux *http.ServeMux, manager *middleware.Manager
 		),
 	)
 
-    // TODO: Implement CreateRoom handler
-    // Usage: POST /rooms (To add new inventory)
-    // mux.Handle(
-	// 	"POST /rooms",
-	// 	manager.With(
-	// 		http.HandlerFunc(h.CreateRoom),
-	// 	),
-	// )
+	// Guest-facing search: which room types are free for these dates
+	// Usage: GET /rooms/availability?check_in=2025-12-03&check_out=2025-12-05&guests=2
+	mux.Handle(
+		"GET /rooms/availability",
+		manager.With(
+			http.HandlerFunc(h.SearchAvailability),
+		),
+	)
+
+	// Guest-facing room listing
+	mux.Handle(
+		"GET /room-types",
+		manager.With(
+			http.HandlerFunc(h.GetRoomTypes),
+		),
+	)
+	mux.Handle(
+		"GET /room-types/{id}",
+		manager.With(
+			http.HandlerFunc(h.GetRoomType),
+		),
+	)
+
+	// Inventory management (Manager only)
+	managerOnly := h.middlewares.AuthorizeRoles(domain.StaffRoleManager, domain.StaffRoleAdmin)
+
+	// Usage: POST /rooms (To add new inventory)
+	mux.Handle(
+		"POST /rooms",
+		manager.With(
+			http.HandlerFunc(h.CreateRoom),
+			managerOnly,
+			h.middlewares.AuthinticateJWT,
+		),
+	)
+	mux.Handle(
+		"PUT /rooms/{number}",
+		manager.With(
+			http.HandlerFunc(h.UpdateRoom),
+			managerOnly,
+			h.middlewares.AuthinticateJWT,
+		),
+	)
+	mux.Handle(
+		"PATCH /rooms/{number}/out-of-order",
+		manager.With(
+			http.HandlerFunc(h.SetOutOfOrder),
+			managerOnly,
+			h.middlewares.AuthinticateJWT,
+		),
+	)
+	mux.Handle(
+		"DELETE /rooms/{number}/out-of-order",
+		manager.With(
+			http.HandlerFunc(h.ClearOutOfOrder),
+			managerOnly,
+			h.middlewares.AuthinticateJWT,
+		),
+	)
+	mux.Handle(
+		"DELETE /rooms/{number}",
+		manager.With(
+			http.HandlerFunc(h.RetireRoom),
+			managerOnly,
+			h.middlewares.AuthinticateJWT,
+		),
+	)
+
+	mux.Handle(
+		"POST /room-types",
+		manager.With(
+			http.HandlerFunc(h.CreateRoomType),
+			managerOnly,
+			h.middlewares.AuthinticateJWT,
+		),
+	)
+	mux.Handle(
+		"PUT /room-types/{id}",
+		manager.With(
+			http.HandlerFunc(h.UpdateRoomType),
+			managerOnly,
+			h.middlewares.AuthinticateJWT,
+		),
+	)
 }
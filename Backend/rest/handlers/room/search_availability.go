@@ -0,0 +1,45 @@
+package room
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"oasis/backend/domain"
+	"oasis/backend/util"
+)
+
+// GET /rooms/availability?check_in=2025-12-03&check_out=2025-12-05&guests=2
+func (h *Handler) SearchAvailability(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	checkIn, err := time.Parse("2006-01-02", query.Get("check_in"))
+	if err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid check-in date format. Use YYYY-MM-DD")
+		return
+	}
+	checkOut, err := time.Parse("2006-01-02", query.Get("check_out"))
+	if err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid check-out date format. Use YYYY-MM-DD")
+		return
+	}
+
+	guests := 1
+	if g := query.Get("guests"); g != "" {
+		guests, err = strconv.Atoi(g)
+		if err != nil || guests < 1 {
+			util.SendError(w, http.StatusBadRequest, "Guests must be a positive number")
+			return
+		}
+	}
+
+	result, err := h.svc.SearchAvailability(checkIn, checkOut, guests)
+	if err != nil {
+		sendRoomError(w, err)
+		return
+	}
+	if result == nil {
+		result = []domain.RoomTypeAvailability{}
+	}
+	util.SendData(w, http.StatusOK, result)
+}
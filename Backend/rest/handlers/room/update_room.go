@@ -0,0 +1,31 @@
+package room
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"oasis/backend/util"
+)
+
+// PUT /rooms/{number}
+func (h *Handler) UpdateRoom(w http.ResponseWriter, r *http.Request) {
+	var req ReqRoom
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		util.SendError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	req.RoomNumber = r.PathValue("number") // Ensure number matches URL
+
+	if msg := req.validate(); msg != "" {
+		util.SendError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	rm, err := h.svc.Update(req.toDomain())
+	if err != nil {
+		sendRoomError(w, err)
+		return
+	}
+
+	util.SendData(w, http.StatusOK, rm)
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"oasis/backend/util"
+)
+
+// AuthorizeRoles only lets staff tokens with one of the given roles through.
+// It must run after AuthinticateJWT, which verifies the signature.
+func (m *Middlewares) AuthorizeRoles(roles ...string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tokenString := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			claims, err := util.ParseJwtClaims(tokenString)
+			if err != nil || !claims.IsStaff() {
+				http.Error(w, "Forbidden", http.StatusForbidden)
+				return
+			}
+
+			for _, role := range roles {
+				if claims.Role() == role {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+			http.Error(w, "Forbidden", http.StatusForbidden)
+		})
+	}
+}
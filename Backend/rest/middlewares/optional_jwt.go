@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"net/http"
+	"strings"
+)
+
+// OptionalJWT lets anonymous callers through but drops a token whose signature does not verify,
+// so handlers that read the token (util.ActorFromRequest) only ever see one we issued.
+func (m *Middlewares) OptionalJWT(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+		if header != "" && !m.validToken(strings.TrimPrefix(header, "Bearer ")) {
+			r.Header.Del("Authorization")
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// validToken checks the HS256 signature the same way AuthinticateJWT does
+func (m *Middlewares) validToken(accessToken string) bool {
+	tokenParts := strings.Split(accessToken, ".")
+	if len(tokenParts) != 3 {
+		return false
+	}
+
+	h := hmac.New(sha256.New, []byte(m.cnf.JwtSecretKey))
+	h.Write([]byte(tokenParts[0] + "." + tokenParts[1]))
+	return base64UrlEncode(h.Sum(nil)) == tokenParts[2]
+}
@@ -0,0 +1,16 @@
+package middleware
+
+import "net/http"
+
+// TokenFromQuery copies ?token= into the Authorization header.
+// Browsers cannot set headers on WebSocket handshakes, so they pass the JWT in the URL instead.
+func (m *Middlewares) TokenFromQuery(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			if token := r.URL.Query().Get("token"); token != "" {
+				r.Header.Set("Authorization", "Bearer "+token)
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -7,10 +7,13 @@ import (
 	"strconv"
 
 	"oasis/backend/config"
+	"oasis/backend/rest/handlers/attachment"
+	"oasis/backend/rest/handlers/group"
 	"oasis/backend/rest/handlers/guest"
 	"oasis/backend/rest/handlers/housekeeping"
 	"oasis/backend/rest/handlers/invoice"
 	"oasis/backend/rest/handlers/laundry"
+	"oasis/backend/rest/handlers/lostfound"
 	raghandler "oasis/backend/rest/handlers/rag"
 	"oasis/backend/rest/handlers/restaurant"
 	"oasis/backend/rest/handlers/room"
@@ -27,6 +30,9 @@ type Server struct {
 	restaurantHandler   *restaurant.Handler
 	housekeepingHandler *housekeeping.Handler
 	invoiceHandler      *invoice.Handler
+	groupHandler        *group.Handler
+	attachmentHandler   *attachment.Handler
+	lostFoundHandler    *lostfound.Handler
 	ragHandler          *raghandler.Handler
 }
 
@@ -39,6 +45,9 @@ func NewServer(
 	restaurantHandler *restaurant.Handler,
 	housekeepingHandler *housekeeping.Handler,
 	invoiceHandler *invoice.Handler,
+	groupHandler *group.Handler,
+	attachmentHandler *attachment.Handler,
+	lostFoundHandler *lostfound.Handler,
 	ragHandler *raghandler.Handler,
 ) *Server {
 	return &Server{
@@ -50,6 +59,9 @@ func NewServer(
 		restaurantHandler:   restaurantHandler,
 		housekeepingHandler: housekeepingHandler,
 		invoiceHandler:      invoiceHandler,
+		groupHandler:        groupHandler,
+		attachmentHandler:   attachmentHandler,
+		lostFoundHandler:    lostFoundHandler,
 		ragHandler:          ragHandler,
 	}
 }
@@ -75,6 +87,9 @@ func (server *Server) Start() {
 	server.restaurantHandler.RegisterRoutes(mux, manager)
 	server.housekeepingHandler.RegisterRoutes(mux, manager)
 	server.invoiceHandler.RegisterRoutes(mux, manager)
+	server.groupHandler.RegisterRoutes(mux, manager)
+	server.attachmentHandler.RegisterRoutes(mux, manager)
+	server.lostFoundHandler.RegisterRoutes(mux, manager)
 	server.ragHandler.RegisterRoutes(mux, manager)
 
 	addr := ":" + strconv.Itoa(server.cnf.HttpPort)
@@ -0,0 +1,179 @@
+package restaurant
+
+import (
+	"strings"
+	"time"
+
+	"oasis/backend/domain"
+)
+
+// guestCancelReason is logged when a guest cancels without saying why
+const guestCancelReason = "Cancelled by guest"
+
+// allVoidedReason is logged when a manager voids every line of an order
+const allVoidedReason = "Every line voided"
+
+// ModifyOrder replaces the lines and notes of an order the kitchen has not started.
+// The new cart is validated and priced like a new order, allergy warning included.
+func (s *service) ModifyOrder(orderID int, notes string, items []domain.RestaurantOrderItemInput, acknowledgeAllergens bool, actor domain.Actor) (*domain.OrderWithItems, error) {
+	if len(items) == 0 {
+		return nil, domain.ErrEmptyOrder
+	}
+	order, err := s.findOrder(orderID, actor)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != domain.OrderStatusReceived {
+		return nil, domain.ErrOrderNotEditable
+	}
+
+	conflicts, err := s.allergenConflicts(order.GuestID, items)
+	if err != nil {
+		return nil, err
+	}
+	if len(conflicts) > 0 && !acknowledgeAllergens {
+		return nil, &domain.AllergenWarning{Conflicts: conflicts}
+	}
+
+	order.Notes = notes
+	order.AllergyAlert = len(conflicts) > 0
+	order.Allergens = domain.ConflictingAllergens(conflicts)
+	if err := s.repo.ReplaceOrderItems(order, items, time.Now()); err != nil {
+		return nil, err
+	}
+	return s.orderChanged(order.ID)
+}
+
+// CancelOrder cancels an order the kitchen has not started.
+// Once it is PREPARING the kitchen can still cancel it, but a guest only files a request the kitchen approves.
+func (s *service) CancelOrder(orderID int, reason string, actor domain.Actor) (*domain.Order, error) {
+	order, err := s.findOrder(orderID, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	reason = strings.TrimSpace(reason)
+	byGuest := actor.Role == domain.ActorRoleGuest
+	if byGuest && reason == "" {
+		reason = guestCancelReason
+	}
+
+	switch {
+	case order.Status.Closed():
+		return nil, domain.ErrOrderClosed
+	case order.Status == domain.OrderStatusReceived,
+		order.Status == domain.OrderStatusPreparing && !byGuest:
+		return s.transition(order, domain.OrderStatusCancelled, reason, actor)
+	case order.Status == domain.OrderStatusPreparing:
+		now := time.Now()
+		if err := s.repo.RequestCancel(order.ID, reason, now); err != nil {
+			return nil, err
+		}
+		order.CancelRequestedAt = &now
+		order.CancelRequestReason = &reason
+		order.UpdatedAt = &now
+
+		s.hub.BroadcastToStaff("ORDER_CANCEL_REQUESTED", order)
+		s.notifyGuest(order)
+		return order, nil
+	default:
+		return nil, domain.ErrCancelTooLate
+	}
+}
+
+// ResolveCancelRequest lets the kitchen approve a guest's cancellation, or turn it down and keep cooking
+func (s *service) ResolveCancelRequest(orderID int, approve bool, actor domain.Actor) (*domain.Order, error) {
+	order, err := s.repo.FindOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		return nil, domain.ErrOrderNotFound
+	}
+	if order.CancelRequestedAt == nil {
+		return nil, domain.ErrNoCancelRequest
+	}
+
+	if approve {
+		reason := guestCancelReason
+		if order.CancelRequestReason != nil {
+			reason = *order.CancelRequestReason
+		}
+		return s.transition(order, domain.OrderStatusCancelled, reason, actor)
+	}
+
+	if err := s.repo.ClearCancelRequest(order.ID); err != nil {
+		return nil, err
+	}
+	order.CancelRequestedAt = nil
+	order.CancelRequestReason = nil
+
+	s.hub.BroadcastToStaff("ORDER_CANCEL_DECLINED", order)
+	s.notifyGuest(order)
+	return order, nil
+}
+
+// AdjustLine comps or voids one line with a reason code. The line leaves the bill
+// (TotalPrice, and so the invoice); a voided line also leaves the kitchen ticket.
+func (s *service) AdjustLine(orderID, lineID int, adj domain.LineAdjustment, actor domain.Actor) (*domain.OrderWithItems, error) {
+	if err := adj.Normalize(); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetLineAdjustment(orderID, lineID, &adj, actor); err != nil {
+		return nil, err
+	}
+
+	ticket, err := s.orderChanged(orderID)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	// Nothing left to cook or bill: the order is over
+	case ticket.Status.CanTransitionTo(domain.OrderStatusCancelled) && ticket.AllVoided():
+		if _, err := s.transition(&ticket.Order, domain.OrderStatusCancelled, allVoidedReason, actor); err != nil {
+			return nil, err
+		}
+	// Voiding the last line still being made finishes the order
+	case ticket.Status == domain.OrderStatusPreparing && ticket.AllReady():
+		if _, err := s.transition(&ticket.Order, domain.OrderStatusReady, "", actor); err != nil {
+			return nil, err
+		}
+	}
+	return ticket, nil
+}
+
+// RemoveLineAdjustment puts a comped or voided line back on the bill
+func (s *service) RemoveLineAdjustment(orderID, lineID int, actor domain.Actor) (*domain.OrderWithItems, error) {
+	if err := s.repo.SetLineAdjustment(orderID, lineID, nil, actor); err != nil {
+		return nil, err
+	}
+	return s.orderChanged(orderID)
+}
+
+// findOrder loads an order; guests must not learn about other guests' orders
+func (s *service) findOrder(orderID int, actor domain.Actor) (*domain.Order, error) {
+	order, err := s.repo.FindOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil || (actor.Role == domain.ActorRoleGuest && order.GuestID != actor.ID) {
+		return nil, domain.ErrOrderNotFound
+	}
+	return order, nil
+}
+
+// orderChanged reloads the ticket after its lines changed and pushes it to the kitchen and the guest
+func (s *service) orderChanged(orderID int) (*domain.OrderWithItems, error) {
+	ticket, err := s.repo.FindKitchenOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if ticket == nil {
+		return nil, domain.ErrOrderNotFound
+	}
+	ticket.StampTimer(time.Now(), s.lateAfter)
+
+	s.hub.BroadcastToStaff("ORDER_UPDATED", ticket)
+	s.notifyGuest(&ticket.Order)
+	return ticket, nil
+}
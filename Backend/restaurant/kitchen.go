@@ -0,0 +1,116 @@
+package restaurant
+
+import (
+	"fmt"
+	"time"
+
+	"oasis/backend/domain"
+)
+
+// BumpItem marks one line ready at its station (ready=false recalls it).
+// The first bump starts the order (PREPARING); once every line is ready it is READY for pickup.
+func (s *service) BumpItem(orderID, lineID int, ready bool, actor domain.Actor) (*domain.OrderWithItems, error) {
+	order, err := s.repo.FindOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		return nil, domain.ErrOrderNotFound
+	}
+	if order.Status != domain.OrderStatusReceived && order.Status != domain.OrderStatusPreparing {
+		return nil, domain.ErrOrderLeftKitchen
+	}
+
+	status := domain.OrderItemPending
+	if ready {
+		status = domain.OrderItemReady
+	}
+	now := time.Now()
+	found, err := s.repo.SetItemStatus(orderID, lineID, status, now)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, domain.ErrOrderItemNotFound
+	}
+
+	ticket, err := s.repo.FindKitchenOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if ticket == nil {
+		return nil, domain.ErrOrderNotFound
+	}
+
+	for _, item := range ticket.Items {
+		if item.ID == lineID {
+			s.hub.BroadcastToStaff("ORDER_ITEM_STATUS", item)
+		}
+	}
+
+	if ready && ticket.Status == domain.OrderStatusReceived {
+		if _, err := s.transition(&ticket.Order, domain.OrderStatusPreparing, "", actor); err != nil {
+			return nil, err
+		}
+	}
+	if ticket.AllReady() && ticket.Status == domain.OrderStatusPreparing {
+		if _, err := s.transition(&ticket.Order, domain.OrderStatusReady, "", actor); err != nil {
+			return nil, err
+		}
+	}
+
+	ticket.StampTimer(now, s.lateAfter)
+	return ticket, nil
+}
+
+// SetCategoryStation routes the category's items to another station (items with their own station keep it)
+func (s *service) SetCategoryStation(categoryID int, station string) error {
+	if !domain.ValidStation(station) {
+		return domain.ErrInvalidStation
+	}
+	found, err := s.repo.SetCategoryStation(categoryID, station)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return domain.ErrCategoryNotFound
+	}
+	return nil
+}
+
+// FlagLateOrders alerts the kitchen once about every ticket older than the threshold
+func (s *service) FlagLateOrders() (int, error) {
+	if s.lateAfter <= 0 {
+		return 0, nil
+	}
+	now := time.Now()
+	late, err := s.repo.FetchLateOrders(now.Add(-s.lateAfter))
+	if err != nil {
+		return 0, err
+	}
+
+	for _, order := range late {
+		if err := s.repo.MarkLateAlerted(order.ID, now); err != nil {
+			return 0, err
+		}
+		s.hub.BroadcastToStaff("ORDER_LATE", map[string]interface{}{
+			"order_id":    order.ID,
+			"room_number": order.RoomNumber,
+			"status":      order.Status,
+			"age_minutes": int(now.Sub(order.CreatedAt).Minutes()),
+		})
+	}
+	return len(late), nil
+}
+
+// RunTicketTimer runs FlagLateOrders periodically (start it in a goroutine)
+func (s *service) RunTicketTimer(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for ; ; <-ticker.C {
+		if _, err := s.FlagLateOrders(); err != nil {
+			fmt.Println("Failed to flag late orders:", err)
+		}
+	}
+}
@@ -1,29 +1,66 @@
 package restaurant
 
-import "oasis/backend/domain"
+import (
+	"oasis/backend/domain"
+	"time"
+)
 
 // Service Port
 type Service interface {
-	GetFullMenu() ([]domain.CategoryWithItems, error)
-	PlaceOrder(guestID int, roomNumber, notes string, items []domain.RestaurantOrderItemInput) (*domain.Order, error)
+	GetFullMenu(filter domain.MenuFilter) ([]domain.CategoryWithItems, error)
+	PlaceOrder(guestID int, roomNumber, notes string, items []domain.RestaurantOrderItemInput, acknowledgeAllergens bool) (*domain.Order, error)
 	GetGuestOrders(guestID int) ([]domain.Order, error)
-	UpdateStatus(orderID int, status string) error // For Kitchen Staff
-	GetKitchenOrders() ([]domain.OrderWithItems, error)
+	UpdateStatus(orderID int, status, reason string, actor domain.Actor) (*domain.Order, error) // For Kitchen Staff
+	TrackOrder(orderID int, actor domain.Actor) (*domain.OrderTracking, error)
+	ModifyOrder(orderID int, notes string, items []domain.RestaurantOrderItemInput, acknowledgeAllergens bool, actor domain.Actor) (*domain.OrderWithItems, error)
+	CancelOrder(orderID int, reason string, actor domain.Actor) (*domain.Order, error)
+	ResolveCancelRequest(orderID int, approve bool, actor domain.Actor) (*domain.Order, error)
+	AdjustLine(orderID, lineID int, adj domain.LineAdjustment, actor domain.Actor) (*domain.OrderWithItems, error)
+	RemoveLineAdjustment(orderID, lineID int, actor domain.Actor) (*domain.OrderWithItems, error)
+	GetKitchenOrders(station string) ([]domain.OrderWithItems, error)
 	AddMenuItem(item *domain.RestaurantMenuItem) error
 	UpdateMenuItem(item *domain.RestaurantMenuItem) error
 	RemoveMenuItem(id int) error
+	SetModifierGroups(itemID int, groups []domain.ModifierGroup) ([]domain.ModifierGroup, error)
+	GetServicePeriods() ([]domain.ServicePeriod, error)
+	SetCategoryPeriods(categoryID int, periods []domain.ServicePeriod) ([]domain.ServicePeriod, error)
+	SetItemPeriods(itemID int, periods []domain.ServicePeriod) ([]domain.ServicePeriod, error)
+	MarkSoldOut(itemID int, until *time.Time) (*domain.RestaurantMenuItem, error)
+	Restock(itemID int) (*domain.RestaurantMenuItem, error)
+	BumpItem(orderID, lineID int, ready bool, actor domain.Actor) (*domain.OrderWithItems, error)
+	SetCategoryStation(categoryID int, station string) error
+	RunTicketTimer(interval time.Duration)
 }
 
 // Repository Port
 type Repository interface {
 	FetchCategories() ([]domain.MenuCategory, error)
 	FetchAllItems() ([]domain.RestaurantMenuItem, error)
+	FetchItems(ids []int) (map[int]domain.RestaurantMenuItem, error)
 	SaveOrder(order *domain.Order, items []domain.RestaurantOrderItemInput) error
 	FetchOrdersByGuest(guestID int) ([]domain.Order, error)
-	UpdateStatus(orderID int, status string) error
+	FindOrder(orderID int) (*domain.Order, error)
+	UpdateStatus(orderID int, from, to domain.OrderStatus, reason string, actor domain.Actor) error
+	FetchOrderEvents(orderID int) ([]domain.OrderEvent, error)
+	CountOrdersAhead(orderID int) (int, error)
+	ReplaceOrderItems(order *domain.Order, items []domain.RestaurantOrderItemInput, at time.Time) error
+	RequestCancel(orderID int, reason string, at time.Time) error
+	ClearCancelRequest(orderID int) error
+	SetLineAdjustment(orderID, lineID int, adj *domain.LineAdjustment, actor domain.Actor) error
 	FetchActiveOrders() ([]domain.OrderWithItems, error)
 	CreateItem(item *domain.RestaurantMenuItem) error
 	UpdateItem(item *domain.RestaurantMenuItem) error
 	DeleteItem(id int) error
+	FindItem(id int) (*domain.RestaurantMenuItem, error)
+	FetchModifierGroups(itemIDs []int) (map[int][]domain.ModifierGroup, error)
+	ReplaceModifierGroups(itemID int, groups []domain.ModifierGroup) error
+	FetchServicePeriods() ([]domain.ServicePeriod, error)
+	ReplaceServicePeriods(categoryID, itemID *int, periods []domain.ServicePeriod) error
+	SetSoldOut(itemID int, until *time.Time) error
+	FindKitchenOrder(orderID int) (*domain.OrderWithItems, error)
+	SetItemStatus(orderID, lineID int, status string, at time.Time) (bool, error)
+	FetchLateOrders(placedBefore time.Time) ([]domain.Order, error)
+	MarkLateAlerted(orderID int, at time.Time) error
+	SetCategoryStation(categoryID int, station string) (bool, error)
 }
 
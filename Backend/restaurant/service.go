@@ -2,19 +2,24 @@ package restaurant
 
 import (
 	"oasis/backend/domain"
+	"oasis/backend/guest"
+	"oasis/backend/ws"
 	"time"
 )
 
 type service struct {
-	repo Repository
+	repo      Repository
+	guestSvc  guest.Service
+	hub       *ws.Hub
+	lateAfter time.Duration // Ticket timer: orders older than this are flagged on the kitchen display
 }
 
-func NewService(repo Repository) Service {
-	return &service{repo: repo}
+func NewService(repo Repository, guestSvc guest.Service, hub *ws.Hub, lateAfter time.Duration) Service {
+	return &service{repo: repo, guestSvc: guestSvc, hub: hub, lateAfter: lateAfter}
 }
 
 // GetFullMenu stitches categories and items into a tree
-func (s *service) GetFullMenu() ([]domain.CategoryWithItems, error) {
+func (s *service) GetFullMenu(filter domain.MenuFilter) ([]domain.CategoryWithItems, error) {
 	// 1. Get All Categories
 	cats, err := s.repo.FetchCategories()
 	if err != nil {
@@ -27,56 +32,178 @@ func (s *service) GetFullMenu() ([]domain.CategoryWithItems, error) {
 		return nil, err
 	}
 
-	// 3. Group items by CategoryID
+	// 3. Drop what the filter rules out; a guest also sees their allergies on each dish
+	allergies, err := s.guestAllergies(filter.GuestID)
+	if err != nil {
+		return nil, err
+	}
+	if filter.SafeForGuest {
+		filter.ExcludeAllergens = append(filter.ExcludeAllergens, allergies...)
+	}
+	kept := items[:0]
+	for _, item := range items {
+		if !filter.Allows(item) {
+			continue
+		}
+		if found := item.AllergensIn(allergies); len(found) > 0 {
+			item.AllergenConflicts = found
+		}
+		kept = append(kept, item)
+	}
+	items = kept
+
+	// 4. Attach modifier groups and what can be ordered right now
+	ids := make([]int, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+	groups, err := s.repo.FetchModifierGroups(ids)
+	if err != nil {
+		return nil, err
+	}
+	periods, err := s.repo.FetchServicePeriods()
+	if err != nil {
+		return nil, err
+	}
+	schedule := domain.NewMenuSchedule(periods)
+	now := time.Now()
+
+	// 5. Group items by CategoryID
 	itemsByCat := make(map[int][]domain.RestaurantMenuItem)
 	for _, item := range items {
+		item.ModifierGroups = groups[item.ID]
+		schedule.Apply(&item, now)
 		itemsByCat[item.CategoryID] = append(itemsByCat[item.CategoryID], item)
 	}
 
-	// 4. Build Result Tree
+	// 6. Build Result Tree
 	var result []domain.CategoryWithItems
 	for _, cat := range cats {
+		catPeriods := schedule.CategoryPeriods(cat.ID)
 		result = append(result, domain.CategoryWithItems{
-			Category: cat,
-			Items:    itemsByCat[cat.ID],
+			Category:       cat,
+			ServicePeriods: catPeriods,
+			OpenNow:        domain.ServedAt(catPeriods, now),
+			Items:          itemsByCat[cat.ID],
 		})
 	}
 
 	return result, nil
 }
 
-func (s *service) PlaceOrder(guestID int, roomNumber, notes string, items []domain.RestaurantOrderItemInput) (*domain.Order, error) {
-	// Note: In a real app, we would re-calculate the price here from the DB.
-	// For this MVP, we will let the Repository handle the price lookup/insert or assume frontend passes details (Risky).
-	// To keep it simple but safe: The Repo Logic below will re-fetch prices.
+// PlaceOrder prices the cart from the database, never from the client.
+// Bad lines come back together as a *domain.OrderValidationError and nothing is saved.
+// Dishes with one of the guest's allergens need acknowledgeAllergens; the order is then flagged for the kitchen.
+func (s *service) PlaceOrder(guestID int, roomNumber, notes string, items []domain.RestaurantOrderItemInput, acknowledgeAllergens bool) (*domain.Order, error) {
+	if len(items) == 0 {
+		return nil, domain.ErrEmptyOrder
+	}
+
+	conflicts, err := s.allergenConflicts(guestID, items)
+	if err != nil {
+		return nil, err
+	}
+	if len(conflicts) > 0 && !acknowledgeAllergens {
+		return nil, &domain.AllergenWarning{Conflicts: conflicts}
+	}
 
 	order := &domain.Order{
 		GuestID:    guestID,
 		RoomNumber: roomNumber,
 		Notes:      notes,
-		Status:     "RECEIVED",
+		Status:     domain.OrderStatusReceived,
 		TotalPrice: 0.00, // Repo will calculate this
 		CreatedAt:  time.Now(),
+
+		AllergyAlert: len(conflicts) > 0,
+		Allergens:    domain.ConflictingAllergens(conflicts),
+	}
+
+	if err := s.repo.SaveOrder(order, items); err != nil {
+		return nil, err
 	}
 
-	err := s.repo.SaveOrder(order, items)
-	return order, err
+	// Push the ticket to the kitchen display
+	if ticket, err := s.repo.FindKitchenOrder(order.ID); err == nil && ticket != nil {
+		ticket.StampTimer(time.Now(), s.lateAfter)
+		s.hub.BroadcastToStaff("NEW_ORDER", ticket)
+	}
+	return order, nil
 }
 
-func (s *service) GetGuestOrders(guestID int) ([]domain.Order, error) {
-	return s.repo.FetchOrdersByGuest(guestID)
+// guestAllergies reads the allergies on the guest profile; unknown guests have none
+func (s *service) guestAllergies(guestID int) ([]string, error) {
+	if guestID == 0 {
+		return nil, nil
+	}
+	gst, err := s.guestSvc.Get(guestID)
+	if err != nil {
+		return nil, err
+	}
+	if gst == nil {
+		return nil, nil
+	}
+	return gst.Allergies, nil
 }
 
-func (s *service) UpdateStatus(orderID int, status string) error {
-	return s.repo.UpdateStatus(orderID, status)
+// allergenConflicts finds the order lines that contain one of the guest's allergens
+func (s *service) allergenConflicts(guestID int, items []domain.RestaurantOrderItemInput) ([]domain.AllergenConflict, error) {
+	allergies, err := s.guestAllergies(guestID)
+	if err != nil || len(allergies) == 0 {
+		return nil, err
+	}
 
+	ids := make([]int, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ItemID)
+	}
+	menu, err := s.repo.FetchItems(ids)
+	if err != nil {
+		return nil, err
+	}
+	return domain.FindAllergenConflicts(items, menu, allergies), nil
+}
+
+func (s *service) GetGuestOrders(guestID int) ([]domain.Order, error) {
+	return s.repo.FetchOrdersByGuest(guestID)
 }
 
 func (s *service) AddMenuItem(item *domain.RestaurantMenuItem) error {
+	if err := item.NormalizeTags(); err != nil {
+		return err
+	}
+	if err := item.NormalizeStation(); err != nil {
+		return err
+	}
 	return s.repo.CreateItem(item)
 }
 
+// UpdateMenuItem replaces the item; tags and station left out of the payload keep their current values
 func (s *service) UpdateMenuItem(item *domain.RestaurantMenuItem) error {
+	if item.Allergens == nil || item.Dietary == nil || item.Station == nil {
+		current, err := s.repo.FindItem(item.ID)
+		if err != nil {
+			return err
+		}
+		if current == nil {
+			return domain.ErrMenuItemNotFound
+		}
+		if item.Allergens == nil {
+			item.Allergens = current.Allergens
+		}
+		if item.Dietary == nil {
+			item.Dietary = current.Dietary
+		}
+		if item.Station == nil {
+			item.Station = current.Station
+		}
+	}
+	if err := item.NormalizeTags(); err != nil {
+		return err
+	}
+	if err := item.NormalizeStation(); err != nil {
+		return err
+	}
 	return s.repo.UpdateItem(item)
 }
 
@@ -84,7 +211,135 @@ func (s *service) RemoveMenuItem(id int) error {
 	return s.repo.DeleteItem(id)
 }
 
-func (s *service) GetKitchenOrders() ([]domain.OrderWithItems, error) {
-	return s.repo.FetchActiveOrders()
+// SetModifierGroups replaces the item's modifier groups; orders already placed keep their copies
+func (s *service) SetModifierGroups(itemID int, groups []domain.ModifierGroup) ([]domain.ModifierGroup, error) {
+	item, err := s.repo.FindItem(itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, domain.ErrMenuItemNotFound
+	}
+
+	for i := range groups {
+		if err := groups[i].Normalize(); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.repo.ReplaceModifierGroups(itemID, groups); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+func (s *service) GetServicePeriods() ([]domain.ServicePeriod, error) {
+	return s.repo.FetchServicePeriods()
+}
+
+// SetCategoryPeriods replaces the periods of a category; items with their own periods keep them
+func (s *service) SetCategoryPeriods(categoryID int, periods []domain.ServicePeriod) ([]domain.ServicePeriod, error) {
+	cats, err := s.repo.FetchCategories()
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range cats {
+		if c.ID == categoryID {
+			return s.replacePeriods(&categoryID, nil, periods)
+		}
+	}
+	return nil, domain.ErrCategoryNotFound
+}
+
+// SetItemPeriods replaces the periods of one item; an empty list falls back to the category's
+func (s *service) SetItemPeriods(itemID int, periods []domain.ServicePeriod) ([]domain.ServicePeriod, error) {
+	item, err := s.repo.FindItem(itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, domain.ErrMenuItemNotFound
+	}
+	return s.replacePeriods(nil, &itemID, periods)
+}
+
+func (s *service) replacePeriods(categoryID, itemID *int, periods []domain.ServicePeriod) ([]domain.ServicePeriod, error) {
+	for i := range periods {
+		if err := periods[i].Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.repo.ReplaceServicePeriods(categoryID, itemID, periods); err != nil {
+		return nil, err
+	}
+	return periods, nil
+}
+
+// MarkSoldOut 86es the item. Without an explicit time it comes back at the start of its next service.
+func (s *service) MarkSoldOut(itemID int, until *time.Time) (*domain.RestaurantMenuItem, error) {
+	item, err := s.repo.FindItem(itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, domain.ErrMenuItemNotFound
+	}
+
+	periods, err := s.repo.FetchServicePeriods()
+	if err != nil {
+		return nil, err
+	}
+	schedule := domain.NewMenuSchedule(periods)
+	now := time.Now()
+	if until == nil || !until.After(now) {
+		next := domain.NextService(schedule.PeriodsFor(*item), now)
+		until = &next
+	}
+
+	if err := s.repo.SetSoldOut(itemID, until); err != nil {
+		return nil, err
+	}
+	item.SoldOutUntil = until
+	schedule.Apply(item, now)
+	return item, nil
+}
+
+// Restock puts an 86'd item back on sale before its next service
+func (s *service) Restock(itemID int) (*domain.RestaurantMenuItem, error) {
+	item, err := s.repo.FindItem(itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, domain.ErrMenuItemNotFound
+	}
+	if err := s.repo.SetSoldOut(itemID, nil); err != nil {
+		return nil, err
+	}
+	item.SoldOutUntil = nil
+	return item, nil
 }
 
+// GetKitchenOrders returns the open tickets with their timers; a station only sees its own lines
+func (s *service) GetKitchenOrders(station string) ([]domain.OrderWithItems, error) {
+	if station != "" && !domain.ValidStation(station) {
+		return nil, domain.ErrInvalidStation
+	}
+	orders, err := s.repo.FetchActiveOrders()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result := []domain.OrderWithItems{}
+	for _, o := range orders {
+		if station != "" {
+			var ok bool
+			if o, ok = o.ForStation(station); !ok {
+				continue
+			}
+		}
+		o.StampTimer(now, s.lateAfter)
+		result = append(result, o)
+	}
+	return result, nil
+}
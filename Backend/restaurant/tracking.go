@@ -0,0 +1,107 @@
+package restaurant
+
+import (
+	"strings"
+	"time"
+
+	"oasis/backend/domain"
+)
+
+// UpdateStatus moves an order one step along the lifecycle.
+// Cancelling needs a reason; PAID is reserved for the checkout invoice.
+func (s *service) UpdateStatus(orderID int, status, reason string, actor domain.Actor) (*domain.Order, error) {
+	next := domain.OrderStatus(strings.ToUpper(strings.TrimSpace(status)))
+	if !next.Valid() {
+		return nil, domain.ErrInvalidOrderStatus
+	}
+
+	order, err := s.repo.FindOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		return nil, domain.ErrOrderNotFound
+	}
+	return s.transition(order, next, reason, actor)
+}
+
+// transition validates and stores one status change, then tells the kitchen and the guest
+func (s *service) transition(order *domain.Order, next domain.OrderStatus, reason string, actor domain.Actor) (*domain.Order, error) {
+	if !order.Status.CanTransitionTo(next) {
+		return nil, domain.ErrIllegalOrderTransition
+	}
+	reason = strings.TrimSpace(reason)
+	if next == domain.OrderStatusCancelled && reason == "" {
+		return nil, domain.ErrCancelReasonRequired
+	}
+
+	if err := s.repo.UpdateStatus(order.ID, order.Status, next, reason, actor); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	order.Status = next
+	order.UpdatedAt = &now
+	if next == domain.OrderStatusCancelled {
+		order.CancelReason = &reason
+	}
+	// A pending cancellation request lapses once the order moves on
+	order.CancelRequestedAt, order.CancelRequestReason = nil, nil
+
+	s.hub.BroadcastToStaff("ORDER_STATUS", map[string]interface{}{
+		"order_id": order.ID,
+		"status":   order.Status,
+	})
+	s.notifyGuest(order)
+	return order, nil
+}
+
+// notifyGuest pushes the order as the guest tracks it to the guest's socket
+func (s *service) notifyGuest(order *domain.Order) {
+	if tracking, err := s.tracking(order.ID); err == nil && tracking != nil {
+		s.hub.BroadcastToGuest(order.GuestID, "ORDER_STATUS", tracking)
+	}
+}
+
+// TrackOrder returns the order with its history and ETA. Guests only see their own orders.
+func (s *service) TrackOrder(orderID int, actor domain.Actor) (*domain.OrderTracking, error) {
+	tracking, err := s.tracking(orderID)
+	if err != nil {
+		return nil, err
+	}
+	// Guests must not learn about other guests' orders
+	if tracking == nil || (actor.Role == domain.ActorRoleGuest && tracking.GuestID != actor.ID) {
+		return nil, domain.ErrOrderNotFound
+	}
+	return tracking, nil
+}
+
+// tracking loads what the guest follows: lines, status history and the ETA
+func (s *service) tracking(orderID int) (*domain.OrderTracking, error) {
+	ticket, err := s.repo.FindKitchenOrder(orderID)
+	if err != nil || ticket == nil {
+		return nil, err
+	}
+	events, err := s.repo.FetchOrderEvents(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Orders waiting in the queue start when the kitchen gets to them; the others from their last change
+	since, ahead := ticket.CreatedAt, 0
+	if ticket.Status == domain.OrderStatusReceived {
+		if ahead, err = s.repo.CountOrdersAhead(orderID); err != nil {
+			return nil, err
+		}
+	} else if ticket.UpdatedAt != nil {
+		since = *ticket.UpdatedAt
+	}
+
+	now := time.Now()
+	ticket.StampTimer(now, s.lateAfter)
+	return &domain.OrderTracking{
+		OrderWithItems: *ticket,
+		ETA:            domain.EstimateDelivery(ticket.Status, since, ahead, now),
+		Events:         events,
+	}, nil
+}
@@ -1,6 +1,8 @@
 package room
 
 import (
+	"time"
+
 	"oasis/backend/domain"
 	roomHandler "oasis/backend/rest/handlers/room"
 )
@@ -16,4 +18,17 @@ type RoomRepo interface {
 	Find(roomNumber string) (*domain.Room, error)
 	FindByID(id string) (*domain.Room, error)
 	GetAll(status string) ([]domain.Room, error)
+	Update(room domain.Room) error
+	SetOutOfOrder(roomNumber string, from, to *time.Time, reason string) error
+	Retire(roomNumber string) error
+	HasActiveGuest(roomNumber string) (bool, error)
+	IsRoomFree(roomNumber string, from, to time.Time, excludeGuestID, groupID int) (bool, error)
+
+	// Room Types
+	FetchRoomTypes() ([]domain.RoomType, error)
+	FindRoomType(id int) (*domain.RoomType, error)
+	FindRoomTypeByName(name string) (*domain.RoomType, error)
+	CreateRoomType(rt *domain.RoomType) error
+	UpdateRoomType(rt *domain.RoomType) error
+	SearchAvailability(checkIn, checkOut time.Time, guests int) ([]domain.RoomTypeAvailability, error)
 }
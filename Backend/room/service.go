@@ -1,6 +1,9 @@
 package room
 
 import (
+	"fmt"
+	"time"
+
 	"oasis/backend/domain"
 )
 
@@ -30,14 +33,25 @@ func (svc *service) Find(roomNumber string) (*domain.Room, error) {
 
 // Create adds a new room to the system
 func (svc *service) Create(room domain.Room) (*domain.Room, error) {
-	rm, err := svc.rmRepo.Create(room)
+	existing, err := svc.rmRepo.Find(room.RoomNumber)
 	if err != nil {
 		return nil, err
 	}
-	if rm == nil {
-		return nil, nil
+	if existing != nil {
+		return nil, domain.ErrRoomExists
 	}
-	return rm, nil
+	if room.Status == "" {
+		room.Status = domain.RoomStatusVacant
+	}
+	if err := svc.applyRoomType(&room); err != nil {
+		return nil, err
+	}
+
+	if _, err := svc.rmRepo.Create(room); err != nil {
+		return nil, err
+	}
+	// Read it back so inherited price and occupancy come from the type
+	return svc.rmRepo.Find(room.RoomNumber)
 }
 
 // FindByID retrieves a room by its UUID
@@ -52,6 +66,198 @@ func (svc *service) FindByID(id string) (*domain.Room, error) {
 	return rm, nil
 }
 
+// CheckAvailability returns the room if it can host a stay over [from, to).
+// excludeGuestID lets a guest's own stay be ignored (room moves, extensions) and
+// groupID lets a group assign members into the rooms it is holding.
+func (svc *service) CheckAvailability(roomNumber string, from, to time.Time, excludeGuestID, groupID int) (*domain.Room, error) {
+	rm, err := svc.rmRepo.Find(roomNumber)
+	if err != nil {
+		return nil, err
+	}
+	if rm == nil {
+		return nil, domain.ErrRoomNotFound
+	}
+	if rm.RetiredAt != nil {
+		return nil, domain.ErrRoomRetired
+	}
+
+	free, err := svc.rmRepo.IsRoomFree(roomNumber, from, to, excludeGuestID, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if !free {
+		return nil, domain.ErrRoomUnavailable
+	}
+	return rm, nil
+}
+
 func (svc *service) GetAll(status string) ([]domain.Room, error) {
 	return svc.rmRepo.GetAll(status)
 }
+
+// Update edits the inventory attributes of an existing room.
+// Status is owned by housekeeping/front desk and is never changed here.
+func (svc *service) Update(room domain.Room) (*domain.Room, error) {
+	existing, err := svc.rmRepo.Find(room.RoomNumber)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, domain.ErrRoomNotFound
+	}
+	if existing.RetiredAt != nil {
+		return nil, domain.ErrRoomRetired
+	}
+
+	existing.RoomTypeID = room.RoomTypeID
+	existing.Price = room.Price
+	existing.Floor = room.Floor
+	existing.Features = room.Features
+	existing.MaxOccupancy = room.MaxOccupancy
+	if err := svc.applyRoomType(existing); err != nil {
+		return nil, err
+	}
+
+	if err := svc.rmRepo.Update(*existing); err != nil {
+		return nil, err
+	}
+	return svc.rmRepo.Find(room.RoomNumber)
+}
+
+// SetOutOfOrder blocks a room for the given (inclusive) date range.
+func (svc *service) SetOutOfOrder(roomNumber string, from, to time.Time, reason string) error {
+	if to.Before(from) {
+		return domain.ErrInvalidWindow
+	}
+	rm, err := svc.rmRepo.Find(roomNumber)
+	if err != nil {
+		return err
+	}
+	if rm == nil {
+		return domain.ErrRoomNotFound
+	}
+	if rm.RetiredAt != nil {
+		return domain.ErrRoomRetired
+	}
+	return svc.rmRepo.SetOutOfOrder(roomNumber, &from, &to, reason)
+}
+
+// ClearOutOfOrder puts a room back into service.
+func (svc *service) ClearOutOfOrder(roomNumber string) error {
+	rm, err := svc.rmRepo.Find(roomNumber)
+	if err != nil {
+		return err
+	}
+	if rm == nil {
+		return domain.ErrRoomNotFound
+	}
+	return svc.rmRepo.SetOutOfOrder(roomNumber, nil, nil, "")
+}
+
+// Retire removes a room from inventory. Occupied rooms cannot be retired.
+func (svc *service) Retire(roomNumber string) error {
+	rm, err := svc.rmRepo.Find(roomNumber)
+	if err != nil {
+		return err
+	}
+	if rm == nil {
+		return domain.ErrRoomNotFound
+	}
+	if rm.RetiredAt != nil {
+		return domain.ErrRoomRetired
+	}
+	if rm.Status == domain.RoomStatusOccupied {
+		return domain.ErrRoomOccupied
+	}
+
+	// Room status is not always kept in sync with check-ins, so double check the guests
+	occupied, err := svc.rmRepo.HasActiveGuest(roomNumber)
+	if err != nil {
+		return err
+	}
+	if occupied {
+		return domain.ErrRoomOccupied
+	}
+
+	return svc.rmRepo.Retire(roomNumber)
+}
+
+// applyRoomType copies the type name onto the room.
+// A zero price or max occupancy means "use the type's", so later type edits reach the room.
+func (svc *service) applyRoomType(room *domain.Room) error {
+	rt, err := svc.rmRepo.FindRoomType(room.RoomTypeID)
+	if err != nil {
+		return err
+	}
+	if rt == nil {
+		return domain.ErrRoomTypeNotFound
+	}
+
+	room.Type = rt.Name
+	return nil
+}
+
+func (svc *service) GetRoomTypes() ([]domain.RoomType, error) {
+	return svc.rmRepo.FetchRoomTypes()
+}
+
+func (svc *service) GetRoomType(id int) (*domain.RoomType, error) {
+	rt, err := svc.rmRepo.FindRoomType(id)
+	if err != nil {
+		return nil, err
+	}
+	if rt == nil {
+		return nil, domain.ErrRoomTypeNotFound
+	}
+	return rt, nil
+}
+
+func (svc *service) CreateRoomType(rt domain.RoomType) (*domain.RoomType, error) {
+	existing, err := svc.rmRepo.FindRoomTypeByName(rt.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, domain.ErrRoomTypeExists
+	}
+
+	if err := svc.rmRepo.CreateRoomType(&rt); err != nil {
+		return nil, err
+	}
+	return &rt, nil
+}
+
+func (svc *service) UpdateRoomType(rt domain.RoomType) (*domain.RoomType, error) {
+	existing, err := svc.rmRepo.FindRoomType(rt.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, domain.ErrRoomTypeNotFound
+	}
+
+	// Renaming onto another type's name would break the unique constraint
+	byName, err := svc.rmRepo.FindRoomTypeByName(rt.Name)
+	if err != nil {
+		return nil, err
+	}
+	if byName != nil && byName.ID != rt.ID {
+		return nil, domain.ErrRoomTypeExists
+	}
+
+	if err := svc.rmRepo.UpdateRoomType(&rt); err != nil {
+		return nil, err
+	}
+	return &rt, nil
+}
+
+// SearchAvailability returns the room types with at least one free room for the stay
+func (svc *service) SearchAvailability(checkIn, checkOut time.Time, guests int) ([]domain.RoomTypeAvailability, error) {
+	if !checkOut.After(checkIn) {
+		return nil, fmt.Errorf("%w: check-out date must be after check-in date", domain.ErrInvalidStayDates)
+	}
+	if guests < 1 {
+		guests = 1
+	}
+	return svc.rmRepo.SearchAvailability(checkIn, checkOut, guests)
+}
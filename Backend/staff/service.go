@@ -37,7 +37,7 @@ func (s *service) Login(staffID int, password string) (*domain.Staff, string, er
 	payload := util.Payload{
 		Sub:         staff.ID,
 		Name:        staff.Name,
-		PhoneNumber: "STAFF",    // Placeholder
+		PhoneNumber: util.StaffMarker, // Placeholder
 		RoomNumber:  staff.Role, // Storing Role here so frontend knows permissions
 	}
 
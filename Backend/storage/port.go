@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"io"
+
+	"oasis/backend/domain"
+	attachmentHandler "oasis/backend/rest/handlers/attachment"
+)
+
+// Service defines what the "Storage Module" is capable of doing.
+// Other modules (tickets, lost and found) use it to attach photos to their records.
+type Service interface {
+	attachmentHandler.Service
+	Upload(ownerType string, ownerID int, fileName string, file io.Reader, actor domain.Actor) (*domain.Attachment, error)
+	List(ownerType string, ownerID int) ([]domain.Attachment, error)
+}
+
+// FileStore is where the bytes live: the local disk today, an object store (S3, GCS) later.
+type FileStore interface {
+	Put(key string, data []byte) error
+	Get(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
+
+// Repository defines how the "Storage Module" talks to the database.
+type Repository interface {
+	SaveAttachment(att *domain.Attachment) error
+	FindAttachment(id int) (*domain.Attachment, error)
+	FetchAttachments(ownerType string, ownerID int) ([]domain.Attachment, error)
+}
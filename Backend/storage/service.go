@@ -0,0 +1,183 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"oasis/backend/domain"
+)
+
+const (
+	// MaxUploadBytes caps a single photo
+	MaxUploadBytes = 8 << 20
+
+	// linkTTL is how long a signed download link stays valid
+	linkTTL = 15 * time.Minute
+)
+
+// allowedTypes maps the sniffed content type to the stored file extension
+var allowedTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+// service implements the Service interface defined in port.go
+type service struct {
+	repo       Repository
+	store      FileStore
+	signingKey []byte
+}
+
+// NewService creates a new instance of the storage service
+func NewService(repo Repository, store FileStore, signingKey string) Service {
+	return &service{
+		repo:       repo,
+		store:      store,
+		signingKey: []byte(signingKey),
+	}
+}
+
+// Upload validates the file, stores it with a thumbnail and links it to its owner
+func (s *service) Upload(ownerType string, ownerID int, fileName string, file io.Reader, actor domain.Actor) (*domain.Attachment, error) {
+	// Read one byte past the limit so oversized files are detected without trusting Content-Length
+	data, err := io.ReadAll(io.LimitReader(file, MaxUploadBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxUploadBytes {
+		return nil, fmt.Errorf("%w: the limit is %d MB", domain.ErrAttachmentTooLarge, MaxUploadBytes>>20)
+	}
+
+	// The client's Content-Type header is not trusted; sniff the bytes instead
+	contentType := http.DetectContentType(data)
+	ext, ok := allowedTypes[contentType]
+	if !ok {
+		return nil, domain.ErrUnsupportedMediaType
+	}
+
+	thumb, err := makeThumbnail(data)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := randomName()
+	if err != nil {
+		return nil, err
+	}
+	prefix := fmt.Sprintf("%s/%d/%s", strings.ToLower(ownerType), ownerID, name)
+	key := prefix + ext
+	thumbKey := prefix + "_thumb.jpg"
+
+	if err := s.store.Put(key, data); err != nil {
+		return nil, err
+	}
+	if err := s.store.Put(thumbKey, thumb); err != nil {
+		s.store.Delete(key)
+		return nil, err
+	}
+
+	att := &domain.Attachment{
+		OwnerType:    ownerType,
+		OwnerID:      ownerID,
+		FileName:     filepath.Base(fileName),
+		ContentType:  contentType,
+		SizeBytes:    int64(len(data)),
+		StorageKey:   key,
+		ThumbnailKey: &thumbKey,
+		UploaderRole: actor.Role,
+		CreatedAt:    time.Now(),
+	}
+	if actor.ID != 0 {
+		att.UploadedBy = &actor.ID
+	}
+	if err := s.repo.SaveAttachment(att); err != nil {
+		s.store.Delete(key)
+		s.store.Delete(thumbKey)
+		return nil, err
+	}
+
+	s.sign(att)
+	return att, nil
+}
+
+// List returns the owner's attachments with fresh download links
+func (s *service) List(ownerType string, ownerID int) ([]domain.Attachment, error) {
+	atts, err := s.repo.FetchAttachments(ownerType, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range atts {
+		s.sign(&atts[i])
+	}
+	return atts, nil
+}
+
+func (s *service) Open(id int, variant string, expires int64, signature string) (*domain.Attachment, io.ReadCloser, error) {
+	if time.Now().Unix() > expires {
+		return nil, nil, domain.ErrInvalidSignature
+	}
+	expected := s.signature(id, variant, expires)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return nil, nil, domain.ErrInvalidSignature
+	}
+
+	att, err := s.repo.FindAttachment(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if att == nil {
+		return nil, nil, domain.ErrAttachmentNotFound
+	}
+
+	key := att.StorageKey
+	if variant == domain.AttachmentThumbnail {
+		if att.ThumbnailKey == nil {
+			return nil, nil, domain.ErrAttachmentNotFound
+		}
+		key = *att.ThumbnailKey
+	}
+
+	file, err := s.store.Get(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return att, file, nil
+}
+
+// sign fills in the short-lived download links
+func (s *service) sign(att *domain.Attachment) {
+	expires := time.Now().Add(linkTTL).Unix()
+	att.URL = s.link(att.ID, domain.AttachmentOriginal, expires)
+	if att.ThumbnailKey != nil {
+		att.ThumbnailURL = s.link(att.ID, domain.AttachmentThumbnail, expires)
+	}
+}
+
+func (s *service) link(id int, variant string, expires int64) string {
+	return fmt.Sprintf("/attachments/%d?variant=%s&expires=%d&sig=%s", id, variant, expires, s.signature(id, variant, expires))
+}
+
+// signature is an HMAC over everything the link grants access to
+func (s *service) signature(id int, variant string, expires int64) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	fmt.Fprintf(mac, "%d:%s:%d", id, variant, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// randomName makes storage keys unguessable and independent of the uploaded file name
+func randomName() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"bytes"
+	"image"
+	_ "image/gif" // Register decoders for the accepted upload types
+	"image/jpeg"
+	_ "image/png"
+
+	"oasis/backend/domain"
+)
+
+const (
+	// thumbnailSide is the longest edge of a thumbnail in pixels
+	thumbnailSide = 320
+
+	// maxPixels rejects "decompression bombs": tiny files that decode to huge images
+	maxPixels = 40_000_000
+)
+
+// makeThumbnail downscales the photo to a JPEG that fits in thumbnailSide x thumbnailSide
+func makeThumbnail(data []byte) ([]byte, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || cfg.Width*cfg.Height > maxPixels {
+		return nil, domain.ErrUnsupportedMediaType
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, domain.ErrUnsupportedMediaType
+	}
+
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	tw, th := w, h
+	if w > thumbnailSide || h > thumbnailSide {
+		if w >= h {
+			tw, th = thumbnailSide, max(1, h*thumbnailSide/w)
+		} else {
+			tw, th = max(1, w*thumbnailSide/h), thumbnailSide
+		}
+	}
+
+	// Nearest-neighbour sampling is plenty for a preview
+	dst := image.NewRGBA(image.Rect(0, 0, tw, th))
+	for y := 0; y < th; y++ {
+		sy := b.Min.Y + y*h/th
+		for x := 0; x < tw; x++ {
+			dst.Set(x, y, src.At(b.Min.X+x*w/tw, sy))
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 80}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
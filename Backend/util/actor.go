@@ -0,0 +1,22 @@
+package util
+
+import (
+	"net/http"
+	"strings"
+
+	"oasis/backend/domain"
+)
+
+// ActorFromRequest reads the JWT (if any) to find out who is calling.
+// Requests without a valid token are attributed to an anonymous guest (ID 0).
+func ActorFromRequest(r *http.Request) domain.Actor {
+	tokenString := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	claims, err := ParseJwtClaims(tokenString)
+	if err != nil {
+		return domain.Actor{ID: 0, Role: domain.ActorRoleGuest}
+	}
+	if claims.IsStaff() {
+		return domain.Actor{ID: claims.Sub, Role: claims.Role()}
+	}
+	return domain.Actor{ID: claims.Sub, Role: domain.ActorRoleGuest}
+}
@@ -20,6 +20,22 @@ type Payload struct {
 	RoomNumber  string `json:"room_number"`  // Part of Login
 }
 
+// StaffMarker is stored in PhoneNumber for staff tokens, whose RoomNumber holds the role
+const StaffMarker = "STAFF"
+
+// IsStaff reports whether the token was issued by the staff login
+func (p Payload) IsStaff() bool {
+	return p.PhoneNumber == StaffMarker
+}
+
+// Role returns the staff role, or "" for guest tokens
+func (p Payload) Role() string {
+	if !p.IsStaff() {
+		return ""
+	}
+	return p.RoomNumber
+}
+
 func CreateJwt(secret string, data Payload) (string, error) {
 	header := Header{
 		Alg: "HS256",
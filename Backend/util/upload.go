@@ -0,0 +1,45 @@
+package util
+
+import (
+	"errors"
+	"mime/multipart"
+	"net/http"
+
+	"oasis/backend/domain"
+)
+
+// maxUploadForm caps the whole multipart body; single files are checked by the storage service
+const maxUploadForm = 40 << 20
+
+// ParseUploads reads up to maxFiles files sent under field in a multipart/form-data request.
+// Call the returned function once the uploads have been consumed to close them.
+func ParseUploads(w http.ResponseWriter, r *http.Request, field string, maxFiles int) ([]domain.Upload, func(), error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadForm)
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		return nil, func() {}, errors.New("invalid multipart form or upload too large")
+	}
+
+	headers := r.MultipartForm.File[field]
+	if len(headers) > maxFiles {
+		return nil, func() {}, errors.New("too many files")
+	}
+
+	var files []multipart.File
+	closeAll := func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}
+
+	uploads := make([]domain.Upload, 0, len(headers))
+	for _, header := range headers {
+		file, err := header.Open()
+		if err != nil {
+			closeAll()
+			return nil, func() {}, err
+		}
+		files = append(files, file)
+		uploads = append(uploads, domain.Upload{FileName: header.Filename, Data: file})
+	}
+	return uploads, closeAll, nil
+}
@@ -23,9 +23,12 @@ var upgrader = websocket.Upgrader{
 
 // Client is a middleman between the websocket connection and the hub.
 type Client struct {
-	hub *Hub
+	hub  *Hub
 	conn *websocket.Conn
 	send chan Message
+
+	// guestID is 0 for staff connections
+	guestID int
 }
 
 // WritePump pumps messages from the hub to the websocket connection.
@@ -57,12 +60,16 @@ func (c *Client) WritePump() {
 
 // ServeWs handles websocket requests from the peer.
 func ServeWs(hub *Hub, w http.ResponseWriter, r *http.Request) {
+	serveClient(hub, w, r, 0)
+}
+
+func serveClient(hub *Hub, w http.ResponseWriter, r *http.Request, guestID int) {
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		log.Println(err)
 		return
 	}
-	client := &Client{hub: hub, conn: conn, send: make(chan Message, 256)}
+	client := &Client{hub: hub, conn: conn, send: make(chan Message, 256), guestID: guestID}
 	client.hub.register <- client
 
 	// Allow collection of memory by starting the write pump in a goroutine
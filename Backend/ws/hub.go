@@ -12,13 +12,19 @@ type Message struct {
 	Payload interface{} `json:"payload"` // The actual data (Room #, Status)
 }
 
+// delivery routes a message to staff (GuestID 0) or to one guest's connections
+type delivery struct {
+	GuestID int
+	Message Message
+}
+
 // Hub maintains the set of active clients and broadcasts messages
 type Hub struct {
-	// Registered clients (Staff members)
+	// Registered clients (Staff members and guests)
 	clients map[*Client]bool
 
 	// Inbound messages from the system to broadcast
-	broadcast chan Message
+	broadcast chan delivery
 
 	// Register requests from the clients.
 	register chan *Client
@@ -32,7 +38,7 @@ type Hub struct {
 
 func NewHub() *Hub {
 	return &Hub{
-		broadcast:  make(chan Message),
+		broadcast:  make(chan delivery),
 		register:   make(chan *Client),
 		unregister: make(chan *Client),
 		clients:    make(map[*Client]bool),
@@ -46,7 +52,11 @@ func (h *Hub) Run() {
 			h.mu.Lock()
 			h.clients[client] = true
 			h.mu.Unlock()
-			fmt.Println("New Staff Connected to WebSocket")
+			if client.guestID != 0 {
+				fmt.Println("New Guest Connected to WebSocket")
+			} else {
+				fmt.Println("New Staff Connected to WebSocket")
+			}
 
 		case client := <-h.unregister:
 			h.mu.Lock()
@@ -57,12 +67,15 @@ func (h *Hub) Run() {
 			h.mu.Unlock()
 			fmt.Println("Staff Disconnected")
 
-		case message := <-h.broadcast:
-			// Send the message to ALL connected staff
+		case d := <-h.broadcast:
+			// Staff messages go to every staff connection, guest messages only to that guest
 			h.mu.Lock()
 			for client := range h.clients {
+				if client.guestID != d.GuestID {
+					continue
+				}
 				select {
-				case client.send <- message:
+				case client.send <- d.Message:
 				default:
 					close(client.send)
 					delete(h.clients, client)
@@ -79,7 +92,19 @@ func (h *Hub) BroadcastToStaff(msgType string, data interface{}) {
 		Type:    msgType,
 		Payload: data,
 	}
-	h.broadcast <- msg
+	h.broadcast <- delivery{Message: msg}
+}
+
+// BroadcastToGuest pushes a message to the connections opened by one guest
+func (h *Hub) BroadcastToGuest(guestID int, msgType string, data interface{}) {
+	if guestID == 0 {
+		return
+	}
+	msg := Message{
+		Type:    msgType,
+		Payload: data,
+	}
+	h.broadcast <- delivery{GuestID: guestID, Message: msg}
 }
 
 // ServeWs handles websocket requests - implements WebSocketHub interface
@@ -87,3 +112,8 @@ func (h *Hub) ServeWs(w http.ResponseWriter, r *http.Request) {
 	ServeWs(h, w, r)
 }
 
+// ServeGuestWs registers a connection that only receives the guest's own updates.
+// The caller must have verified the guest's token.
+func (h *Hub) ServeGuestWs(w http.ResponseWriter, r *http.Request, guestID int) {
+	serveClient(h, w, r, guestID)
+}
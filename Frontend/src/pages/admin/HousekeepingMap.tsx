@@ -1,5 +1,6 @@
 import { useState, useEffect, useRef } from 'react';
 import { getLiveHousekeepingStatus, markRoomClean, getAmenityRequests, getMaintenanceTickets, markAmenityDelivered, resolveTicket } from '../../services/api';
+import { getToken } from '../../utils/auth';
 import { CheckCircle, AlertCircle, Moon, XCircle, Loader2, Filter, Package, Wrench, Clock } from 'lucide-react';
 
 interface RoomStatus {
@@ -59,7 +60,7 @@ const HousekeepingMap = () => {
   // 2. WebSocket Connection
   useEffect(() => {
     const connectWebSocket = () => {
-      const socket = new WebSocket('ws://localhost:8080/ws');
+      const socket = new WebSocket(`ws://localhost:8080/ws?token=${getToken() ?? ''}`);
       ws.current = socket;
 
       socket.onopen = () => console.log('Connected to Housekeeping WebSocket');
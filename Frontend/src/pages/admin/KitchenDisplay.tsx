@@ -1,16 +1,23 @@
-import { useState, useEffect } from 'react';
-import { getActiveOrders, updateRestaurantOrderStatus } from '../../services/api';
+import { useState, useEffect, useRef } from 'react';
+import { getActiveOrders, updateRestaurantOrderStatus, bumpOrderItem, resolveCancelRequest } from '../../services/api';
 import { KitchenOrder } from '../../types';
-import { Clock, CheckCircle, ChefHat, Utensils } from 'lucide-react';
+import { getToken } from '../../utils/auth';
+import { Clock, CheckCircle, ChefHat, Utensils, AlertTriangle } from 'lucide-react';
+
+const STATIONS = ['', 'GRILL', 'COLD', 'BAR'];
 
 const KitchenDisplay = () => {
   const [orders, setOrders] = useState<KitchenOrder[]>([]);
   const [loading, setLoading] = useState(true);
   const [error, setError] = useState('');
+  const [station, setStation] = useState('');
+  const [connected, setConnected] = useState(false);
+  const stationRef = useRef(station);
+  stationRef.current = station;
 
   const fetchOrders = async () => {
     try {
-      const data = await getActiveOrders();
+      const data = await getActiveOrders(stationRef.current);
       setOrders(data || []);
       setError('');
     } catch (err) {
@@ -23,15 +30,66 @@ const KitchenDisplay = () => {
 
   useEffect(() => {
     fetchOrders();
-    const interval = setInterval(fetchOrders, 10000); // Poll every 10s
-    return () => clearInterval(interval);
+  }, [station]);
+
+  // Tickets are pushed by the server; a slow refresh keeps the timers moving
+  useEffect(() => {
+    let socket: WebSocket;
+    let closed = false;
+    const connect = () => {
+      socket = new WebSocket(`ws://localhost:8080/ws?token=${getToken() ?? ''}`);
+      socket.onopen = () => setConnected(true);
+      socket.onmessage = (event) => {
+        try {
+          const message = JSON.parse(event.data);
+          if (['NEW_ORDER', 'ORDER_STATUS', 'ORDER_ITEM_STATUS', 'ORDER_LATE', 'ORDER_UPDATED', 'ORDER_CANCEL_REQUESTED', 'ORDER_CANCEL_DECLINED'].includes(message.type)) {
+            fetchOrders();
+          }
+        } catch (err) {
+          console.error('Failed to parse WebSocket message', err);
+        }
+      };
+      socket.onclose = () => {
+        setConnected(false);
+        if (!closed) setTimeout(connect, 3000);
+      };
+      socket.onerror = () => socket.close();
+    };
+    connect();
+    const interval = setInterval(fetchOrders, 60000);
+    return () => {
+      closed = true;
+      socket.close();
+      clearInterval(interval);
+    };
   }, []);
 
+  const handleBump = async (orderId: number, lineId: number, ready: boolean) => {
+    try {
+      await bumpOrderItem(orderId, lineId, ready);
+      fetchOrders();
+    } catch (err) {
+      console.error(err);
+      setError('Failed to bump item');
+    }
+  };
+
+  const handleCancelRequest = async (orderId: number, approve: boolean) => {
+    try {
+      await resolveCancelRequest(orderId, approve);
+      fetchOrders();
+    } catch (err) {
+      console.error(err);
+      setError('Failed to answer the cancellation request');
+    }
+  };
+
   const handleStatusUpdate = async (orderId: number, currentStatus: string) => {
     let newStatus = '';
     if (currentStatus === 'RECEIVED') newStatus = 'PREPARING';
     else if (currentStatus === 'PREPARING') newStatus = 'READY';
-    else if (currentStatus === 'READY') newStatus = 'DELIVERED';
+    else if (currentStatus === 'READY') newStatus = 'OUT_FOR_DELIVERY';
+    else if (currentStatus === 'OUT_FOR_DELIVERY') newStatus = 'DELIVERED';
 
     if (!newStatus) return;
 
@@ -67,6 +125,7 @@ const KitchenDisplay = () => {
       case 'RECEIVED': return 'border-red-500 bg-red-50';
       case 'PREPARING': return 'border-amber-500 bg-amber-50';
       case 'READY': return 'border-green-500 bg-green-50';
+      case 'OUT_FOR_DELIVERY': return 'border-blue-500 bg-blue-50';
       default: return 'border-slate-200 bg-white';
     }
   };
@@ -76,6 +135,7 @@ const KitchenDisplay = () => {
       case 'RECEIVED': return 'bg-red-100 text-red-800';
       case 'PREPARING': return 'bg-amber-100 text-amber-800';
       case 'READY': return 'bg-green-100 text-green-800';
+      case 'OUT_FOR_DELIVERY': return 'bg-blue-100 text-blue-800';
       default: return 'bg-slate-100 text-slate-800';
     }
   };
@@ -96,9 +156,22 @@ const KitchenDisplay = () => {
             <ChefHat className="h-8 w-8 text-slate-700" />
             <h1 className="text-3xl font-bold text-slate-900">Live Kitchen Feed</h1>
           </div>
-          <div className="flex items-center gap-2 text-sm text-slate-500">
-            <div className="w-2 h-2 bg-green-500 rounded-full animate-pulse"></div>
-            Live Updates (10s)
+          <div className="flex items-center gap-4">
+            <div className="flex bg-white rounded-lg shadow-sm overflow-hidden">
+              {STATIONS.map((s) => (
+                <button
+                  key={s || 'ALL'}
+                  onClick={() => setStation(s)}
+                  className={`px-4 py-2 text-sm font-bold ${station === s ? 'bg-slate-900 text-white' : 'text-slate-600 hover:bg-slate-100'}`}
+                >
+                  {s || 'ALL'}
+                </button>
+              ))}
+            </div>
+            <div className="flex items-center gap-2 text-sm text-slate-500">
+              <div className={`w-2 h-2 rounded-full ${connected ? 'bg-green-500 animate-pulse' : 'bg-slate-400'}`}></div>
+              {connected ? 'Live' : 'Reconnecting...'}
+            </div>
           </div>
         </div>
 
@@ -119,8 +192,32 @@ const KitchenDisplay = () => {
             {orders.map((order) => (
               <div 
                 key={order.id}
-                className={`rounded-xl border-l-4 shadow-sm p-6 flex flex-col h-full transition-all duration-300 ${getStatusColor(order.status)} bg-white`}
+                className={`rounded-xl border-l-4 shadow-sm p-6 flex flex-col h-full transition-all duration-300 ${getStatusColor(order.status)} bg-white ${order.allergy_alert ? 'ring-4 ring-red-500' : ''}`}
               >
+                {/* Allergy Alert */}
+                {order.allergy_alert && (
+                  <div className="flex items-center gap-2 mb-4 p-3 bg-red-600 text-white rounded-lg font-bold uppercase text-sm">
+                    <AlertTriangle className="h-5 w-5" />
+                    Allergy: {order.allergens?.join(', ')}
+                  </div>
+                )}
+
+                {/* Guest wants to cancel */}
+                {order.cancel_requested_at && (
+                  <div className="mb-4 p-3 bg-amber-100 border border-amber-300 rounded-lg text-sm">
+                    <p className="font-bold text-amber-900">Guest asked to cancel</p>
+                    {order.cancel_request_reason && <p className="text-amber-800 italic">{order.cancel_request_reason}</p>}
+                    <div className="flex gap-2 mt-2">
+                      <button onClick={() => handleCancelRequest(order.id, true)} className="px-3 py-1 rounded bg-red-600 hover:bg-red-700 text-white font-bold">
+                        Approve
+                      </button>
+                      <button onClick={() => handleCancelRequest(order.id, false)} className="px-3 py-1 rounded bg-slate-200 hover:bg-slate-300 text-slate-800 font-bold">
+                        Keep Cooking
+                      </button>
+                    </div>
+                  </div>
+                )}
+
                 {/* Header */}
                 <div className="flex justify-between items-start mb-4 pb-4 border-b border-slate-200/60">
                   <div>
@@ -131,21 +228,28 @@ const KitchenDisplay = () => {
                     <div className={`inline-flex items-center px-2.5 py-0.5 rounded-full text-xs font-medium mb-1 ${getStatusBadge(order.status)}`}>
                       {order.status}
                     </div>
-                    <div className="flex items-center text-slate-500 text-sm font-medium">
+                    <div className={`flex items-center text-sm font-medium ${order.late ? 'text-red-600 font-bold' : 'text-slate-500'}`}>
                       <Clock className="h-3 w-3 mr-1" />
-                      {formatTimeAgo(order.created_at)}
+                      {formatTimeAgo(order.created_at)}{order.late && ' · LATE'}
                     </div>
                   </div>
                 </div>
 
                 {/* Items */}
                 <div className="flex-1 space-y-3 mb-6">
-                  {order.items?.map((item, idx) => (
-                    <div key={idx} className="flex justify-between items-start">
+                  {order.items?.filter(item => item.adjustment !== 'VOID').map((item) => (
+                    <div key={item.id} className={`flex justify-between items-start ${item.status === 'READY' ? 'opacity-50 line-through' : ''}`}>
                       <div className="flex gap-3">
                         <span className="font-bold text-slate-900 min-w-[1.5rem]">{item.quantity}x</span>
-                        <span className="text-slate-800 font-medium">{item.name}</span>
+                        <span className={`font-medium ${item.allergens?.some(a => order.allergens?.includes(a)) ? 'text-red-700 underline' : 'text-slate-800'}`}>{item.name}</span>
+                        {!station && <span className="text-xs text-slate-400 self-center">{item.station}</span>}
                       </div>
+                      <button
+                        onClick={() => handleBump(order.id, item.id, item.status !== 'READY')}
+                        className="text-xs font-bold px-2 py-1 rounded bg-slate-100 hover:bg-slate-200 text-slate-700 no-underline"
+                      >
+                        {item.status === 'READY' ? 'Recall' : 'Bump'}
+                      </button>
                     </div>
                   ))}
                   
@@ -164,14 +268,16 @@ const KitchenDisplay = () => {
                     ${order.status === 'RECEIVED' ? 'bg-slate-900 hover:bg-slate-800' : ''}
                     ${order.status === 'PREPARING' ? 'bg-amber-600 hover:bg-amber-700' : ''}
                     ${order.status === 'READY' ? 'bg-green-600 hover:bg-green-700' : ''}
+                    ${order.status === 'OUT_FOR_DELIVERY' ? 'bg-blue-600 hover:bg-blue-700' : ''}
                   `}
                 >
                   {order.status === 'RECEIVED' && 'Start Cooking'}
                   {order.status === 'PREPARING' && 'Order Up / Ready'}
-                  {order.status === 'READY' && (
+                  {order.status === 'READY' && 'Picked Up'}
+                  {order.status === 'OUT_FOR_DELIVERY' && (
                     <>
                       <CheckCircle className="h-5 w-5" />
-                      Picked Up
+                      Delivered
                     </>
                   )}
                 </button>
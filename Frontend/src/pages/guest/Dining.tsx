@@ -1,9 +1,13 @@
 import { useState, useEffect, useRef } from 'react';
-import { getRestaurantMenu, createRestaurantOrder, getGuestRestaurantOrders } from '../../services/api';
+import { getRestaurantMenu, createRestaurantOrder, getGuestRestaurantOrders, cancelRestaurantOrder } from '../../services/api';
 import { CategoryWithItems, MenuItem, RestaurantOrder } from '../../types';
 import { useAuth } from '../../context/AuthContext';
+import { getToken } from '../../utils/auth';
 import { Plus, Minus, X, UtensilsCrossed, AlertCircle, CheckCircle, Clock, ChefHat } from 'lucide-react';
 
+// Orders that no longer need following
+const CLOSED_STATUSES = ['DELIVERED', 'PAID', 'CANCELLED'];
+
 const DiningPage = () => {
   const [menu, setMenu] = useState<CategoryWithItems[]>([]);
   const [orders, setOrders] = useState<RestaurantOrder[]>([]);
@@ -24,9 +28,35 @@ const DiningPage = () => {
   useEffect(() => {
     fetchMenu();
     fetchOrders();
-    // Poll for order status updates every 10 seconds
-    const interval = setInterval(fetchOrders, 10000);
-    return () => clearInterval(interval);
+    // Live order tracking over the guest socket; polling is only a fallback
+    let socket: WebSocket;
+    let closed = false;
+    const connect = () => {
+      socket = new WebSocket(`ws://localhost:8080/ws/guest?token=${getToken() ?? ''}`);
+      socket.onmessage = (event) => {
+        try {
+          const message = JSON.parse(event.data);
+          if (message.type === 'ORDER_STATUS') {
+            const update = message.payload as RestaurantOrder;
+            setOrders(prev => prev
+              .map(o => (o.id === update.id ? { ...o, ...update } : o))
+              .filter(o => !CLOSED_STATUSES.includes(o.status)));
+          }
+        } catch (err) {
+          console.error('Failed to parse WebSocket message', err);
+        }
+      };
+      socket.onclose = () => {
+        if (!closed) setTimeout(connect, 3000);
+      };
+    };
+    connect();
+    const interval = setInterval(fetchOrders, 60000);
+    return () => {
+      closed = true;
+      socket?.close();
+      clearInterval(interval);
+    };
   }, []);
 
   const fetchMenu = async () => {
@@ -48,14 +78,25 @@ const DiningPage = () => {
   const fetchOrders = async () => {
     try {
       const data = await getGuestRestaurantOrders();
-      // Filter out delivered orders so they disappear from the active view
-      const activeOrders = (data || []).filter(order => order.status !== 'DELIVERED');
+      // Filter out closed orders so they disappear from the active view
+      const activeOrders = (data || []).filter(order => !CLOSED_STATUSES.includes(order.status));
       setOrders(activeOrders);
     } catch (err) {
       console.error("Failed to fetch orders", err);
     }
   };
 
+  // Cancels a RECEIVED order; once the kitchen has started, it asks them instead
+  const handleCancelOrder = async (orderId: number) => {
+    try {
+      await cancelRestaurantOrder(orderId);
+      fetchOrders();
+    } catch (err) {
+      console.error('Failed to cancel order', err);
+      setError('This order can no longer be cancelled.');
+    }
+  };
+
   const scrollToCategory = (catId: number) => {
     setActiveCategory(catId);
     const element = categoryRefs.current[catId];
@@ -143,6 +184,7 @@ const DiningPage = () => {
       case 'RECEIVED': return 'bg-blue-100 text-blue-800 border-blue-200';
       case 'PREPARING': return 'bg-amber-100 text-amber-800 border-amber-200';
       case 'READY': return 'bg-green-100 text-green-800 border-green-200';
+      case 'OUT_FOR_DELIVERY': return 'bg-indigo-100 text-indigo-800 border-indigo-200';
       case 'DELIVERED': return 'bg-slate-100 text-slate-800 border-slate-200';
       default: return 'bg-slate-100 text-slate-800';
     }
@@ -227,10 +269,15 @@ const DiningPage = () => {
                     <p className="text-xs text-slate-400 mt-1">
                       {new Date(order.created_at).toLocaleTimeString([], { hour: '2-digit', minute: '2-digit' })}
                     </p>
+                    {order.eta && (
+                      <p className="text-xs text-amber-600 mt-1">
+                        Arriving around {new Date(order.eta).toLocaleTimeString([], { hour: '2-digit', minute: '2-digit' })}
+                      </p>
+                    )}
                   </div>
                   <span className={`inline-flex items-center px-2.5 py-0.5 rounded-full text-xs font-medium border ${getStatusColor(order.status)}`}>
                     {getStatusIcon(order.status)}
-                    {order.status}
+                    {order.status.replace(/_/g, ' ')}
                   </span>
                 </div>
                 <div className="space-y-2">
@@ -249,6 +296,16 @@ const DiningPage = () => {
                   <span className="text-sm font-medium text-slate-900">Total</span>
                   <span className="text-sm font-bold text-amber-600">${order.total_price.toFixed(2)}</span>
                 </div>
+                {order.cancel_requested_at ? (
+                  <p className="mt-2 text-xs text-slate-500 italic">Cancellation requested, waiting for the kitchen</p>
+                ) : (['RECEIVED', 'PREPARING'].includes(order.status) && (
+                  <button
+                    onClick={() => handleCancelOrder(order.id)}
+                    className="mt-2 text-xs font-medium text-red-600 hover:text-red-700"
+                  >
+                    {order.status === 'RECEIVED' ? 'Cancel order' : 'Ask to cancel'}
+                  </button>
+                ))}
               </div>
             ))}
           </div>
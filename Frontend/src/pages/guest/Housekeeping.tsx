@@ -8,7 +8,8 @@ import {
   Sun, 
   Bath, 
   Droplets, 
-  Coffee, 
+  Footprints, 
+  Sparkles, 
   CheckCircle, 
   AlertCircle,
   Minus,
@@ -16,12 +17,14 @@ import {
   ChevronRight
 } from 'lucide-react';
 
+// Ids are the amenity catalog codes (seeded in migration 021)
 const AMENITIES = [
   { id: 'TOWEL', name: 'Towels', icon: Bath },
-  { id: 'SHAMPOO', name: 'Shampoo', icon: Droplets },
-  { id: 'WATER', name: 'Water', icon: Droplets },
-  { id: 'PILLOW', name: 'Pillows', icon: BedDouble },
-  { id: 'COFFEE', name: 'Coffee', icon: Coffee },
+  { id: 'EXTRA_PILLOW', name: 'Pillows', icon: BedDouble },
+  { id: 'BLANKET', name: 'Blanket', icon: Moon },
+  { id: 'TOOTHBRUSH_KIT', name: 'Toothbrush Kit', icon: Sparkles },
+  { id: 'WATER_BOTTLE', name: 'Water', icon: Droplets },
+  { id: 'SLIPPERS', name: 'Slippers', icon: Footprints },
 ];
 
 const ISSUE_TYPES = ['AC', 'Plumbing', 'Electric', 'Other'];
@@ -40,14 +43,14 @@ const HousekeepingPage = () => {
     setTimeout(() => setNotification(null), 3000);
   };
 
+  // Guests can only ask for a make-up or close the room; housekeeping reports it clean
   const handleStatusChange = async (status: 'REQUESTED_CLEANING' | 'DND') => {
-    if (!user?.room_number) return;
-    
-    const newStatus = status === roomStatus ? 'CLEAN' : status;
-    setRoomStatus(newStatus);
+    if (!user?.room_number || status === roomStatus) return;
+
+    setRoomStatus(status);
 
     try {
-      await requestCleaning(user.room_number, newStatus);
+      await requestCleaning(user.room_number, status);
       showNotification('success', `Room status updated`);
     } catch (err) {
       console.error(err);
@@ -72,7 +75,7 @@ const HousekeepingPage = () => {
     setLoading(true);
     try {
       await Promise.all(itemsToRequest.map(([item, qty]) => 
-        requestAmenity(user.id, user.room_number!, item, qty)
+        requestAmenity(user.room_number!, item, qty)
       ));
       
       setAmenityCounts({});
@@ -205,7 +208,7 @@ const HousekeepingPage = () => {
           transition={{ duration: 0.4, delay: 0.1 }}
         >
           <p className="text-xs tracking-widest text-slate-500 uppercase mb-6 font-semibold">Request Amenities</p>
-          <div className="grid grid-cols-2 md:grid-cols-3 lg:grid-cols-5 gap-4 mb-6">
+          <div className="grid grid-cols-2 md:grid-cols-3 lg:grid-cols-6 gap-4 mb-6">
             {AMENITIES.map((item) => (
               <div key={item.id} className="p-4 bg-white rounded-lg border border-slate-200 hover:border-slate-300 transition-colors">
                 <div className="flex justify-center mb-3">
@@ -137,6 +137,12 @@ const CheckoutManager = () => {
                   <span className="text-slate-600">Restaurant & Bar</span>
                   <span className="font-medium text-slate-900">{formatCurrency(bill.restaurant_total)}</span>
                 </div>
+                {!!bill.restaurant_comps && (
+                  <div className="flex justify-between text-sm">
+                    <span className="text-slate-500 pl-4">Comps & voids (not charged)</span>
+                    <span className="text-slate-500">-{formatCurrency(bill.restaurant_comps)}</span>
+                  </div>
+                )}
                 <div className="flex justify-between">
                   <span className="text-slate-600">Laundry</span>
                   <span className="font-medium text-slate-900">{formatCurrency(bill.laundry_total)}</span>
@@ -116,8 +116,24 @@ export const getGuestRestaurantOrders = async () => {
   return response.data;
 };
 
-export const getActiveOrders = async () => {
-  const response = await api.get<KitchenOrder[]>('/restaurant/orders/active');
+export const getActiveOrders = async (station = '') => {
+  const response = await api.get<KitchenOrder[]>('/restaurant/orders/active', { params: station ? { station } : {} });
+  return response.data;
+};
+
+export const bumpOrderItem = async (orderId: number, lineId: number, ready: boolean) => {
+  const url = `/restaurant/orders/${orderId}/items/${lineId}/bump`;
+  const response = ready ? await api.post<KitchenOrder>(url) : await api.delete<KitchenOrder>(url);
+  return response.data;
+};
+
+export const cancelRestaurantOrder = async (orderId: number, reason = '') => {
+  const response = await api.post<RestaurantOrder>(`/restaurant/orders/${orderId}/cancel`, { reason });
+  return response.data;
+};
+
+export const resolveCancelRequest = async (orderId: number, approve: boolean) => {
+  const response = await api.post<RestaurantOrder>(`/restaurant/orders/${orderId}/cancel-request/${approve ? 'approve' : 'reject'}`);
   return response.data;
 };
 
@@ -132,8 +148,8 @@ export const requestCleaning = async (roomNumber: string, status: string) => {
   return response.data;
 };
 
-export const requestAmenity = async (guestId: number, roomNumber: string, amenity: string, quantity: number) => {
-  const response = await api.post('/housekeeping/amenity', { guest_id: guestId, room_number: roomNumber, amenity, quantity });
+export const requestAmenity = async (roomNumber: string, amenity: string, quantity: number) => {
+  const response = await api.post('/housekeeping/amenity', { room_number: roomNumber, amenity, quantity });
   return response.data;
 };
 
@@ -179,6 +195,7 @@ export interface InvoicePreview {
   room_total: number;
   laundry_total: number;
   restaurant_total: number;
+  restaurant_comps?: number; // Comped and voided lines, not charged
   grand_total: number;
 }
 
@@ -74,6 +74,9 @@ export interface MenuItem {
   price: number;
   is_available: boolean;
   image_url?: string;
+  allergens?: string[];
+  dietary?: string[];
+  allergen_conflicts?: string[];
 }
 
 export interface CreateMenuItem {
@@ -115,15 +118,34 @@ export interface RestaurantOrder {
   status: string;
   total_price: number;
   created_at: string;
+  allergy_alert?: boolean;
+  allergens?: string[];
+  updated_at?: string;
+  cancel_reason?: string;
+  cancel_requested_at?: string; // Guest asked to cancel after cooking started
+  cancel_request_reason?: string;
+  adjusted_total?: number; // Comped and voided lines
+  eta?: string; // Sent with live ORDER_STATUS updates
   items: RestaurantOrderItem[];
 }
 
 export interface KitchenOrderItem {
+  id: number;
+  order_id: number;
   name: string;
   quantity: number;
   price: number;
+  allergens?: string[];
+  station: string;
+  status: 'PENDING' | 'READY';
+  ready_at?: string;
+  adjustment?: 'COMP' | 'VOID';
+  adjust_reason?: string;
+  adjust_note?: string;
 }
 
 export interface KitchenOrder extends RestaurantOrder {
   items: KitchenOrderItem[];
+  age_minutes: number;
+  late: boolean;
 }